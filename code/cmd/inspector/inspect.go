@@ -6,22 +6,17 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var (
-	// inspect命令的配置选项
-	inspectKubeconfig  string
-	inspectContextName string
-	inspectOutputFormat string
-	inspectNoColor     bool
-	inspectRulesFile   string
-	inspectOutputFile  string
-	inspectOnlyIssues  bool
-)
+// inspectOptions inspect命令及其子命令的配置选项
+var inspectOptions = inspect.NewOptions()
 
 // inspectCmd 表示资源检查命令
 var inspectCmd = &cobra.Command{
 	Use:   "inspect",
 	Short: "检查Kubernetes资源",
 	Long:  `检查Kubernetes集群中的资源状态并生成详细报告，可以检测资源配置问题和潜在风险。`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return inspectOptions.ValidateFailureGate()
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		// 默认显示帮助信息
 		if err := cmd.Help(); err != nil {
@@ -32,51 +27,39 @@ var inspectCmd = &cobra.Command{
 
 func init() {
 	// 添加标志
-	inspectCmd.PersistentFlags().StringVar(&inspectKubeconfig, "kubeconfig", "", "kubeconfig文件路径")
-	inspectCmd.PersistentFlags().StringVar(&inspectContextName, "context", "", "要使用的kubeconfig上下文")
-	inspectCmd.PersistentFlags().StringVar(&inspectOutputFormat, "output", "text", "报告输出格式 (text, json, yaml)")
-	inspectCmd.PersistentFlags().BoolVar(&inspectNoColor, "no-color", false, "禁用颜色输出")
-	inspectCmd.PersistentFlags().StringVar(&inspectRulesFile, "rules-file", "", "自定义规则配置文件路径")
-	inspectCmd.PersistentFlags().StringVarP(&inspectOutputFile, "output-file", "o", "", "将报告写入文件而不是标准输出")
-	inspectCmd.PersistentFlags().BoolVar(&inspectOnlyIssues, "only-issues", false, "只显示有问题的资源")
-	
-	// 添加子命令 - 使用inspect包中的NewNodeCommand函数
-	inspectCmd.AddCommand(inspect.NewNodeCommand(
-		&inspectKubeconfig, 
-		&inspectContextName,
-		&inspectOutputFormat,
-		&inspectNoColor,
-		&inspectOnlyIssues,
-		&inspectRulesFile,
-		&inspectOutputFile,
-	))
-	
+	inspectCmd.PersistentFlags().StringVar(&inspectOptions.Kubeconfig, "kubeconfig", "", "kubeconfig文件路径")
+	inspectCmd.PersistentFlags().StringVar(&inspectOptions.Context, "context", "", "要使用的kubeconfig上下文")
+	inspectCmd.PersistentFlags().StringVar(&inspectOptions.OutputFormat, "output", "text", "报告输出格式 (text, json, yaml, sarif)")
+	inspectCmd.PersistentFlags().BoolVar(&inspectOptions.NoColor, "no-color", false, "禁用颜色输出")
+	inspectCmd.PersistentFlags().StringArrayVar(&inspectOptions.RulesFiles, "rules-file", nil, "自定义规则配置文件或目录路径，可重复指定，后面的规则按ID覆盖前面的规则")
+	inspectCmd.PersistentFlags().StringVarP(&inspectOptions.OutputFile, "output-file", "o", "", "将报告写入文件而不是标准输出")
+	inspectCmd.PersistentFlags().BoolVar(&inspectOptions.OnlyIssues, "only-issues", false, "只显示有问题的资源")
+	inspectCmd.PersistentFlags().StringVar(&inspectOptions.StateFile, "state-file", "", "持续条件状态文件路径 (默认为$HOME/.k8s-inspector/state.json)")
+	inspectCmd.PersistentFlags().StringVar(&inspectOptions.WaiverFile, "waiver-file", "", "豁免文件路径，被有效豁免的发现项单独列出")
+	inspectCmd.PersistentFlags().BoolVar(&inspectOptions.ExplainScore, "explain-score", false, "在报告中列出构成健康评分的每一项扣分")
+	inspectCmd.PersistentFlags().StringArrayVar(&inspectOptions.RulePacks, "rule-pack", nil, "与默认规则包一起加载的内置规则包，如 security，可重复指定")
+	inspectCmd.PersistentFlags().StringSliceVar(&inspectOptions.Tags, "tag", nil, "只使用包含任一指定标签的规则，可重复指定或用逗号分隔")
+	inspectCmd.PersistentFlags().StringSliceVar(&inspectOptions.Controls, "control", nil, "只使用对应任一指定控制项的规则，格式为 framework 或 framework:id，如 cis-kubernetes:5.2.2")
+	inspectCmd.PersistentFlags().StringVar(&inspectOptions.Environment, "env", "", "指定巡检使用的环境（如 prod、staging），覆盖规则配置中按集群确定的环境")
+	inspectCmd.PersistentFlags().StringVar(&inspectOptions.Snapshot, "from-snapshot", "", "从集群快照离线分析，不连接集群；快照可以是 inspector snapshot 生成的归档、kubectl get -o json/yaml 导出的文件或包含这些文件的目录")
+	inspectCmd.PersistentFlags().StringVar(&inspectOptions.FailOn, "fail-on", "", "存在该级别及以上的未豁免发现项时以状态码 2 退出 (critical, error, warning, info)")
+	inspectCmd.PersistentFlags().IntVar(&inspectOptions.MaxFindings, "max-findings", -1, "允许的未豁免发现项数量（只统计 --fail-on 级别及以上），超过时以状态码 2 退出，-1 表示不限制")
+	inspectCmd.PersistentFlags().DurationVar(&inspectOptions.Watch, "watch", 0, "按指定间隔（如 5m）重复巡检直到收到中断信号，规则配置启用 autoReload 时在后台重载规则；0 表示只巡检一次")
+
+	// 添加节点检查命令
+	inspectCmd.AddCommand(inspect.NewNodeCommand(inspectOptions))
+
 	// 添加Pod检查命令
-	inspectCmd.AddCommand(inspect.NewPodCommand(
-		&inspectKubeconfig, 
-		&inspectContextName,
-		&inspectOutputFormat,
-		&inspectNoColor,
-		&inspectOnlyIssues,
-		&inspectRulesFile,
-		&inspectOutputFile,
-	))
-	
+	inspectCmd.AddCommand(inspect.NewPodCommand(inspectOptions))
+
 	// 添加Deployment检查命令
-	inspectCmd.AddCommand(inspect.NewDeploymentCommand(
-		&inspectKubeconfig,
-		&inspectContextName,
-		&inspectRulesFile,
-		&inspectNoColor,
-	))
+	inspectCmd.AddCommand(inspect.NewDeploymentCommand(inspectOptions))
 
 	// 添加Service检查命令
-	inspectCmd.AddCommand(inspect.NewServiceCommand(
-		&inspectKubeconfig,
-		&inspectContextName,
-		&inspectRulesFile,
-		&inspectNoColor,
-	))
+	inspectCmd.AddCommand(inspect.NewServiceCommand(inspectOptions))
+
+	// 添加任意资源类型检查命令
+	inspectCmd.AddCommand(inspect.NewResourceCommand(inspectOptions))
 
 	// 添加inspect命令到根命令
 	rootCmd.AddCommand(inspectCmd)
@@ -0,0 +1,44 @@
+package inspect
+
+import (
+	"fmt"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/snapshot"
+)
+
+// offline 判断是否从快照离线分析
+func (o *Options) offline() bool {
+	return o.Snapshot != ""
+}
+
+// newClusterClient 创建巡检使用的集群客户端并返回集群名称；指定 --from-snapshot 时从快照构造离线客户端，
+// 集群名称为 --context 或快照名称，否则连接 kubeconfig 中的集群，集群名称为 --context 或 default-cluster；
+// 无法加载快照或连接集群时返回的错误退出状态码为 ExitClusterUnreachable
+func (o *Options) newClusterClient() (*cluster.Client, string, error) {
+	kubeconfig, contextName := o.Kubeconfig, o.Context
+	if o.offline() {
+		client, err := snapshot.Load(o.Snapshot)
+		if err != nil {
+			return nil, "", withExitCode(ExitClusterUnreachable, fmt.Errorf("加载集群快照失败: %w", err))
+		}
+		if contextName != "" {
+			client.ContextName = contextName
+		}
+		return client, client.ContextName, nil
+	}
+
+	client, err := cluster.NewClient(kubeconfig, contextName)
+	if err != nil {
+		return nil, "", withExitCode(ExitClusterUnreachable, fmt.Errorf("创建集群客户端失败: %w", err))
+	}
+	// 先确认集群可以访问，使无法连接集群的错误与检查过程中的其他错误区分开
+	if _, err := client.GetServerVersion(); err != nil {
+		return nil, "", withExitCode(ExitClusterUnreachable, fmt.Errorf("连接集群失败: %w", err))
+	}
+	clusterName := "default-cluster"
+	if contextName != "" {
+		clusterName = contextName
+	}
+	return client, clusterName, nil
+}
@@ -0,0 +1,126 @@
+package inspect
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/configs"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// newRulesEngine 创建规则引擎，rulesFiles 可以是多个规则文件或目录，按顺序合并；
+// 未指定时使用编译进二进制的默认规则包（defaultPack 为空时使用全部规则包）以及 --rule-pack 指定的规则包，
+// 按 --env 或集群名称确定环境；规则作用范围使用了命名空间标签选择器时，从集群读取命名空间标签。
+// 不读取持续条件的状态文件，也不启动自动重载；规则文件或规则包无效时返回的错误退出状态码为 ExitRulesInvalid
+func (o *Options) newRulesEngine(client *cluster.Client, clusterName string, defaultPack string) (*rules.Engine, error) {
+	packs, rulesFiles := o.RulePacks, o.RulesFiles
+	if len(packs) > 0 && len(rulesFiles) > 0 {
+		return nil, fmt.Errorf("--rule-pack 不能与 --rules-file 同时使用，可将规则包导出后作为规则文件指定")
+	}
+
+	var rulesEngine *rules.Engine
+	var err error
+	if len(rulesFiles) > 0 {
+		rulesEngine, err = rules.NewEngine(rulesFiles...)
+	} else if defaultPack == "" {
+		rulesEngine, err = rules.NewEngineFromFS(configs.RulesFS, configs.RulesDir)
+	} else {
+		var paths []string
+		paths, err = rulePackPaths(append([]string{defaultPack}, packs...))
+		if err == nil {
+			rulesEngine, err = rules.NewEngineFromFS(configs.RulesFS, paths...)
+		}
+	}
+	if err != nil {
+		return nil, withExitCode(ExitRulesInvalid, fmt.Errorf("加载规则引擎失败: %w", err))
+	}
+	rulesEngine.SelectRules(o.Tags, o.Controls)
+	rulesEngine.SetEnvironment(o.resolveEnvironment(client, rulesEngine, clusterName))
+
+	if rulesEngine.UsesNamespaceSelectors() {
+		namespaceLabels, err := client.ListNamespaceLabels(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("获取命名空间标签失败: %w", err)
+		}
+		rulesEngine.SetNamespaceLabels(namespaceLabels)
+	}
+
+	return rulesEngine, nil
+}
+
+// loadRulesEngine 按 newRulesEngine 创建巡检使用的规则引擎，并从状态文件恢复持续条件的状态；
+// 规则配置中的 autoReload 只在 --watch 模式下由 run 启动
+func (o *Options) loadRulesEngine(client *cluster.Client, clusterName string, defaultPack string) (*rules.Engine, error) {
+	rulesEngine, err := o.newRulesEngine(client, clusterName, defaultPack)
+	if err != nil {
+		return nil, err
+	}
+
+	path := rules.DefaultStateFile()
+	if o.StateFile != "" {
+		path = o.StateFile
+	}
+	store, err := rules.LoadStateStore(path)
+	if err != nil {
+		return nil, fmt.Errorf("加载条件状态失败: %w", err)
+	}
+	rulesEngine.SetStateStore(store)
+
+	return rulesEngine, nil
+}
+
+// resolveEnvironment 确定巡检使用的环境：--env 优先，否则按规则配置中的集群映射确定；
+// 配置了 config.environmentLabel 时读取 kube-system 命名空间的标签，读取失败时只输出警告
+func (o *Options) resolveEnvironment(client *cluster.Client, rulesEngine *rules.Engine, clusterName string) string {
+	if o.Environment != "" {
+		return o.Environment
+	}
+	var clusterLabels map[string]string
+	if rulesEngine.EnvironmentLabel() != "" {
+		labels, err := client.GetNamespaceLabels(context.Background(), rules.KubeSystemNamespace)
+		if err != nil {
+			fmt.Fprint(os.Stderr, i18n.Sprintf("警告: 读取 %s 命名空间标签失败，无法按标签确定环境: %v\n", rules.KubeSystemNamespace, err))
+		}
+		clusterLabels = labels
+	}
+	return rulesEngine.ResolveEnvironment(clusterName, clusterLabels)
+}
+
+// rulePackPaths 返回内置规则包的路径，重复的规则包只加载一次
+func rulePackPaths(packs []string) ([]string, error) {
+	available, err := configs.RulePacks()
+	if err != nil {
+		return nil, fmt.Errorf("读取内置规则包失败: %w", err)
+	}
+	seen := make(map[string]bool, len(packs))
+	paths := make([]string, 0, len(packs))
+	for _, pack := range packs {
+		if seen[pack] {
+			continue
+		}
+		seen[pack] = true
+		found := false
+		for _, name := range available {
+			if name == pack {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("未知的规则包 %q (可用: %s)", pack, strings.Join(available, ", "))
+		}
+		paths = append(paths, configs.RulePackPath(pack))
+	}
+	return paths, nil
+}
+
+// saveConditionState 保存持续条件状态，失败时仅输出警告
+func saveConditionState(rulesEngine *rules.Engine) {
+	if err := rulesEngine.SaveState(); err != nil {
+		fmt.Fprint(os.Stderr, i18n.Sprintf("警告: 保存条件状态失败: %v\n", err))
+	}
+}
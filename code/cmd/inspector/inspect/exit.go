@@ -0,0 +1,112 @@
+package inspect
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+)
+
+// 检查命令的退出状态码，供CI和定时任务区分失败原因
+const (
+	// ExitOK 检查完成，发现项没有超过 --fail-on 和 --max-findings 设定的阈值
+	ExitOK = 0
+	// ExitError 参数错误、输出失败等其他错误
+	ExitError = 1
+	// ExitViolations 发现项超过 --fail-on 和 --max-findings 设定的阈值
+	ExitViolations = 2
+	// ExitRulesInvalid 规则文件或规则包无效
+	ExitRulesInvalid = 3
+	// ExitClusterUnreachable 无法连接集群或无法加载集群快照
+	ExitClusterUnreachable = 4
+)
+
+// exitError 带有退出状态码的错误
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode 为错误附加退出状态码，err 为 nil 时返回 nil
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+// ExitCode 返回错误对应的退出状态码：附加了状态码的错误使用该状态码，
+// 访问集群API的网络错误视为无法连接集群，其他错误为 ExitError
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return ExitClusterUnreachable
+	}
+	return ExitError
+}
+
+// exitWithError 输出错误信息并以错误对应的状态码退出；发现项超过阈值时只输出阈值说明，其他错误输出 message
+func exitWithError(err error, message string) {
+	if ExitCode(err) == ExitViolations {
+		message = err.Error() + "\n"
+	}
+	fmt.Fprint(os.Stderr, message)
+	os.Exit(ExitCode(err))
+}
+
+// ValidateFailureGate 校验 --fail-on 指定的严重性级别，在执行检查前发现参数错误
+func (o *Options) ValidateFailureGate() error {
+	if o.FailOn == "" {
+		return nil
+	}
+	_, err := report.ParseSeverity(o.FailOn)
+	return err
+}
+
+// CheckFindings 按 --fail-on 和 --max-findings 检查报告中未豁免的发现项，超过阈值时返回退出状态码为 ExitViolations 的错误；
+// 未指定阈值时 always 为 false 则不检查，为 true 则存在任何发现项即失败
+func (o *Options) CheckFindings(r *report.Report, always bool) error {
+	severity, allowed := o.FailOn, o.MaxFindings
+	if severity == "" && allowed < 0 && !always {
+		return nil
+	}
+
+	threshold := report.SeverityInfo
+	if severity != "" {
+		parsed, err := report.ParseSeverity(severity)
+		if err != nil {
+			return err
+		}
+		threshold = parsed
+	}
+
+	count := r.CountAtOrAbove(threshold)
+	if allowed < 0 {
+		if count == 0 {
+			return nil
+		}
+		return withExitCode(ExitViolations, errors.New(i18n.Sprintf("发现 %d 个 %s 及以上级别的问题", count, threshold)))
+	}
+	if count <= allowed {
+		return nil
+	}
+	return withExitCode(ExitViolations, errors.New(i18n.Sprintf("发现 %d 个 %s 及以上级别的问题，超过 --max-findings 允许的 %d 个", count, threshold, allowed)))
+}
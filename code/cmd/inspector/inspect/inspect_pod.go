@@ -3,27 +3,24 @@ package inspect
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/pod"
-	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
-	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
 	"github.com/spf13/cobra"
 )
 
-var (
-	// Pod命令特有的选项
-	namespace  string
-	fetchLogs  bool
-	logLines   int
-	liveLogs   bool
-)
-
 // NewPodCommand 创建Pod检查命令
-func NewPodCommand(kubecfg, ctx, outFmt *string, noClr, onlyIss *bool, rFile, outFile *string) *cobra.Command {
+func NewPodCommand(opts *Options) *cobra.Command {
+	// Pod命令特有的选项
+	var (
+		namespace string
+		fetchLogs bool
+		logLines  int
+		liveLogs  bool
+	)
 	cmd := &cobra.Command{
 		Use:   "pod [pod名称] [-n 命名空间]",
 		Short: "检查Pod资源并生成报告",
@@ -35,9 +32,8 @@ func NewPodCommand(kubecfg, ctx, outFmt *string, noClr, onlyIss *bool, rFile, ou
 				podName = args[0]
 			}
 
-			if err := runPodInspect(podName, namespace, *kubecfg, *contextName, *outputFormat, *noColor, *onlyIssues, *rulesFile, *outputFile, fetchLogs, logLines, liveLogs); err != nil {
-				fmt.Fprintf(os.Stderr, "检查Pod失败: %v\n", err)
-				os.Exit(1)
+			if err := runPodInspect(opts, podName, namespace, fetchLogs, logLines, liveLogs); err != nil {
+				exitWithError(err, i18n.Sprintf("检查Pod失败: %v\n", err))
 			}
 		},
 	}
@@ -52,151 +48,140 @@ func NewPodCommand(kubecfg, ctx, outFmt *string, noClr, onlyIss *bool, rFile, ou
 }
 
 // runPodInspect 执行Pod检查逻辑
-func runPodInspect(podName, namespace, kubeconfig, contextName, outputFormat string, noColor, onlyIssues bool, rulesFile, outputFile string, fetchLogs bool, logLines int, liveLogs bool) error {
-	// 创建集群客户端
-	client, err := cluster.NewClient(kubeconfig, contextName)
-	if err != nil {
-		return fmt.Errorf("创建集群客户端失败: %w", err)
-	}
-
-	// 获取集群信息
-	clusterName := "default-cluster"
-	if contextName != "" {
-		clusterName = contextName
+func runPodInspect(opts *Options, podName, namespace string, fetchLogs bool, logLines int, liveLogs bool) error {
+	// 快照中没有容器日志
+	if opts.offline() && (fetchLogs || liveLogs) {
+		return fmt.Errorf("--fetch-logs 和 --live-logs 不能与 --from-snapshot 同时使用")
 	}
 
-	// 加载规则配置
-	var rulesEngine *rules.Engine
-	if rulesFile != "" {
-		// 使用用户提供的规则文件
-		rulesEngine, err = rules.NewEngine(rulesFile)
-	} else {
-		// 使用默认规则文件
-		defaultRulesPath := filepath.Join("code", "configs", "rules", "pod.yaml")
-		rulesEngine, err = rules.NewEngine(defaultRulesPath)
+	// 创建集群客户端
+	client, clusterName, err := opts.newClusterClient()
+	if err != nil {
+		return err
 	}
 
+	// 加载规则
+	rulesEngine, err := opts.loadRulesEngine(client, clusterName, "pod")
 	if err != nil {
-		return fmt.Errorf("加载规则引擎失败: %w", err)
+		return err
 	}
 
-	// 创建分析器并设置客户端
-	analyzer := pod.NewPodAnalyzer(rulesEngine)
-	// 创建 podCollector 并注入 analyzer
-	podCollector, _ := collector.NewPodCollector(client)
-	analyzer.SetCollector(podCollector)
-
-	// 分析Pod
-	var results []*pod.AnalysisResult
-	if podName != "" {
-		// 分析单个Pod
-		result, err := analyzer.AnalyzePodByName(namespace, podName)
+	// 执行分析，指定 --watch 时按间隔重复执行
+	return opts.run(client, clusterName, rulesEngine, func() error {
+		// 加载豁免
+		waivers, err := opts.loadWaivers()
 		if err != nil {
-			return fmt.Errorf("分析Pod %s/%s 失败: %w", namespace, podName, err)
+			return err
 		}
-		results = []*pod.AnalysisResult{result}
-
-		// 如果需要获取日志
-		if fetchLogs {
-			for _, container := range result.Containers {
-				logs, err := podCollector.GetPodLogs(context.TODO(), namespace, podName, container.Name, logLines)
-				if err != nil {
-					fmt.Printf("警告: 获取容器 %s 日志失败: %v\n", container.Name, err)
-					continue
-				}
-				fmt.Printf("容器 %s 日志:\n", container.Name)
-				for _, line := range logs {
-					fmt.Println(line)
+
+		// 创建分析器并设置客户端
+		analyzer := pod.NewPodAnalyzer(rulesEngine)
+		// 创建 podCollector 并注入 analyzer
+		podCollector, _ := collector.NewPodCollector(client)
+		analyzer.SetCollector(podCollector)
+
+		// 分析Pod
+		var results []*pod.AnalysisResult
+		if podName != "" {
+			// 分析单个Pod
+			result, err := analyzer.AnalyzePodByName(namespace, podName)
+			if err != nil {
+				return fmt.Errorf("分析Pod %s/%s 失败: %w", namespace, podName, err)
+			}
+			results = []*pod.AnalysisResult{result}
+
+			// 如果需要获取日志
+			if fetchLogs {
+				for _, container := range result.Containers {
+					logs, err := podCollector.GetPodLogs(context.TODO(), namespace, podName, container.Name, logLines)
+					if err != nil {
+						fmt.Print(i18n.Sprintf("警告: 获取容器 %s 日志失败: %v\n", container.Name, err))
+						continue
+					}
+					fmt.Print(i18n.Sprintf("容器 %s 日志:\n", container.Name))
+					for _, line := range logs {
+						fmt.Println(line)
+					}
 				}
 			}
+		} else {
+			// 分析命名空间中的所有Pod
+			results, err = analyzer.AnalyzePodsInNamespace(namespace)
+			if err != nil {
+				return fmt.Errorf("分析命名空间 %s 中的Pod失败: %w", namespace, err)
+			}
 		}
-	} else {
-		// 分析命名空间中的所有Pod
-		results, err = analyzer.AnalyzePodsInNamespace(namespace)
-		if err != nil {
-			return fmt.Errorf("分析命名空间 %s 中的Pod失败: %w", namespace, err)
-		}
-	}
 
-	// 过滤结果（如果只显示有问题的资源）
-	if onlyIssues {
-		filteredResults := []*pod.AnalysisResult{}
-		for _, result := range results {
-			// 检查是否有未通过的分析项
-			hasIssues := false
-			for _, item := range result.Items {
-				if !item.Passed {
-					hasIssues = true
-					break
+		// 保存持续条件状态，供下次巡检判断条件是否持续成立
+		saveConditionState(rulesEngine)
+
+		// 过滤结果（如果只显示有问题的资源）
+		if opts.OnlyIssues {
+			filteredResults := []*pod.AnalysisResult{}
+			for _, result := range results {
+				// 检查是否有未通过的分析项
+				hasIssues := false
+				for _, item := range result.Items {
+					if !item.Passed {
+						hasIssues = true
+						break
+					}
+				}
+				if hasIssues {
+					filteredResults = append(filteredResults, result)
 				}
 			}
-			if hasIssues {
-				filteredResults = append(filteredResults, result)
-			}
+			results = filteredResults
 		}
-		results = filteredResults
-	}
 
-	// 获取规则列表
-	filter := rules.RuleFilter{}
-	rulesList := rulesEngine.GetRules(filter)
-
-	// 创建报告生成器
-	reportGenerator := report.NewGenerator(clusterName, namespace)
-	podReport := reportGenerator.GeneratePodReport(results, rulesList)
-
-	// 创建格式化器
-	var formatter report.Formatter
-	switch outputFormat {
-	case "text":
-		formatter = report.NewTextFormatter(!noColor)
-	default:
-		return fmt.Errorf("不支持的输出格式: %s", outputFormat)
-	}
+		// 获取规则列表
+		filter := rules.RuleFilter{}
+		rulesList := rulesEngine.GetRules(filter)
 
-	// 格式化报告
-	output := formatter.Format(podReport)
+		// 创建报告生成器
+		reportGenerator := report.NewGenerator(clusterName, namespace)
+		podReport := reportGenerator.GeneratePodReport(results, rulesList)
 
-	// 输出报告
-	if outputFile != "" {
-		// 写入文件
-		err = os.WriteFile(outputFile, []byte(output), 0644)
-		if err != nil {
-			return fmt.Errorf("写入报告到文件失败: %w", err)
+		// 应用豁免文件和资源注解中的豁免
+		for _, result := range results {
+			waivers.AddAnnotations("Pod", result.Namespace+"/"+result.PodName, result.Annotations)
 		}
-		fmt.Printf("报告已写入文件: %s\n", outputFile)
-	} else {
-		// 输出到标准输出
-		fmt.Println(output)
-	}
+		applyWaivers(podReport, waivers)
 
-	// 如果启用了实时日志并且有问题Pod
-	if liveLogs && onlyIssues && len(results) > 0 {
-		fmt.Println("\n=== 问题Pod的实时日志 ===")
-		for _, result := range results {
-			for _, item := range result.Items {
-				if !item.Passed {
-					fmt.Printf("\nPod %s/%s 有问题: %s\n", result.Namespace, result.PodName, item.Description)
-					
-					// 获取该Pod的所有容器
-					for _, container := range result.Containers {
-						logs, err := podCollector.GetPodLogs(context.TODO(), result.Namespace, result.PodName, container.Name, logLines)
-						if err != nil {
-							fmt.Printf("警告: 获取容器 %s 日志失败: %v\n", container.Name, err)
-							continue
-						}
-						fmt.Printf("容器 %s 最新日志:\n", container.Name)
-						for _, line := range logs {
-							fmt.Println(line)
+		// 输出报告
+		if err := opts.writeReport(podReport); err != nil {
+			return err
+		}
+
+		// 如果启用了实时日志并且有问题Pod
+		if liveLogs && opts.OnlyIssues && len(results) > 0 {
+			fmt.Println(i18n.T("\n=== 问题Pod的实时日志 ==="))
+			for _, result := range results {
+				for _, item := range result.Items {
+					if !item.Passed {
+						fmt.Print(i18n.Sprintf("\nPod %s/%s 有问题: %s\n", result.Namespace, result.PodName, item.Description))
+
+						// 获取该Pod的所有容器
+						for _, container := range result.Containers {
+							logs, err := podCollector.GetPodLogs(context.TODO(), result.Namespace, result.PodName, container.Name, logLines)
+							if err != nil {
+								fmt.Print(i18n.Sprintf("警告: 获取容器 %s 日志失败: %v\n", container.Name, err))
+								continue
+							}
+							fmt.Print(i18n.Sprintf("容器 %s 最新日志:\n", container.Name))
+							for _, line := range logs {
+								fmt.Println(line)
+							}
+							fmt.Println()
 						}
-						fmt.Println()
+
+						break
 					}
-					
-					break
 				}
 			}
 		}
-	}
 
-	return nil
-} 
\ No newline at end of file
+		// 按发现项阈值确定检查结果
+		return opts.CheckFindings(podReport, false)
+	})
+}
@@ -0,0 +1,111 @@
+package inspect
+
+import (
+	"fmt"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/generic"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+// NewResourceCommand 创建任意资源类型（包括CRD）的检查命令
+func NewResourceCommand(opts *Options) *cobra.Command {
+	var namespace string
+	cmd := &cobra.Command{
+		Use:   "resource <group/version/kind>",
+		Short: "检查任意资源类型（包括CRD）并生成报告",
+		Long: `通过 dynamic client 获取任意资源类型（包括CRD，如 cert-manager.io/v1/Certificate）并评估规则，
+规则的 category 为资源 Kind 的小写形式（如 certificate），只能使用通用指标 name、namespace、has_labels、has_annotations 和 jsonpath。
+核心组资源可以写成 version/kind，如 v1/ConfigMap。`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runResourceInspect(opts, args[0], namespace); err != nil {
+				exitWithError(err, i18n.Sprintf("检查资源失败: %v\n", err))
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "只检查指定命名空间的资源，默认检查所有命名空间")
+	return cmd
+}
+
+// runResourceInspect 执行任意资源类型的检查逻辑
+func runResourceInspect(opts *Options, resourceType, namespace string) error {
+	gvk, err := cluster.ParseGroupVersionKind(resourceType)
+	if err != nil {
+		return err
+	}
+
+	client, clusterName, err := opts.newClusterClient()
+	if err != nil {
+		return err
+	}
+
+	// 加载规则，未指定规则文件时使用全部内置规则包
+	rulesEngine, err := opts.loadRulesEngine(client, clusterName, "")
+	if err != nil {
+		return err
+	}
+
+	// 执行分析，指定 --watch 时按间隔重复执行
+	return opts.run(client, clusterName, rulesEngine, func() error {
+		// 加载豁免
+		waivers, err := opts.loadWaivers()
+		if err != nil {
+			return err
+		}
+
+		// 创建分析器并注入采集器
+		analyzer := generic.NewResourceAnalyzer(rulesEngine, collector.NewResourceCollector(client))
+
+		results, err := analyzer.AnalyzeResources(gvk, namespace)
+		if err != nil {
+			return fmt.Errorf("分析%s失败: %w", gvk.Kind, err)
+		}
+
+		// 保存持续条件状态，供下次巡检判断条件是否持续成立
+		saveConditionState(rulesEngine)
+
+		// 过滤结果（如果只显示有问题的资源）
+		if opts.OnlyIssues {
+			filteredResults := []*generic.AnalysisResult{}
+			for _, result := range results {
+				for _, item := range result.Items {
+					if !item.Passed {
+						filteredResults = append(filteredResults, result)
+						break
+					}
+				}
+			}
+			results = filteredResults
+		}
+
+		// 获取规则列表
+		rulesList := rulesEngine.GetRules(rules.RuleFilter{Categories: []string{generic.RuleCategory(gvk.Kind)}})
+
+		// 创建报告生成器
+		reportGenerator := report.NewGenerator(clusterName, namespace)
+		resourceReport := reportGenerator.GenerateResourceReport(results, rulesList)
+
+		// 应用豁免文件和资源注解中的豁免
+		for _, result := range results {
+			resource := result.ResourceName
+			if result.Namespace != "" {
+				resource = result.Namespace + "/" + result.ResourceName
+			}
+			waivers.AddAnnotations(result.Resource.Kind, resource, result.Resource.Annotations)
+		}
+		applyWaivers(resourceReport, waivers)
+
+		// 输出报告
+		if err := opts.writeReport(resourceReport); err != nil {
+			return err
+		}
+
+		// 按发现项阈值确定检查结果
+		return opts.CheckFindings(resourceReport, false)
+	})
+}
@@ -1,185 +1,105 @@
 package inspect
 
 import (
-	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 
-	"github.com/fatih/color"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/service"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
-	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
 	"github.com/spf13/cobra"
 )
 
-// 共享配置选项
-var (
-	svcKubeconfig   *string
-	svcContextName  *string
-	svcRulesFile    *string
-	svcNoColor      *bool
-)
-
-// 颜色对象
-var (
-	svcRedColor    = color.New(color.FgRed, color.Bold)
-	svcGreenColor  = color.New(color.FgGreen, color.Bold)
-	svcYellowColor = color.New(color.FgYellow, color.Bold)
-)
-
-// 颜色工具函数
-func svcColoredFail(text string) string {
-	if svcNoColor != nil && *svcNoColor {
-		return text
-	}
-	return svcRedColor.Sprint(text)
-}
-
-func svcColoredSuccess(text string) string {
-	if svcNoColor != nil && *svcNoColor {
-		return text
-	}
-	return svcGreenColor.Sprint(text)
-}
-
-func svcColoredWarning(text string) string {
-	if svcNoColor != nil && *svcNoColor {
-		return text
-	}
-	return svcYellowColor.Sprint(text)
-}
-
-func NewServiceCommand(kubecfg, ctx *string, rFile *string, noColor *bool) *cobra.Command {
-	svcKubeconfig = kubecfg
-	svcContextName = ctx
-	svcRulesFile = rFile
-	svcNoColor = noColor
-
+// NewServiceCommand 创建Service检查命令
+func NewServiceCommand(opts *Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "service",
 		Short: "检查Service资源并生成报告",
 		Long:  `检查Kubernetes集群中的Service资源配置与合规性，并生成详细报告。`,
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := runServiceInspect(); err != nil {
-				fmt.Fprintf(os.Stderr, "检查Service失败: %v\n", err)
-				os.Exit(1)
+			if err := runServiceInspect(opts); err != nil {
+				exitWithError(err, i18n.Sprintf("检查Service失败: %v\n", err))
 			}
 		},
 	}
 	return cmd
 }
 
-func runServiceInspect() error {
-	client, err := cluster.NewClient(*svcKubeconfig, *svcContextName)
+// runServiceInspect 执行Service检查逻辑
+func runServiceInspect(opts *Options) error {
+	client, clusterName, err := opts.newClusterClient()
 	if err != nil {
-		return fmt.Errorf("创建集群客户端失败: %w", err)
+		return err
 	}
-	collectorInst := collector.NewServiceCollector(client)
 
 	// 加载规则
-	var rulesEngine *rules.Engine
-	if *svcRulesFile != "" {
-		rulesEngine, err = rules.NewEngine(*svcRulesFile)
-	} else {
-		// 使用默认规则文件
-		defaultRulesPath := filepath.Join("code", "configs", "rules", "service.yaml")
-		rulesEngine, err = rules.NewEngine(defaultRulesPath)
-	}
+	rulesEngine, err := opts.loadRulesEngine(client, clusterName, "service")
 	if err != nil {
-		return fmt.Errorf("加载规则引擎失败: %w", err)
+		return err
 	}
 
-	// 获取所有命名空间的Service
-	namespaces := []string{"default", "kube-system", "kube-public", "kube-node-lease"}
-	
-	// 获取规则列表
-	ruleFilter := rules.RuleFilter{
-		Categories: []string{"service"},
-	}
-	rulesList := rulesEngine.GetRules(ruleFilter)
-
-	for _, namespace := range namespaces {
-		services, err := collectorInst.GetServices(context.TODO(), namespace)
+	// 执行分析，指定 --watch 时按间隔重复执行
+	return opts.run(client, clusterName, rulesEngine, func() error {
+		// 加载豁免
+		waivers, err := opts.loadWaivers()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "获取命名空间 %s 的Service失败: %v\n", namespace, err)
-			continue
+			return err
 		}
 
-		if len(services) == 0 {
-			continue
+		// 创建分析器并注入采集器
+		analyzer := service.NewServiceAnalyzer(rulesEngine, collector.NewServiceCollector(client))
+
+		// 获取所有命名空间的Service
+		namespaces := []string{"default", "kube-system", "kube-public", "kube-node-lease"}
+
+		var results []*service.AnalysisResult
+		for _, namespace := range namespaces {
+			nsResults, err := analyzer.AnalyzeServicesInNamespace(namespace)
+			if err != nil {
+				fmt.Fprint(os.Stderr, i18n.Sprintf("获取命名空间 %s 的Service失败: %v\n", namespace, err))
+				continue
+			}
+			results = append(results, nsResults...)
 		}
 
-		// 分析与规则适配
-		for _, svc := range services {
-			hasIssues := false
-			var failedChecks []string
-			
-			for _, rule := range rulesList {
-				var actualValue interface{}
-				var metricType string
-				
-				analyzer := service.NewServiceAnalyzer()
-				
-				switch rule.Condition.Metric {
-				case "is_loadbalancer_type":
-					actualValue = analyzer.IsLoadBalancerType(&svc)
-					metricType = "boolean"
-				case "is_nodeport_type":
-					actualValue = analyzer.IsNodePortType(&svc)
-					metricType = "boolean"
-				case "min_port":
-					actualValue = analyzer.GetMinPort(&svc)
-					metricType = "numeric"
-				case "has_sensitive_annotations":
-					actualValue = analyzer.HasSensitiveAnnotations(&svc)
-					metricType = "boolean"
-				case "has_ready_endpoints":
-					actualValue = analyzer.HasReadyEndpoints(&svc)
-					metricType = "boolean"
-				case "has_matching_pods":
-					actualValue = analyzer.HasMatchingPods(&svc)
-					metricType = "boolean"
-				case "has_labels":
-					actualValue = svc.Labels
-					metricType = "map"
-				case "has_selector":
-					actualValue = analyzer.HasSelector(&svc)
-					metricType = "boolean"
-				default:
-					continue
-				}
-				
-				result, err := rulesEngine.EvaluateRule(rule, metricType, actualValue)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "规则评估失败: %v\n", err)
-					continue
-				}
-				
-				// 只记录失败的检查
-				if !result.Passed {
-					hasIssues = true
-					// 处理消息重复问题
-					message := result.Message
-					if len(message) > len(rule.Name)+2 && message[:len(rule.Name)+2] == rule.Name+": " {
-						message = message[len(rule.Name)+2:]
+		// 保存持续条件状态，供下次巡检判断条件是否持续成立
+		saveConditionState(rulesEngine)
+
+		// 过滤结果（如果只显示有问题的资源）
+		if opts.OnlyIssues {
+			filteredResults := []*service.AnalysisResult{}
+			for _, result := range results {
+				for _, item := range result.Items {
+					if !item.Passed {
+						filteredResults = append(filteredResults, result)
+						break
 					}
-					failedChecks = append(failedChecks, fmt.Sprintf("  %s %s: %s", svcColoredFail("[FAIL]"), rule.Name, message))
-				}
-			}
-			
-			// 输出结果
-			if hasIssues {
-				fmt.Printf("\nService %s/%s 检查问题:\n", svc.Namespace, svc.Name)
-				for _, check := range failedChecks {
-					fmt.Println(check)
 				}
-			} else {
-				fmt.Printf("Service %s/%s: %s\n", svc.Namespace, svc.Name, svcColoredSuccess("所有检查通过"))
 			}
+			results = filteredResults
+		}
+
+		// 获取规则列表
+		rulesList := rulesEngine.GetRules(rules.RuleFilter{Categories: []string{"service"}})
+
+		// 创建报告生成器
+		reportGenerator := report.NewGenerator(clusterName, "")
+		serviceReport := reportGenerator.GenerateServiceReport(results, rulesList)
+
+		// 应用豁免文件和资源注解中的豁免
+		for _, result := range results {
+			waivers.AddAnnotations("Service", result.Namespace+"/"+result.ServiceName, result.Service.Annotations)
+		}
+		applyWaivers(serviceReport, waivers)
+
+		// 输出报告
+		if err := opts.writeReport(serviceReport); err != nil {
+			return err
 		}
-	}
 
-	return nil
+		// 按发现项阈值确定检查结果
+		return opts.CheckFindings(serviceReport, false)
+	})
 }
@@ -0,0 +1,99 @@
+package inspect
+
+import (
+	"os"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/manifest"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/snapshot"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewLintCommand 创建资源清单静态检查命令
+func NewLintCommand(opts *Options) *cobra.Command {
+	var (
+		files     []string
+		namespace string
+	)
+	cmd := &cobra.Command{
+		Use:   "lint -f <文件|目录|->",
+		Short: "检查资源清单，无需连接集群",
+		Long: `解析多文档YAML或JSON资源清单（Deployment、Service、Pod、StatefulSet、CRD等），通过与采集器相同的模型转换评估规则，
+只使用不依赖集群运行时数据的规则（资源使用率、重启次数、端点等指标以及配置了 duration 的规则会被跳过）。
+Deployment、Pod、Service 使用对应的分析器，其他资源类型按 inspect resource 的方式使用通用指标检查。
+存在未豁免的发现项时以状态码 2 退出，可通过 --fail-on 和 --max-findings 调整阈值，可用于在合并请求前检查清单。`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.ValidateFailureGate()
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runLint(opts, files, namespace); err != nil {
+				exitWithError(err, i18n.Sprintf("检查资源清单失败: %v\n", err))
+			}
+		},
+	}
+	cmd.Flags().StringArrayVarP(&files, "filename", "f", nil, "要检查的清单文件或目录，- 表示从标准输入读取，可重复指定")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "清单中未指定命名空间的资源使用的命名空间")
+	cmd.MarkFlagRequired("filename")
+	return cmd
+}
+
+// runLint 执行资源清单检查逻辑，未指定发现项阈值时存在任何未豁免的发现项即返回错误
+func runLint(opts *Options, files []string, namespace string) error {
+	objects, err := manifest.Load(files, os.Stdin, namespace)
+	if err != nil {
+		return err
+	}
+
+	// 清单中的对象构造离线客户端，使规则作用范围中的命名空间标签选择器可以使用清单中的 Namespace
+	raw := make([]runtime.Object, 0, len(objects))
+	for _, obj := range objects {
+		raw = append(raw, obj.Object)
+	}
+	client, err := snapshot.NewClient("", raw)
+	if err != nil {
+		return err
+	}
+
+	// 加载规则，未指定规则文件时使用全部内置规则包，只保留不依赖运行时数据的规则；
+	// 静态检查不读写持续条件的状态文件，也不需要自动重载
+	rulesEngine, err := opts.newRulesEngine(client, "", "")
+	if err != nil {
+		return err
+	}
+	rulesEngine.SetStaticOnly(true)
+
+	// 加载豁免
+	waivers, err := opts.loadWaivers()
+	if err != nil {
+		return err
+	}
+
+	lintReport, err := manifest.Analyze(rulesEngine, objects, "", "")
+	if err != nil {
+		return err
+	}
+
+	// 应用豁免文件和资源注解中的豁免
+	for _, obj := range objects {
+		accessor, err := meta.Accessor(obj.Object)
+		if err != nil {
+			continue
+		}
+		resource := accessor.GetName()
+		if accessor.GetNamespace() != "" {
+			resource = accessor.GetNamespace() + "/" + resource
+		}
+		waivers.AddAnnotations(obj.GetObjectKind().GroupVersionKind().Kind, resource, accessor.GetAnnotations())
+	}
+	applyWaivers(lintReport, waivers)
+
+	// 输出报告
+	if err := opts.writeReport(lintReport); err != nil {
+		return err
+	}
+
+	// 按发现项阈值确定检查结果
+	return opts.CheckFindings(lintReport, true)
+}
@@ -0,0 +1,51 @@
+package inspect
+
+import "time"
+
+// Options 检查命令的选项，由命令行标志绑定后传入命令构造函数；
+// inspect 和 lint 命令各自持有一份选项，互不影响
+type Options struct {
+	// Kubeconfig kubeconfig文件路径
+	Kubeconfig string
+	// Context 要使用的kubeconfig上下文，离线分析时作为集群名称
+	Context string
+	// OutputFormat 报告输出格式
+	OutputFormat string
+	// NoColor 是否禁用颜色输出
+	NoColor bool
+	// OnlyIssues 是否只显示有问题的资源
+	OnlyIssues bool
+	// RulesFiles 自定义规则配置文件或目录，按顺序合并
+	RulesFiles []string
+	// OutputFile 报告输出文件，空字符串表示标准输出
+	OutputFile string
+	// StateFile 持续条件状态文件路径，空字符串表示使用默认路径
+	StateFile string
+	// WaiverFile 豁免文件路径，空字符串表示只使用资源注解中的豁免
+	WaiverFile string
+	// ExplainScore 是否在报告中输出健康评分的扣分明细
+	ExplainScore bool
+	// RulePacks 与默认规则包一起加载的内置规则包名称
+	RulePacks []string
+	// Tags 和 Controls 限定只使用包含这些标签或对应这些控制项的规则
+	Tags     []string
+	Controls []string
+	// Environment 通过 --env 指定的环境，空字符串表示按规则配置确定
+	Environment string
+	// Snapshot 通过 --from-snapshot 指定的快照文件或目录，空字符串表示连接集群
+	Snapshot string
+	// FailOn 和 MaxFindings 发现项阈值：存在 FailOn 及以上级别的发现项，或其数量超过 MaxFindings 时检查失败；
+	// FailOn 为空且 MaxFindings 为负数时 inspect 命令不按发现项失败
+	FailOn      string
+	MaxFindings int
+	// Watch 重复巡检的间隔，为0时只执行一次
+	Watch time.Duration
+}
+
+// NewOptions 创建带有默认值的检查选项
+func NewOptions() *Options {
+	return &Options{
+		OutputFormat: "text",
+		MaxFindings:  -1,
+	}
+}
@@ -0,0 +1,40 @@
+package inspect
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+)
+
+// writeReport 按 --output 指定的格式格式化报告，并输出到 --output-file 指定的文件或标准输出
+func (o *Options) writeReport(r *report.Report) error {
+	outputFormat, noColor, outputFile := o.OutputFormat, o.NoColor, o.OutputFile
+	if !o.ExplainScore {
+		r.HideScoreExplanations()
+	}
+
+	// 创建格式化器
+	formatter, err := report.NewFormatter(outputFormat, !noColor)
+	if err != nil {
+		return err
+	}
+
+	// 格式化报告
+	output := formatter.Format(r)
+
+	// 输出报告
+	if outputFile != "" {
+		// 写入文件
+		if err := os.WriteFile(outputFile, []byte(output), 0644); err != nil {
+			return fmt.Errorf("写入报告到文件失败: %w", err)
+		}
+		fmt.Print(i18n.Sprintf("报告已写入文件: %s\n", outputFile))
+	} else {
+		// 输出到标准输出
+		fmt.Println(output)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package inspect
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/waiver"
+)
+
+// loadWaivers 加载豁免文件，未指定时返回空的豁免集合
+func (o *Options) loadWaivers() (*waiver.Set, error) {
+	if o.WaiverFile == "" {
+		return waiver.NewSet(), nil
+	}
+	waivers, err := waiver.LoadFile(o.WaiverFile)
+	if err != nil {
+		return nil, fmt.Errorf("加载豁免失败: %w", err)
+	}
+	return waivers, nil
+}
+
+// applyWaivers 将豁免应用到报告
+func applyWaivers(r *report.Report, waivers *waiver.Set) {
+	report.ApplyWaivers(r, waivers, time.Now())
+}
@@ -0,0 +1,67 @@
+package inspect
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// run 执行巡检：未指定 --watch 时执行一次 pass；指定时按间隔重复执行，直到收到中断信号，
+// 并按规则配置中的 autoReload 在后台重载规则。每次 pass 都在规则引擎的 Pass 中执行，
+// 重载会等待正在进行的分析结束，保证同一次分析中的所有资源按同一版本的规则检查
+func (o *Options) run(client *cluster.Client, clusterName string, rulesEngine *rules.Engine, pass func() error) error {
+	if o.Watch <= 0 {
+		return rulesEngine.Pass(pass)
+	}
+
+	stop, err := rulesEngine.StartAutoReload(func(event rules.ReloadEvent) {
+		logReloadEvent(event)
+		// 新规则可能修改了集群到环境的映射，未通过 --env 指定环境时重新确定
+		if event.Reloaded && o.Environment == "" {
+			rulesEngine.SetEnvironment(o.resolveEnvironment(client, rulesEngine, clusterName))
+		}
+	})
+	if err != nil {
+		return withExitCode(ExitRulesInvalid, fmt.Errorf("启动规则自动重载失败: %w", err))
+	}
+	defer stop()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	ticker := time.NewTicker(o.Watch)
+	defer ticker.Stop()
+	for {
+		// 发现项超过阈值或暂时无法连接集群时只输出错误，继续下一次巡检
+		if err := rulesEngine.Pass(pass); err != nil {
+			switch ExitCode(err) {
+			case ExitViolations, ExitClusterUnreachable:
+				fmt.Fprintln(os.Stderr, err)
+			default:
+				return err
+			}
+		}
+
+		select {
+		case <-signals:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// logReloadEvent 输出规则重载事件
+func logReloadEvent(event rules.ReloadEvent) {
+	if event.Err != nil {
+		fmt.Fprint(os.Stderr, i18n.Sprintf("警告: 重载规则文件 %s 失败，继续使用原有规则: %v\n", event.File, event.Err))
+		return
+	}
+	fmt.Fprint(os.Stderr, i18n.Sprintf("规则文件 %s 已重新加载，共 %d 条规则\n", event.File, event.RuleCount))
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"io"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// langFlag --lang 参数的值，为空时按 LANG 等环境变量选择语言
+var langFlag string
+
+// setupLanguage 按 --lang 参数和环境变量设置输出语言，并翻译命令帮助；
+// 帮助和用法信息可能在命令执行前输出，因此在 cobra 解析参数之前单独读取 --lang
+func setupLanguage(root *cobra.Command, args []string) error {
+	lang, err := i18n.Detect(scanLangFlag(args))
+	if err != nil {
+		return err
+	}
+	i18n.SetLanguage(lang)
+	localizeCommand(root)
+	return nil
+}
+
+// scanLangFlag 从命令行参数中读取 --lang，忽略其他参数
+func scanLangFlag(args []string) string {
+	flags := pflag.NewFlagSet("lang", pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	lang := flags.String("lang", "", "")
+	// 避免 -h/--help 提前结束解析
+	flags.BoolP("help", "h", false, "")
+	_ = flags.Parse(args)
+	return *lang
+}
+
+// localizeCommand 将命令及其子命令的用法、说明和参数说明翻译为当前语言
+func localizeCommand(cmd *cobra.Command) {
+	cmd.Use = i18n.T(cmd.Use)
+	cmd.Short = i18n.T(cmd.Short)
+	cmd.Long = i18n.T(cmd.Long)
+	localizeFlags := func(flag *pflag.Flag) {
+		flag.Usage = i18n.T(flag.Usage)
+	}
+	cmd.LocalFlags().VisitAll(localizeFlags)
+	cmd.PersistentFlags().VisitAll(localizeFlags)
+	for _, sub := range cmd.Commands() {
+		localizeCommand(sub)
+	}
+}
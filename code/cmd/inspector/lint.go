@@ -0,0 +1,24 @@
+package main
+
+import (
+	"github.com/FreshMan1123/k8s-resource-inspector/code/cmd/inspector/inspect"
+)
+
+func init() {
+	// lint 命令使用独立的选项，只绑定与集群无关的规则、豁免和输出相关的标志
+	lintOptions := inspect.NewOptions()
+	lintCmd := inspect.NewLintCommand(lintOptions)
+	lintCmd.Flags().StringVar(&lintOptions.OutputFormat, "output", "text", "报告输出格式 (text, json, yaml, sarif)")
+	lintCmd.Flags().BoolVar(&lintOptions.NoColor, "no-color", false, "禁用颜色输出")
+	lintCmd.Flags().StringArrayVar(&lintOptions.RulesFiles, "rules-file", nil, "自定义规则配置文件或目录路径，可重复指定，后面的规则按ID覆盖前面的规则")
+	lintCmd.Flags().StringVarP(&lintOptions.OutputFile, "output-file", "o", "", "将报告写入文件而不是标准输出")
+	lintCmd.Flags().StringVar(&lintOptions.WaiverFile, "waiver-file", "", "豁免文件路径，被有效豁免的发现项单独列出")
+	lintCmd.Flags().StringArrayVar(&lintOptions.RulePacks, "rule-pack", nil, "与默认规则包一起加载的内置规则包，如 security，可重复指定")
+	lintCmd.Flags().StringSliceVar(&lintOptions.Tags, "tag", nil, "只使用包含任一指定标签的规则，可重复指定或用逗号分隔")
+	lintCmd.Flags().StringSliceVar(&lintOptions.Controls, "control", nil, "只使用对应任一指定控制项的规则，格式为 framework 或 framework:id，如 cis-kubernetes:5.2.2")
+	lintCmd.Flags().StringVar(&lintOptions.Environment, "env", "", "指定巡检使用的环境（如 prod、staging），覆盖规则配置中按集群确定的环境")
+	lintCmd.Flags().StringVar(&lintOptions.FailOn, "fail-on", "", "存在该级别及以上的未豁免发现项时以状态码 2 退出 (critical, error, warning, info)，默认为 info")
+	lintCmd.Flags().IntVar(&lintOptions.MaxFindings, "max-findings", -1, "允许的未豁免发现项数量（只统计 --fail-on 级别及以上），超过时以状态码 2 退出，默认不允许任何发现项")
+
+	rootCmd.AddCommand(lintCmd)
+}
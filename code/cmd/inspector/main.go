@@ -20,12 +20,17 @@ func init() {
 	rootCmd.PersistentFlags().StringP("kubeconfig", "k", "", "kubeconfig文件路径 (默认为$HOME/.kube/config)")
 	rootCmd.PersistentFlags().StringP("contextName", "c", "", "要使用的kubeconfig上下文名称")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "启用详细输出")
+	rootCmd.PersistentFlags().StringVar(&langFlag, "lang", "", "输出语言 (zh, en)，默认按 LANG 环境变量选择")
 
 	// 添加子命令
 	rootCmd.AddCommand(clusterCmd)
 }
 
 func main() {
+	if err := setupLanguage(rootCmd, os.Args[1:]); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
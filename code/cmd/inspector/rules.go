@@ -0,0 +1,269 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/configs"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/ruletest"
+	"github.com/spf13/cobra"
+
+	// 各分析器在 init 中注册指标目录
+	_ "github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	_ "github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/generic"
+	_ "github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/node"
+	_ "github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/pod"
+	_ "github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/service"
+)
+
+var (
+	// rules export命令的配置选项
+	rulesExportDir   string
+	rulesExportForce bool
+)
+
+// rulesCmd 表示规则管理命令
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "管理巡检规则",
+	Long:  `管理巡检规则，包括导出内置的默认规则包、检查和测试规则文件以及查看可用指标。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 默认显示帮助信息
+		if err := cmd.Help(); err != nil {
+			fmt.Printf("显示帮助信息失败: %v\n", err)
+		}
+	},
+}
+
+// rulesExportCmd 表示导出内置规则包命令
+var rulesExportCmd = &cobra.Command{
+	Use:   "export [规则包...]",
+	Short: "导出内置的默认规则包",
+	Long: `将编译进二进制的默认规则包（node、pod、deployment、service）和专题规则包（security）写入指定目录，便于在此基础上定制。
+未指定规则包时导出全部规则包，导出的文件可以通过 inspect 命令的 --rules-file 参数使用。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := exportRulePacks(args, rulesExportDir, rulesExportForce); err != nil {
+			fmt.Fprintf(os.Stderr, "导出规则包失败: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// rulesLintCmd 表示检查规则文件命令
+var rulesLintCmd = &cobra.Command{
+	Use:   "lint [规则文件或目录...]",
+	Short: "按指标目录检查规则文件",
+	Long: `检查规则文件中的指标名称、操作符和阈值类型是否与分析器产生的指标一致，
+以及环境阈值的键是否对应 clusterEnvironments 中的环境。
+多个文件或目录按 --rules-file 的方式合并后检查；未指定时检查内置的默认规则包。
+存在错误时以非零状态码退出。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ok, err := lintRules(args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "检查规则失败: %v\n", err)
+			os.Exit(1)
+		}
+		if !ok {
+			os.Exit(1)
+		}
+	},
+}
+
+// rulesTestCmd 表示规则单元测试命令
+var rulesTestCmd = &cobra.Command{
+	Use:   "test <测试套件文件或目录...>",
+	Short: "使用测试夹具验证规则结果",
+	Long: `执行规则测试套件：将测试夹具中的Kubernetes对象（YAML/JSON清单，NodeMetrics 和 PodMetrics 作为指标数据）
+交给真实的采集器、分析器和规则引擎，并与套件中期望的规则结果（pass、fail、pending、skip）比较。
+存在未通过的用例时以非零状态码退出。`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ok, err := runRuleTests(args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "执行规则测试失败: %v\n", err)
+			os.Exit(1)
+		}
+		if !ok {
+			os.Exit(1)
+		}
+	},
+}
+
+// rulesMetricsCmd 表示列出可用指标命令
+var rulesMetricsCmd = &cobra.Command{
+	Use:   "metrics [资源类型...]",
+	Short: "列出规则可用的指标",
+	Long:  `列出各分析器产生的指标及其值类型和可用的操作符，未指定资源类型时列出全部。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		kinds := args
+		if len(kinds) == 0 {
+			kinds = rules.CatalogKinds()
+		}
+		for _, kind := range kinds {
+			specs := rules.CatalogMetrics(kind)
+			if len(specs) == 0 {
+				fmt.Fprintf(os.Stderr, "未知的资源类型: %s (可用: %s)\n", kind, strings.Join(rules.CatalogKinds(), ", "))
+				os.Exit(1)
+			}
+			fmt.Printf("%s:\n", kind)
+			for _, spec := range specs {
+				description := spec.Description
+				if spec.Runtime {
+					// 依赖运行时数据的指标不参与 lint 静态检查
+					description += " (运行时)"
+				}
+				fmt.Printf("  %-36s %-9s %s\n", spec.Name, spec.Type, description)
+				fmt.Printf("  %-36s %-9s 操作符: %s\n", "", "", strings.Join(spec.Operators, " "))
+			}
+		}
+	},
+}
+
+func init() {
+	// 添加子命令到rules命令
+	rulesCmd.AddCommand(rulesExportCmd)
+	rulesCmd.AddCommand(rulesLintCmd)
+	rulesCmd.AddCommand(rulesTestCmd)
+	rulesCmd.AddCommand(rulesMetricsCmd)
+
+	// 添加rules export命令的标志
+	rulesExportCmd.Flags().StringVarP(&rulesExportDir, "dir", "d", "rules", "规则包导出目录")
+	rulesExportCmd.Flags().BoolVar(&rulesExportForce, "force", false, "覆盖已存在的文件")
+
+	rootCmd.AddCommand(rulesCmd)
+}
+
+// exportRulePacks 将内置规则包写入目录，packs 为空时导出全部规则包
+func exportRulePacks(packs []string, dir string, force bool) error {
+	available, err := configs.RulePacks()
+	if err != nil {
+		return fmt.Errorf("读取内置规则包失败: %w", err)
+	}
+	if len(packs) == 0 {
+		packs = available
+	}
+
+	// 先检查所有规则包，避免只导出一部分
+	for _, pack := range packs {
+		if _, err := fs.Stat(configs.RulesFS, configs.RulePackPath(pack)); err != nil {
+			return fmt.Errorf("未知的规则包: %s (可用: %v)", pack, available)
+		}
+		target := filepath.Join(dir, pack+".yaml")
+		if _, err := os.Stat(target); err == nil && !force {
+			return fmt.Errorf("文件已存在: %s，使用 --force 覆盖", target)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建导出目录失败: %w", err)
+	}
+	for _, pack := range packs {
+		data, err := fs.ReadFile(configs.RulesFS, configs.RulePackPath(pack))
+		if err != nil {
+			return fmt.Errorf("读取规则包 %s 失败: %w", pack, err)
+		}
+		target := filepath.Join(dir, pack+".yaml")
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return fmt.Errorf("写入规则包 %s 失败: %w", pack, err)
+		}
+		fmt.Printf("已导出规则包 %s: %s\n", pack, target)
+	}
+
+	return nil
+}
+
+// lintRules 检查规则文件并输出发现的问题，没有错误级别的问题时返回true
+func lintRules(paths []string) (bool, error) {
+	loaders := make([]*rules.RuleLoader, 0)
+	if len(paths) > 0 {
+		loaders = append(loaders, rules.NewRuleLoader(paths...))
+	} else {
+		packs, err := configs.RulePacks()
+		if err != nil {
+			return false, fmt.Errorf("读取内置规则包失败: %w", err)
+		}
+		for _, pack := range packs {
+			loaders = append(loaders, rules.NewRuleLoaderFromFS(configs.RulesFS, configs.RulePackPath(pack)))
+		}
+	}
+
+	ok := true
+	errorCount, warningCount := 0, 0
+	for _, loader := range loaders {
+		// 加载时会先校验规则结构，结构错误无法继续检查
+		if err := loader.LoadRules(); err != nil {
+			fmt.Printf("%s:\n  [error] %v\n", loader.Sources(), err)
+			ok = false
+			errorCount++
+			continue
+		}
+
+		issues := rules.LintConfig(loader.GetRulesConfig())
+		if len(issues) == 0 {
+			fmt.Printf("%s: 未发现问题\n", loader.Sources())
+			continue
+		}
+		fmt.Printf("%s:\n", loader.Sources())
+		for _, issue := range issues {
+			fmt.Printf("  %s\n", issue)
+			if issue.Level == rules.LintError {
+				errorCount++
+			} else {
+				warningCount++
+			}
+		}
+		if rules.HasLintErrors(issues) {
+			ok = false
+		}
+	}
+
+	fmt.Printf("检查完成: %d 个错误, %d 个警告\n", errorCount, warningCount)
+	return ok, nil
+}
+
+// runRuleTests 执行规则测试套件并输出结果，全部用例通过时返回true
+func runRuleTests(paths []string) (bool, error) {
+	suites, err := ruletest.LoadSuites(paths...)
+	if err != nil {
+		return false, err
+	}
+
+	passed, failed := 0, 0
+	for _, suite := range suites {
+		fmt.Printf("%s (%s)\n", suite.Name, suite.Path())
+		for _, result := range ruletest.Run(suite) {
+			if result.Passed() {
+				passed++
+				fmt.Printf("  通过  %s\n", result.Name)
+				continue
+			}
+
+			failed++
+			fmt.Printf("  失败  %s\n", result.Name)
+			if result.Err != nil {
+				fmt.Printf("        错误: %v\n", result.Err)
+				continue
+			}
+			resource := ""
+			for _, diff := range result.Diffs {
+				if diff.Resource != resource {
+					resource = diff.Resource
+					fmt.Printf("        %s:\n", resource)
+				}
+				fmt.Printf("          - %s: %s\n", diff.RuleID, diff.Expected)
+				if diff.Detail != "" {
+					fmt.Printf("          + %s: %s (%s)\n", diff.RuleID, diff.Actual, diff.Detail)
+				} else {
+					fmt.Printf("          + %s: %s\n", diff.RuleID, diff.Actual)
+				}
+			}
+		}
+	}
+
+	fmt.Printf("测试结果: %d 通过, %d 失败\n", passed, failed)
+	return failed == 0, nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/snapshot"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// snapshot命令的配置选项
+	snapshotOutputFile  string
+	snapshotNamespace   string
+	snapshotClusterName string
+	snapshotNoRedact    bool
+)
+
+// snapshotCmd 表示采集集群快照命令
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "采集集群状态快照，用于离线分析",
+	Long: `采集集群中的命名空间、节点、Pod、Deployment、Service、Endpoints、事件以及节点和Pod指标，
+写入 gzip 压缩的 tar 归档，归档中的 manifest.json 记录集群名称、集群版本和采集时间。
+生成的快照可以通过 inspect 命令的 --from-snapshot 参数离线分析。
+默认删除 last-applied-configuration 注解，并替换敏感注解和容器环境变量的明文值，不采集 Secret。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := captureSnapshot(cmd); err != nil {
+			fmt.Fprint(os.Stderr, i18n.Sprintf("采集集群快照失败: %v\n", err))
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	snapshotCmd.Flags().StringVarP(&snapshotOutputFile, "output-file", "o", "", "快照归档路径 (默认为 <集群名称>-<采集时间>.tar.gz)")
+	snapshotCmd.Flags().StringVarP(&snapshotNamespace, "namespace", "n", "", "只采集指定命名空间的资源，默认采集所有命名空间")
+	snapshotCmd.Flags().StringVar(&snapshotClusterName, "name", "", "写入快照的集群名称 (默认为kubeconfig上下文名称)")
+	snapshotCmd.Flags().BoolVar(&snapshotNoRedact, "no-redact", false, "保留注解和环境变量的原始值，不做脱敏")
+
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+// captureSnapshot 采集集群快照并写入归档文件
+func captureSnapshot(cmd *cobra.Command) error {
+	configPath := getConfigPath(cmd)
+	contextName, _ := cmd.Flags().GetString("contextName")
+
+	client, err := cluster.NewClient(configPath, contextName)
+	if err != nil {
+		return fmt.Errorf("创建集群客户端失败: %w", err)
+	}
+
+	// 集群名称依次使用 --name、--contextName 和kubeconfig的当前上下文
+	name := snapshotClusterName
+	if name == "" {
+		name = contextName
+	}
+	if name == "" {
+		name, _ = cluster.GetCurrentContext(configPath)
+	}
+	if name == "" {
+		name = "default-cluster"
+	}
+
+	path := snapshotOutputFile
+	if path == "" {
+		path = fmt.Sprintf("%s-%s.tar.gz", name, time.Now().Format("20060102-150405"))
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("创建快照文件失败: %w", err)
+	}
+
+	manifest, err := snapshot.Capture(context.Background(), client, file, snapshot.CaptureOptions{
+		ClusterName: name,
+		Namespace:   snapshotNamespace,
+		Redact:      !snapshotNoRedact,
+	})
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("写入快照文件失败: %w", closeErr)
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+
+	for _, warning := range manifest.Warnings {
+		fmt.Fprint(os.Stderr, i18n.Sprintf("警告: %s\n", warning))
+	}
+	total := 0
+	for _, count := range manifest.Resources {
+		total += count
+	}
+	fmt.Print(i18n.Sprintf("集群 %s 的快照已写入 %s，共 %d 个对象\n", name, path, total))
+	return nil
+}
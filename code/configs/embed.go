@@ -0,0 +1,34 @@
+// Package configs 提供编译进二进制文件的默认配置
+package configs
+
+import (
+	"embed"
+	"io/fs"
+	"strings"
+)
+
+// RulesDir 内置规则包在 RulesFS 中的目录
+const RulesDir = "rules"
+
+// RulesFS 内置的默认规则包，即 rules 目录下的 YAML 文件
+//
+//go:embed rules/*.yaml
+var RulesFS embed.FS
+
+// RulePacks 返回所有内置规则包的名称（不含扩展名），按名称排序
+func RulePacks() ([]string, error) {
+	entries, err := fs.ReadDir(RulesFS, RulesDir)
+	if err != nil {
+		return nil, err
+	}
+	packs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		packs = append(packs, strings.TrimSuffix(entry.Name(), ".yaml"))
+	}
+	return packs, nil
+}
+
+// RulePackPath 返回内置规则包在 RulesFS 中的路径
+func RulePackPath(pack string) string {
+	return RulesDir + "/" + pack + ".yaml"
+}
@@ -1,21 +1,221 @@
 package deployment
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
-type DeploymentAnalyzer struct {
-	collector *collector.DeploymentCollector
+// RulesEngine 规则引擎接口
+type RulesEngine interface {
+	// GetRules 获取规则
+	GetRules(filter rules.RuleFilter) []rules.Rule
+	// EvaluateRuleFor 评估规则对指定资源的检查结果，未通过时按规则的消息模板生成消息
+	EvaluateRuleFor(rule rules.Rule, resource *rules.ResourceMeta, metricType string, actualValue interface{}) (*rules.RuleResult, error)
+	// ApplyConditionDurations 处理配置了 duration 的规则
+	ApplyConditionDurations(resource string, items []rules.ConditionItem)
 }
 
-func NewDeploymentAnalyzer(collector *collector.DeploymentCollector) *DeploymentAnalyzer {
-	return &DeploymentAnalyzer{collector: collector}
+// AnalysisItem 单个分析项目
+type AnalysisItem struct {
+	// 规则ID
+	RuleID string `json:"rule_id"`
+	// 规则名称
+	Name string `json:"name"`
+	// 类别
+	Category string `json:"category"`
+	// 严重程度：critical, error, warning, info
+	Severity string `json:"severity"`
+	// 检查的指标
+	Metric string `json:"metric"`
+	// 指标值
+	Value string `json:"value"`
+	// 阈值
+	Threshold string `json:"threshold"`
+	// 比较结果 (是否通过)
+	Passed bool `json:"passed"`
+	// 描述
+	Description string `json:"description"`
+	// 建议的修复措施
+	Remediation string `json:"remediation"`
+	// 配置了持续时间的规则条件首次成立的时间
+	PendingSince *time.Time `json:"pending_since,omitempty"`
 }
 
 // AnalysisResult 表示单个Deployment的分析结果
-// 可根据后续报告结构扩展
+type AnalysisResult struct {
+	// Deployment名称
+	DeploymentName string `json:"deployment_name"`
+	// Deployment命名空间
+	Namespace string `json:"namespace"`
+	// 分析结果项目列表
+	Items []AnalysisItem `json:"items"`
+	// 分析时间
+	AnalyzedAt time.Time `json:"analyzed_at"`
+	// 被分析的Deployment
+	Deployment models.Deployment `json:"deployment"`
+}
+
+// DeploymentAnalyzer Deployment资源分析器
+type DeploymentAnalyzer struct {
+	rulesEngine RulesEngine
+	collector   *collector.DeploymentCollector
+}
+
+// NewDeploymentAnalyzer 创建Deployment分析器
+func NewDeploymentAnalyzer(rulesEngine RulesEngine, collector *collector.DeploymentCollector) *DeploymentAnalyzer {
+	return &DeploymentAnalyzer{
+		rulesEngine: rulesEngine,
+		collector:   collector,
+	}
+}
+
+// AnalyzeDeployment 分析单个Deployment
+// Deployment规则描述的是期望状态（如副本数>=2），规则引擎返回Passed=true即表示检查通过，无需反转
+func (da *DeploymentAnalyzer) AnalyzeDeployment(dep models.Deployment) *AnalysisResult {
+	result := &AnalysisResult{
+		DeploymentName: dep.Name,
+		Namespace:      dep.Namespace,
+		Items:          make([]AnalysisItem, 0),
+		AnalyzedAt:     time.Now(),
+		Deployment:     dep,
+	}
+
+	filter := rules.RuleFilter{
+		Categories: []string{"deployment"},
+		Resource: &rules.ResourceMeta{
+			Kind:      "Deployment",
+			Name:      dep.Name,
+			Namespace: dep.Namespace,
+			Labels:    dep.Labels,
+		},
+	}
+	source := rules.MetricSource(func(metric string) (interface{}, string, bool) {
+		return GetMetricValue(dep, metric)
+	})
+	for _, rule := range da.rulesEngine.GetRules(filter) {
+		metric := rule.Condition.Metric
+		actualValue, metricType, ok := GetMetricValue(dep, metric)
+		if rule.Condition.IsCompound() {
+			// 组合条件由规则引擎按需获取各子条件的指标值
+			metric = rules.DescribeCondition(rule.Condition)
+			actualValue, metricType, ok = source, "", true
+		} else if metric == rules.JSONPathMetric {
+			metric = rule.Condition.Path
+		}
+		if !ok {
+			continue
+		}
+
+		ruleResult, err := da.rulesEngine.EvaluateRuleFor(rule, filter.Resource, metricType, actualValue)
+		if err != nil {
+			// 记录错误并继续
+			fmt.Fprint(os.Stderr, i18n.Sprintf("规则评估失败 (%s, Deployment %s/%s): %v\n", rule.ID, dep.Namespace, dep.Name, err))
+			continue
+		}
+
+		result.Items = append(result.Items, AnalysisItem{
+			RuleID:      ruleResult.RuleID,
+			Name:        ruleResult.RuleName,
+			Category:    rule.Category,
+			Severity:    ruleResult.Severity,
+			Metric:      metric,
+			Value:       fmt.Sprintf("%v", ruleResult.ActualValue),
+			Threshold:   fmt.Sprintf("%v", ruleResult.ExpectedValue),
+			Passed:      ruleResult.Passed,
+			Description: ruleResult.Message,
+			Remediation: ruleResult.Remediation,
+		})
+	}
+
+	// 处理需要持续一段时间才报告的规则
+	da.rulesEngine.ApplyConditionDurations(fmt.Sprintf("Deployment/%s/%s", dep.Namespace, dep.Name), durationItems(result.Items))
+
+	return result
+}
+
+// AnalyzeDeploymentsInNamespace 分析命名空间中的所有Deployment，namespace为空表示所有命名空间
+func (da *DeploymentAnalyzer) AnalyzeDeploymentsInNamespace(namespace string) ([]*AnalysisResult, error) {
+	if da.collector == nil {
+		return nil, fmt.Errorf("未设置 DeploymentCollector")
+	}
+	deployments, err := da.collector.GetDeployments(context.TODO(), namespace)
+	if err != nil {
+		return nil, fmt.Errorf("获取Deployment列表失败: %w", err)
+	}
+	results := make([]*AnalysisResult, 0, len(deployments))
+	for _, dep := range deployments {
+		results = append(results, da.AnalyzeDeployment(dep))
+	}
+	return results, nil
+}
+
+// GetMetricValue 获取Deployment指定指标的实际值及其验证器类型
+func GetMetricValue(dep models.Deployment, metric string) (interface{}, string, bool) {
+	switch metric {
+	case "replicas":
+		return dep.Replicas, "numeric", true
+	case "has_resource_limits":
+		return AllContainersHaveResourceLimits(dep), "boolean", true
+	case "image_pull_policy":
+		return GetImagePullPolicy(dep), "string", true
+	case "has_labels":
+		return dep.Labels, "map", true
+	case "namespace":
+		return dep.Namespace, "string", true
+	case "image_registries":
+		return ImageRegistries(dep), "string", true
+	case "max_container_cpu_limit":
+		return quantityString(MaxContainerResource(dep, "limits", "cpu")), "quantity", true
+	case "max_container_memory_limit":
+		return quantityString(MaxContainerResource(dep, "limits", "memory")), "quantity", true
+	case "max_container_cpu_request":
+		return quantityString(MaxContainerResource(dep, "requests", "cpu")), "quantity", true
+	case "max_container_memory_request":
+		return quantityString(MaxContainerResource(dep, "requests", "memory")), "quantity", true
+	case rules.JSONPathMetric:
+		return dep.Object, rules.ObjectMetricType, dep.Object != nil
+	default:
+		return nil, "", false
+	}
+}
+
+// MaxContainerResource 获取所有容器中指定资源限制或请求的最大值，kind 为 limits 或 requests；
+// 未设置或无法解析的容器不参与比较，均未设置时返回0
+func MaxContainerResource(deployment models.Deployment, kind, name string) resource.Quantity {
+	max := resource.Quantity{}
+	for _, c := range deployment.Containers {
+		values := c.Resources.Limits
+		if kind == "requests" {
+			values = c.Resources.Requests
+		}
+		value, ok := values[name]
+		if !ok {
+			continue
+		}
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			continue
+		}
+		if q.Cmp(max) > 0 {
+			max = q
+		}
+	}
+	return max
+}
+
+// quantityString 返回资源数量的规范写法，如 512Mi
+func quantityString(q resource.Quantity) string {
+	return q.String()
+}
 
 // HasLabels 检查Deployment是否包含所有指定标签
 func HasLabels(deployment models.Deployment, required map[string]string) bool {
@@ -64,4 +264,36 @@ func GetImagePullPolicy(deployment models.Deployment) string {
 		}
 	}
 	return policy
-} 
\ No newline at end of file
+}
+
+// ImageRegistries 获取所有容器镜像所属的镜像仓库，未显式指定仓库的镜像视为 docker.io
+func ImageRegistries(deployment models.Deployment) []string {
+	registries := make([]string, 0, len(deployment.Containers))
+	for _, c := range deployment.Containers {
+		registries = append(registries, imageRegistry(c.Image))
+	}
+	return registries
+}
+
+// imageRegistry 解析镜像引用中的仓库地址
+func imageRegistry(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		return parts[0]
+	}
+	return "docker.io"
+}
+
+// durationItems 返回分析项中与持续条件相关的字段，供规则引擎处理配置了 duration 的规则
+func durationItems(items []AnalysisItem) []rules.ConditionItem {
+	conditions := make([]rules.ConditionItem, len(items))
+	for i := range items {
+		conditions[i] = rules.ConditionItem{
+			RuleID:       items[i].RuleID,
+			Passed:       &items[i].Passed,
+			Description:  &items[i].Description,
+			PendingSince: &items[i].PendingSince,
+		}
+	}
+	return conditions
+}
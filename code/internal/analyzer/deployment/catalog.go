@@ -0,0 +1,21 @@
+package deployment
+
+import "github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+
+// 注册Deployment分析器产生的指标，供规则检查使用，需与 GetMetricValue 保持一致
+func init() {
+	rules.RegisterCompoundKind("deployment")
+	rules.RegisterMetrics(
+		rules.MetricSpec{Name: "replicas", Kind: "deployment", Type: "numeric", Description: "副本数"},
+		rules.MetricSpec{Name: "has_resource_limits", Kind: "deployment", Type: "boolean", Description: "所有容器是否都设置了资源请求和限制"},
+		rules.MetricSpec{Name: "image_pull_policy", Kind: "deployment", Type: "string", Description: "容器统一的镜像拉取策略，不一致时为Mixed"},
+		rules.MetricSpec{Name: "has_labels", Kind: "deployment", Type: "map", Description: "Deployment标签"},
+		rules.MetricSpec{Name: "namespace", Kind: "deployment", Type: "string", Description: "命名空间"},
+		rules.MetricSpec{Name: "image_registries", Kind: "deployment", Type: "string", Description: "各容器镜像所属仓库，每个值都需满足条件"},
+		rules.MetricSpec{Name: "max_container_cpu_limit", Kind: "deployment", Type: "quantity", Description: "容器CPU限制的最大值"},
+		rules.MetricSpec{Name: "max_container_memory_limit", Kind: "deployment", Type: "quantity", Description: "容器内存限制的最大值"},
+		rules.MetricSpec{Name: "max_container_cpu_request", Kind: "deployment", Type: "quantity", Description: "容器CPU请求的最大值"},
+		rules.MetricSpec{Name: "max_container_memory_request", Kind: "deployment", Type: "quantity", Description: "容器内存请求的最大值"},
+		rules.JSONPathMetricSpec("deployment"),
+	)
+}
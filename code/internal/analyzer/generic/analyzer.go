@@ -0,0 +1,223 @@
+// Package generic 分析没有专用分析器的任意资源类型（包括CRD），规则的 category 为资源 Kind 的小写形式
+package generic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// RulesEngine 规则引擎接口
+type RulesEngine interface {
+	// GetRules 获取规则
+	GetRules(filter rules.RuleFilter) []rules.Rule
+	// EvaluateRuleFor 评估规则对指定资源的检查结果，未通过时按规则的消息模板生成消息
+	EvaluateRuleFor(rule rules.Rule, resource *rules.ResourceMeta, metricType string, actualValue interface{}) (*rules.RuleResult, error)
+	// ApplyConditionDurations 处理配置了 duration 的规则
+	ApplyConditionDurations(resource string, items []rules.ConditionItem)
+}
+
+// AnalysisItem 单个分析项目
+type AnalysisItem struct {
+	// 规则ID
+	RuleID string `json:"rule_id"`
+	// 规则名称
+	Name string `json:"name"`
+	// 类别
+	Category string `json:"category"`
+	// 严重程度：critical, error, warning, info
+	Severity string `json:"severity"`
+	// 检查的指标
+	Metric string `json:"metric"`
+	// 指标值
+	Value string `json:"value"`
+	// 阈值
+	Threshold string `json:"threshold"`
+	// 比较结果 (是否通过)
+	Passed bool `json:"passed"`
+	// 描述
+	Description string `json:"description"`
+	// 建议的修复措施
+	Remediation string `json:"remediation"`
+	// 配置了持续时间的规则条件首次成立的时间
+	PendingSince *time.Time `json:"pending_since,omitempty"`
+}
+
+// AnalysisResult 表示单个资源的分析结果
+type AnalysisResult struct {
+	// 资源名称
+	ResourceName string `json:"resource_name"`
+	// 资源命名空间，集群级资源为空
+	Namespace string `json:"namespace,omitempty"`
+	// 分析结果项目列表
+	Items []AnalysisItem `json:"items"`
+	// 分析时间
+	AnalyzedAt time.Time `json:"analyzed_at"`
+	// 被分析的资源
+	Resource models.Resource `json:"resource"`
+}
+
+// ResourceAnalyzer 任意资源类型的分析器
+type ResourceAnalyzer struct {
+	rulesEngine RulesEngine
+	collector   *collector.ResourceCollector
+}
+
+// NewResourceAnalyzer 创建任意资源类型的分析器
+func NewResourceAnalyzer(rulesEngine RulesEngine, collector *collector.ResourceCollector) *ResourceAnalyzer {
+	return &ResourceAnalyzer{
+		rulesEngine: rulesEngine,
+		collector:   collector,
+	}
+}
+
+// RuleCategory 返回资源类型对应的规则 category，即 Kind 的小写形式（如 Certificate 对应 certificate）
+func RuleCategory(kind string) string {
+	return strings.ToLower(kind)
+}
+
+// AnalyzeResource 分析单个资源
+// 与Deployment规则相同，规则描述的是期望状态，规则引擎返回Passed=true即表示检查通过，无需反转
+func (a *ResourceAnalyzer) AnalyzeResource(res *models.Resource) *AnalysisResult {
+	result := &AnalysisResult{
+		ResourceName: res.Name,
+		Namespace:    res.Namespace,
+		Items:        make([]AnalysisItem, 0),
+		AnalyzedAt:   time.Now(),
+		Resource:     *res,
+	}
+
+	filter := rules.RuleFilter{
+		Categories: []string{RuleCategory(res.Kind)},
+		Resource: &rules.ResourceMeta{
+			Kind:      res.Kind,
+			Name:      res.Name,
+			Namespace: res.Namespace,
+			Labels:    res.Labels,
+		},
+	}
+	source := rules.MetricSource(func(metric string) (interface{}, string, bool) {
+		return GetMetricValue(res, metric)
+	})
+	for _, rule := range a.rulesEngine.GetRules(filter) {
+		metric := rule.Condition.Metric
+		actualValue, metricType, ok := GetMetricValue(res, metric)
+		if rule.Condition.IsCompound() {
+			// 组合条件由规则引擎按需获取各子条件的指标值，子条件使用了通用指标以外的指标时跳过该规则
+			if !usesGenericMetrics(rule.Condition) {
+				continue
+			}
+			metric = rules.DescribeCondition(rule.Condition)
+			actualValue, metricType, ok = source, "", true
+		} else if metric == rules.JSONPathMetric {
+			metric = rule.Condition.Path
+		}
+		if !ok {
+			continue
+		}
+
+		ruleResult, err := a.rulesEngine.EvaluateRuleFor(rule, filter.Resource, metricType, actualValue)
+		if err != nil {
+			// 记录错误并继续
+			continue
+		}
+
+		result.Items = append(result.Items, AnalysisItem{
+			RuleID:      ruleResult.RuleID,
+			Name:        ruleResult.RuleName,
+			Category:    rule.Category,
+			Severity:    ruleResult.Severity,
+			Metric:      metric,
+			Value:       fmt.Sprintf("%v", ruleResult.ActualValue),
+			Threshold:   fmt.Sprintf("%v", ruleResult.ExpectedValue),
+			Passed:      ruleResult.Passed,
+			Description: ruleResult.Message,
+			Remediation: ruleResult.Remediation,
+		})
+	}
+
+	// 处理需要持续一段时间才报告的规则
+	resource := fmt.Sprintf("%s/%s", res.Kind, res.Name)
+	if res.Namespace != "" {
+		resource = fmt.Sprintf("%s/%s/%s", res.Kind, res.Namespace, res.Name)
+	}
+	a.rulesEngine.ApplyConditionDurations(resource, durationItems(result.Items))
+
+	return result
+}
+
+// AnalyzeResources 分析指定资源类型的全部资源，namespace 为空时分析所有命名空间
+func (a *ResourceAnalyzer) AnalyzeResources(gvk schema.GroupVersionKind, namespace string) ([]*AnalysisResult, error) {
+	if a.collector == nil {
+		return nil, fmt.Errorf("未设置 ResourceCollector")
+	}
+	resources, err := a.collector.GetResources(context.TODO(), gvk, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("获取 %s 列表失败: %w", gvk.Kind, err)
+	}
+	results := make([]*AnalysisResult, 0, len(resources))
+	for i := range resources {
+		results = append(results, a.AnalyzeResource(&resources[i]))
+	}
+	return results, nil
+}
+
+// GetMetricValue 获取资源指定指标的实际值及其验证器类型
+func GetMetricValue(res *models.Resource, metric string) (interface{}, string, bool) {
+	switch metric {
+	case "name":
+		return res.Name, "string", true
+	case "namespace":
+		return res.Namespace, "string", true
+	case "has_labels":
+		return res.Labels, "map", true
+	case "has_annotations":
+		return res.Annotations, "map", true
+	case rules.JSONPathMetric:
+		return res.Object, rules.ObjectMetricType, res.Object != nil
+	default:
+		return nil, "", false
+	}
+}
+
+// usesGenericMetrics 判断组合条件中的全部指标是否都是通用指标
+func usesGenericMetrics(condition rules.RuleCondition) bool {
+	for _, child := range condition.All {
+		if !usesGenericMetrics(child) {
+			return false
+		}
+	}
+	for _, child := range condition.Any {
+		if !usesGenericMetrics(child) {
+			return false
+		}
+	}
+	if condition.Not != nil && !usesGenericMetrics(*condition.Not) {
+		return false
+	}
+	if condition.IsCompound() {
+		return true
+	}
+	_, _, ok := GetMetricValue(&models.Resource{Object: map[string]interface{}{}}, condition.Metric)
+	return ok
+}
+
+// durationItems 返回分析项中与持续条件相关的字段，供规则引擎处理配置了 duration 的规则
+func durationItems(items []AnalysisItem) []rules.ConditionItem {
+	conditions := make([]rules.ConditionItem, len(items))
+	for i := range items {
+		conditions[i] = rules.ConditionItem{
+			RuleID:       items[i].RuleID,
+			Passed:       &items[i].Passed,
+			Description:  &items[i].Description,
+			PendingSince: &items[i].PendingSince,
+		}
+	}
+	return conditions
+}
@@ -0,0 +1,15 @@
+package generic
+
+import "github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+
+// 注册通用分析器产生的指标，供规则检查使用，需与 GetMetricValue 保持一致
+func init() {
+	rules.RegisterCompoundKind(rules.GenericKind)
+	rules.RegisterMetrics(
+		rules.MetricSpec{Name: "name", Kind: rules.GenericKind, Type: "string", Description: "资源名称"},
+		rules.MetricSpec{Name: "namespace", Kind: rules.GenericKind, Type: "string", Description: "命名空间，集群级资源为空"},
+		rules.MetricSpec{Name: "has_labels", Kind: rules.GenericKind, Type: "map", Description: "资源标签"},
+		rules.MetricSpec{Name: "has_annotations", Kind: rules.GenericKind, Type: "map", Description: "资源注解"},
+		rules.JSONPathMetricSpec(rules.GenericKind),
+	)
+}
@@ -32,6 +32,8 @@ type AnalysisItem struct {
 	Description string `json:"description"`
 	// 建议的修复措施
 	Remediation string `json:"remediation"`
+	// 配置了持续时间的规则条件首次成立的时间
+	PendingSince *time.Time `json:"pending_since,omitempty"`
 }
 
 // AnalysisResult 分析结果
@@ -42,6 +44,8 @@ type AnalysisResult struct {
 	Items []AnalysisItem `json:"items"`
 	// 总体健康状态评分（0-100）
 	HealthScore int `json:"health_score"`
+	// 健康评分的扣分明细
+	ScoreBreakdown rules.ScoreBreakdown `json:"score_breakdown"`
 	// 分析时间
 	AnalyzedAt time.Time `json:"analyzed_at"`
 	// 节点基本信息
@@ -117,6 +121,8 @@ type AnalysisResult struct {
 	CreationTime time.Time `json:"creation_time"`
 	Schedulable bool `json:"schedulable"`
 	Addresses map[string]string `json:"addresses"`
+	// 节点注解
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // NodeAnalyzer 节点资源分析器
@@ -186,35 +192,52 @@ func (na *NodeAnalyzer) AnalyzeNode(node *models.Node) (*AnalysisResult, error)
 	// 填充其他节点信息
 	result.Roles = node.Roles
 	result.CreationTime = node.CreationTime
+	result.Annotations = node.Annotations
 	result.Schedulable = node.Schedulable
 	result.Addresses = node.Addresses
 
 	// 分析CPU资源指标
-	cpuItems := na.analyzeResourceMetric(node.Name, "cpu", node.CPU)
+	cpuItems := na.analyzeResourceMetric(node, "cpu", node.CPU)
 	result.Items = append(result.Items, cpuItems...)
 
 	// 分析内存资源指标
-	memoryItems := na.analyzeResourceMetric(node.Name, "memory", node.Memory)
+	memoryItems := na.analyzeResourceMetric(node, "memory", node.Memory)
 	result.Items = append(result.Items, memoryItems...)
 
 	// 分析临时存储资源指标
-	storageItems := na.analyzeResourceMetric(node.Name, "ephemeral_storage", node.EphemeralStorage)
+	storageItems := na.analyzeResourceMetric(node, "ephemeral_storage", node.EphemeralStorage)
 	result.Items = append(result.Items, storageItems...)
 
 	// 分析Pod资源指标
-	podItems := na.analyzeResourceMetric(node.Name, "pods", node.Pods)
+	podItems := na.analyzeResourceMetric(node, "pods", node.Pods)
 	result.Items = append(result.Items, podItems...)
 
 	// 分析节点压力状态
-	pressureItems := na.analyzePressureStatus(node.Name, node.PressureStatus)
+	pressureItems := na.analyzePressureStatus(node, node.PressureStatus)
 	result.Items = append(result.Items, pressureItems...)
 
 	// 分析节点条件状态
-	conditionItems := na.analyzeNodeConditions(node.Name, node.Ready, node.Conditions)
+	conditionItems := na.analyzeNodeConditions(node, node.Ready, node.Conditions)
 	result.Items = append(result.Items, conditionItems...)
 
+	// 分析节点组件版本
+	versionItems := na.analyzeNodeVersions(node, node.NodeInfo)
+	result.Items = append(result.Items, versionItems...)
+
+	// 分析 jsonpath 规则
+	jsonPathItems := na.analyzeJSONPathRules(node)
+	result.Items = append(result.Items, jsonPathItems...)
+
+	// 分析 all/any/not 组合条件规则
+	compoundItems := na.analyzeCompoundRules(node)
+	result.Items = append(result.Items, compoundItems...)
+
+	// 处理需要持续一段时间才报告的规则
+	na.rulesEngine.ApplyConditionDurations("Node/"+node.Name, durationItems(result.Items))
+
 	// 计算健康评分
-	result.HealthScore = na.calculateHealthScore(result.Items)
+	result.ScoreBreakdown = na.calculateHealthScore(result.Items)
+	result.HealthScore = result.ScoreBreakdown.Score
 
 	return result, nil
 }
@@ -275,12 +298,13 @@ func (na *NodeAnalyzer) AnalyzeNodes(nodes *models.NodeList) ([]*AnalysisResult,
 }
 
 // analyzeResourceMetric 分析资源指标
-func (na *NodeAnalyzer) analyzeResourceMetric(nodeName string, metricName string, metric models.ResourceMetric) []AnalysisItem {
+func (na *NodeAnalyzer) analyzeResourceMetric(node *models.Node, metricName string, metric models.ResourceMetric) []AnalysisItem {
 	items := make([]AnalysisItem, 0)
 
 	// 获取所有资源相关规则
 	filter := rules.RuleFilter{
 		Categories: []string{"node"},
+		Resource:   nodeResource(node),
 	}
 	allRules := na.rulesEngine.GetRules(filter)
 
@@ -318,7 +342,7 @@ func (na *NodeAnalyzer) analyzeResourceMetric(nodeName string, metricName string
 				Value:        fmt.Sprintf("%.2f", value.(float64)),
 				Threshold:    fmt.Sprintf("%v", ruleResult.ExpectedValue),
 				Passed:       !ruleResult.Passed,  // 反转结果
-				Description:  rule.Description,
+				Description:  na.describe(rule, node, ruleResult, rule.Description),
 				Remediation:  ruleResult.Remediation,
 			}
 
@@ -330,12 +354,13 @@ func (na *NodeAnalyzer) analyzeResourceMetric(nodeName string, metricName string
 }
 
 // analyzePressureStatus 分析节点压力状态
-func (na *NodeAnalyzer) analyzePressureStatus(nodeName string, pressure models.NodePressureStatus) []AnalysisItem {
+func (na *NodeAnalyzer) analyzePressureStatus(node *models.Node, pressure models.NodePressureStatus) []AnalysisItem {
 	items := make([]AnalysisItem, 0)
 
 	// 获取所有压力相关规则
 	filter := rules.RuleFilter{
 		Categories: []string{"node"},
+		Resource:   nodeResource(node),
 	}
 	allRules := na.rulesEngine.GetRules(filter)
 
@@ -376,7 +401,7 @@ func (na *NodeAnalyzer) analyzePressureStatus(nodeName string, pressure models.N
 				Value:        fmt.Sprintf("%v", value),
 				Threshold:    fmt.Sprintf("%v", ruleResult.ExpectedValue),
 				Passed:       !ruleResult.Passed,  // 反转结果
-				Description:  rule.Description,
+				Description:  na.describe(rule, node, ruleResult, rule.Description),
 				Remediation:  ruleResult.Remediation,
 			}
 
@@ -388,12 +413,13 @@ func (na *NodeAnalyzer) analyzePressureStatus(nodeName string, pressure models.N
 }
 
 // analyzeNodeConditions 分析节点条件状态
-func (na *NodeAnalyzer) analyzeNodeConditions(nodeName string, ready bool, conditions []models.NodeConditionStatus) []AnalysisItem {
+func (na *NodeAnalyzer) analyzeNodeConditions(node *models.Node, ready bool, conditions []models.NodeConditionStatus) []AnalysisItem {
 	items := make([]AnalysisItem, 0)
 
 	// 获取所有条件相关规则
 	filter := rules.RuleFilter{
 		Categories: []string{"node"},
+		Resource:   nodeResource(node),
 	}
 	allRules := na.rulesEngine.GetRules(filter)
 
@@ -420,7 +446,7 @@ func (na *NodeAnalyzer) analyzeNodeConditions(nodeName string, ready bool, condi
 				Value:        fmt.Sprintf("%v", ready),
 				Threshold:    fmt.Sprintf("%v", ruleResult.ExpectedValue),
 				Passed:       !ruleResult.Passed,  // 反转结果
-				Description:  rule.Description,
+				Description:  na.describe(rule, node, ruleResult, rule.Description),
 				Remediation:  ruleResult.Remediation,
 			}
 
@@ -431,37 +457,216 @@ func (na *NodeAnalyzer) analyzeNodeConditions(nodeName string, ready bool, condi
 	return items
 }
 
-// calculateHealthScore 计算节点健康评分
-func (na *NodeAnalyzer) calculateHealthScore(items []AnalysisItem) int {
-	if len(items) == 0 {
-		return 100
+// analyzeNodeVersions 分析节点组件版本
+func (na *NodeAnalyzer) analyzeNodeVersions(node *models.Node, info models.NodeInfo) []AnalysisItem {
+	items := make([]AnalysisItem, 0)
+
+	// 获取所有版本相关规则
+	filter := rules.RuleFilter{
+		Categories: []string{"node"},
+		Resource:   nodeResource(node),
 	}
+	allRules := na.rulesEngine.GetRules(filter)
 
-	// 基础分数
-	baseScore := 100
-	
-	// 不同严重程度的扣分
-	deductions := map[string]int{
-		"critical": 20,
-		"warning":  10,
-		"info":     5,
+	// 定义要检查的指标（按固定顺序输出）
+	versionChecks := []struct {
+		metric string
+		value  string
+	}{
+		{"kubelet_version", info.KubeletVersion},
 	}
-	
-	// 计算扣分
-	totalDeduction := 0
+
+	for _, check := range versionChecks {
+		metric, value := check.metric, check.value
+		for _, rule := range allRules {
+			// 跳过不匹配的规则
+			if rule.Condition.Metric != metric {
+				continue
+			}
+
+			// 评估规则
+			ruleResult, err := na.rulesEngine.EvaluateRule(rule, "string", value)
+			if err != nil {
+				// 记录错误并继续
+				continue
+			}
+
+			// 创建分析项 - 与其他节点规则一致，规则条件描述的是问题状态（如版本过低），需要反转结果
+			item := AnalysisItem{
+				RuleID:      ruleResult.RuleID,
+				Name:        ruleResult.RuleName,
+				Category:    rule.Category,
+				Severity:    ruleResult.Severity,
+				Metric:      metric,
+				Value:       value,
+				Threshold:   fmt.Sprintf("%v", ruleResult.ExpectedValue),
+				Passed:      !ruleResult.Passed, // 反转结果
+				Description: na.describe(rule, node, ruleResult, rule.Description),
+				Remediation: ruleResult.Remediation,
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// analyzeJSONPathRules 分析按 jsonpath 从原始节点对象取值的规则，条件成立表示存在问题
+func (na *NodeAnalyzer) analyzeJSONPathRules(node *models.Node) []AnalysisItem {
+	items := make([]AnalysisItem, 0)
+	if node.Object == nil {
+		return items
+	}
+
+	filter := rules.RuleFilter{
+		Categories: []string{"node"},
+		Resource:   nodeResource(node),
+	}
+	for _, rule := range na.rulesEngine.GetRules(filter) {
+		if rule.Condition.Metric != rules.JSONPathMetric {
+			continue
+		}
+		ruleResult, err := na.rulesEngine.EvaluateRule(rule, rules.ObjectMetricType, node.Object)
+		if err != nil {
+			// 记录错误并继续
+			continue
+		}
+
+		items = append(items, AnalysisItem{
+			RuleID:      ruleResult.RuleID,
+			Name:        ruleResult.RuleName,
+			Category:    rule.Category,
+			Severity:    ruleResult.Severity,
+			Metric:      rule.Condition.Path,
+			Value:       fmt.Sprintf("%v", ruleResult.ActualValue),
+			Threshold:   fmt.Sprintf("%v", ruleResult.ExpectedValue),
+			Passed:      !ruleResult.Passed, // 反转结果
+			Description: na.describe(rule, node, ruleResult, ruleResult.Message),
+			Remediation: ruleResult.Remediation,
+		})
+	}
+
+	return items
+}
+
+// analyzeCompoundRules 分析 all/any/not 组合条件规则，各子条件的指标值由 GetMetricValue 获取，条件成立表示存在问题
+func (na *NodeAnalyzer) analyzeCompoundRules(node *models.Node) []AnalysisItem {
+	items := make([]AnalysisItem, 0)
+
+	filter := rules.RuleFilter{
+		Categories: []string{"node"},
+		Resource:   nodeResource(node),
+	}
+	source := rules.MetricSource(func(metric string) (interface{}, string, bool) {
+		return GetMetricValue(node, metric)
+	})
+	for _, rule := range na.rulesEngine.GetRules(filter) {
+		if !rule.Condition.IsCompound() {
+			continue
+		}
+		ruleResult, err := na.rulesEngine.EvaluateRule(rule, "", source)
+		if err != nil {
+			// 记录错误并继续
+			continue
+		}
+
+		items = append(items, AnalysisItem{
+			RuleID:      ruleResult.RuleID,
+			Name:        ruleResult.RuleName,
+			Category:    rule.Category,
+			Severity:    ruleResult.Severity,
+			Metric:      rules.DescribeCondition(rule.Condition),
+			Value:       fmt.Sprintf("%v", ruleResult.ActualValue),
+			Threshold:   fmt.Sprintf("%v", ruleResult.ExpectedValue),
+			Passed:      !ruleResult.Passed, // 反转结果
+			Description: na.describe(rule, node, ruleResult, ruleResult.Message),
+			Remediation: ruleResult.Remediation,
+		})
+	}
+
+	return items
+}
+
+// GetMetricValue 获取节点指定指标的实际值及其验证器类型，需与 catalog.go 中注册的指标保持一致
+func GetMetricValue(node *models.Node, metric string) (interface{}, string, bool) {
+	resources := map[string]models.ResourceMetric{
+		"cpu":               node.CPU,
+		"memory":            node.Memory,
+		"ephemeral_storage": node.EphemeralStorage,
+		"pods":              node.Pods,
+	}
+	for name, resource := range resources {
+		switch metric {
+		case name + "_utilization":
+			return resource.Utilization, "numeric", true
+		case name + "_allocation_rate":
+			return resource.AllocationRate, "numeric", true
+		}
+	}
+
+	switch metric {
+	case "memory_pressure":
+		return node.PressureStatus.MemoryPressure, "boolean", true
+	case "cpu_pressure":
+		return node.PressureStatus.CPUPressure, "boolean", true
+	case "disk_pressure":
+		return node.PressureStatus.DiskPressure, "boolean", true
+	case "pid_pressure":
+		return node.PressureStatus.PIDPressure, "boolean", true
+	case "network_pressure":
+		return node.PressureStatus.NetworkPressure, "boolean", true
+	case "ready":
+		return node.Ready, "boolean", true
+	case "kubelet_version":
+		return node.NodeInfo.KubeletVersion, "string", true
+	case rules.JSONPathMetric:
+		return node.Object, rules.ObjectMetricType, node.Object != nil
+	default:
+		return nil, "", false
+	}
+}
+
+// nodeResource 返回用于匹配规则作用范围的节点信息
+func nodeResource(node *models.Node) *rules.ResourceMeta {
+	return &rules.ResourceMeta{
+		Kind:   "Node",
+		Name:   node.Name,
+		Labels: node.Labels,
+	}
+}
+
+// describe 返回分析项的描述：节点规则描述问题状态，条件成立（存在问题）且规则设置了消息模板时使用模板生成的消息
+func (na *NodeAnalyzer) describe(rule rules.Rule, node *models.Node, result *rules.RuleResult, fallback string) string {
+	if result.Passed {
+		if message, ok := na.rulesEngine.RenderMessage(rule, nodeResource(node), result); ok {
+			return message
+		}
+	}
+	return fallback
+}
+
+// calculateHealthScore 按规则配置中的评分模型计算节点健康评分
+func (na *NodeAnalyzer) calculateHealthScore(items []AnalysisItem) rules.ScoreBreakdown {
+	failed := make([]rules.ScoreItem, 0, len(items))
 	for _, item := range items {
 		if !item.Passed {
-			if deduction, exists := deductions[item.Severity]; exists {
-				totalDeduction += deduction
-			}
+			failed = append(failed, rules.ScoreItem{RuleID: item.RuleID, Severity: item.Severity})
 		}
 	}
-	
-	// 计算最终分数，确保不低于0
-	score := baseScore - totalDeduction
-	if score < 0 {
-		score = 0
+	return na.rulesEngine.Scoring().Score(failed)
+}
+
+// durationItems 返回分析项中与持续条件相关的字段，供规则引擎处理配置了 duration 的规则
+func durationItems(items []AnalysisItem) []rules.ConditionItem {
+	conditions := make([]rules.ConditionItem, len(items))
+	for i := range items {
+		conditions[i] = rules.ConditionItem{
+			RuleID:       items[i].RuleID,
+			Passed:       &items[i].Passed,
+			Description:  &items[i].Description,
+			PendingSince: &items[i].PendingSince,
+		}
 	}
-	
-	return score
-} 
\ No newline at end of file
+	return conditions
+}
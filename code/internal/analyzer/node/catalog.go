@@ -0,0 +1,26 @@
+package node
+
+import "github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+
+// 注册节点分析器产生的指标，供规则检查使用，需与 GetMetricValue 保持一致
+func init() {
+	rules.RegisterCompoundKind("node")
+	rules.RegisterMetrics(
+		rules.MetricSpec{Name: "cpu_utilization", Kind: "node", Type: "numeric", Description: "CPU使用率(%)", Runtime: true},
+		rules.MetricSpec{Name: "cpu_allocation_rate", Kind: "node", Type: "numeric", Description: "CPU分配率(%)", Runtime: true},
+		rules.MetricSpec{Name: "memory_utilization", Kind: "node", Type: "numeric", Description: "内存使用率(%)", Runtime: true},
+		rules.MetricSpec{Name: "memory_allocation_rate", Kind: "node", Type: "numeric", Description: "内存分配率(%)", Runtime: true},
+		rules.MetricSpec{Name: "ephemeral_storage_utilization", Kind: "node", Type: "numeric", Description: "临时存储使用率(%)", Runtime: true},
+		rules.MetricSpec{Name: "ephemeral_storage_allocation_rate", Kind: "node", Type: "numeric", Description: "临时存储分配率(%)", Runtime: true},
+		rules.MetricSpec{Name: "pods_utilization", Kind: "node", Type: "numeric", Description: "Pod数量使用率(%)", Runtime: true},
+		rules.MetricSpec{Name: "pods_allocation_rate", Kind: "node", Type: "numeric", Description: "Pod数量分配率(%)", Runtime: true},
+		rules.MetricSpec{Name: "memory_pressure", Kind: "node", Type: "boolean", Description: "是否存在内存压力", Runtime: true},
+		rules.MetricSpec{Name: "cpu_pressure", Kind: "node", Type: "boolean", Description: "是否存在CPU压力", Runtime: true},
+		rules.MetricSpec{Name: "disk_pressure", Kind: "node", Type: "boolean", Description: "是否存在磁盘压力", Runtime: true},
+		rules.MetricSpec{Name: "pid_pressure", Kind: "node", Type: "boolean", Description: "是否存在PID压力", Runtime: true},
+		rules.MetricSpec{Name: "network_pressure", Kind: "node", Type: "boolean", Description: "是否存在网络压力", Runtime: true},
+		rules.MetricSpec{Name: "ready", Kind: "node", Type: "boolean", Description: "节点是否就绪", Runtime: true},
+		rules.MetricSpec{Name: "kubelet_version", Kind: "node", Type: "string", Description: "Kubelet版本", Runtime: true},
+		rules.JSONPathMetricSpec("node"),
+	)
+}
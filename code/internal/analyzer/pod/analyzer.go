@@ -6,6 +6,7 @@ import (
 	"time"
 
 
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
@@ -19,6 +20,8 @@ type RulesEngine interface {
 	GetRules(filter rules.RuleFilter) []rules.Rule
 	// EvaluateRule 评估单个规则
 	EvaluateRule(rule rules.Rule, metricType string, actualValue interface{}) (*rules.RuleResult, error)
+	// RenderMessage 按规则的消息模板生成发现项消息，规则未设置模板时返回false
+	RenderMessage(rule rules.Rule, resource *rules.ResourceMeta, result *rules.RuleResult) (string, bool)
 	// SetEnvironment 设置当前环境
 	SetEnvironment(env string)
 	// GetEnvironment 获取当前环境
@@ -27,6 +30,10 @@ type RulesEngine interface {
 	DetermineEnvironment(clusterName string) string
 	// RegisterValidator 注册验证器
 	RegisterValidator(name string, validator rules.Validator)
+	// ApplyConditionDurations 处理配置了 duration 的规则
+	ApplyConditionDurations(resource string, items []rules.ConditionItem)
+	// Scoring 返回健康评分模型
+	Scoring() *rules.ScoringConfig
 }
 
 // AnalysisItem 单个分析项目
@@ -51,6 +58,10 @@ type AnalysisItem struct {
 	Description string `json:"description"`
 	// 建议的修复措施
 	Remediation string `json:"remediation"`
+	// 配置了持续时间的规则条件首次成立的时间
+	PendingSince *time.Time `json:"pending_since,omitempty"`
+	// 容器级检查对应的容器名称，Pod级检查为空
+	Container string `json:"container,omitempty"`
 }
 
 // AnalysisResult 分析结果
@@ -63,8 +74,12 @@ type AnalysisResult struct {
 	Items []AnalysisItem `json:"items"`
 	// 总体健康状态评分（0-100）
 	HealthScore int `json:"health_score"`
+	// 健康评分的扣分明细
+	ScoreBreakdown rules.ScoreBreakdown `json:"score_breakdown"`
 	// 分析时间
 	AnalyzedAt time.Time `json:"analyzed_at"`
+	// Pod注解
+	Annotations map[string]string `json:"annotations,omitempty"`
 	
 	// Pod基本信息
 	PodBasicInfo struct {
@@ -172,10 +187,11 @@ func (pa *PodAnalyzer) AnalyzePod(pod *models.Pod) (*AnalysisResult, error) {
 
 	// 创建分析结果
 	result := &AnalysisResult{
-		PodName:    pod.Name,
-		Namespace:  pod.Namespace,
-		Items:      make([]AnalysisItem, 0),
-		AnalyzedAt: time.Now(),
+		PodName:     pod.Name,
+		Namespace:   pod.Namespace,
+		Items:       make([]AnalysisItem, 0),
+		AnalyzedAt:  time.Now(),
+		Annotations: pod.Annotations,
 	}
 	
 	// 填充Pod基本信息
@@ -296,8 +312,20 @@ func (pa *PodAnalyzer) AnalyzePod(pod *models.Pod) (*AnalysisResult, error) {
 	configItems := pa.analyzePodConfig(pod)
 	result.Items = append(result.Items, configItems...)
 
+	// 分析 jsonpath 规则
+	jsonPathItems := pa.analyzeJSONPathRules(pod)
+	result.Items = append(result.Items, jsonPathItems...)
+
+	// 分析 all/any/not 组合条件规则
+	compoundItems := pa.analyzeCompoundRules(pod)
+	result.Items = append(result.Items, compoundItems...)
+
+	// 处理需要持续一段时间才报告的规则
+	pa.rulesEngine.ApplyConditionDurations(fmt.Sprintf("Pod/%s/%s", pod.Namespace, pod.Name), durationItems(result.Items))
+
 	// 计算健康评分
-	result.HealthScore = pa.calculateHealthScore(result.Items)
+	result.ScoreBreakdown = pa.calculateHealthScore(result.Items)
+	result.HealthScore = result.ScoreBreakdown.Score
 
 	return result, nil
 }
@@ -341,6 +369,7 @@ func (pa *PodAnalyzer) analyzePodStatus(pod *models.Pod) []AnalysisItem {
 	// 获取所有Pod状态相关规则
 	filter := rules.RuleFilter{
 		Categories: []string{"pod"},
+		Resource:   podResource(pod),
 	}
 	allRules := pa.rulesEngine.GetRules(filter)
 
@@ -369,7 +398,7 @@ func (pa *PodAnalyzer) analyzePodStatus(pod *models.Pod) []AnalysisItem {
 					Value:       fmt.Sprintf("%.1f", notRunningDuration),
 					Threshold:   fmt.Sprintf("%v", ruleResult.ExpectedValue),
 					Passed:      !ruleResult.Passed, // 反转结果
-					Description: fmt.Sprintf("Pod处于%s状态%s", pod.Phase, formatDuration(time.Since(pod.CreationTime))),
+					Description: pa.describe(rule, pod, ruleResult, i18n.Sprintf("Pod处于%s状态%s", pod.Phase, formatDuration(time.Since(pod.CreationTime)))),
 					Remediation: ruleResult.Remediation,
 				}
 				
@@ -388,6 +417,7 @@ func (pa *PodAnalyzer) analyzePodResources(pod *models.Pod) []AnalysisItem {
 	// 获取所有Pod资源相关规则
 	filter := rules.RuleFilter{
 		Categories: []string{"pod"},
+		Resource:   podResource(pod),
 	}
 	allRules := pa.rulesEngine.GetRules(filter)
 
@@ -414,8 +444,9 @@ func (pa *PodAnalyzer) analyzePodResources(pod *models.Pod) []AnalysisItem {
 						Value:       fmt.Sprintf("%.2f", container.CPU.Utilization),
 						Threshold:   fmt.Sprintf("%v", ruleResult.ExpectedValue),
 						Passed:      !ruleResult.Passed, // 反转结果
-						Description: fmt.Sprintf("容器 %s CPU使用率为 %.2f%%", container.Name, container.CPU.Utilization),
+						Description: pa.describe(rule, pod, ruleResult, i18n.Sprintf("容器 %s CPU使用率为 %.2f%%", container.Name, container.CPU.Utilization)),
 						Remediation: ruleResult.Remediation,
+						Container:   container.Name,
 					}
 					
 					items = append(items, item)
@@ -444,8 +475,9 @@ func (pa *PodAnalyzer) analyzePodResources(pod *models.Pod) []AnalysisItem {
 						Value:       fmt.Sprintf("%.2f", container.Memory.Utilization),
 						Threshold:   fmt.Sprintf("%v", ruleResult.ExpectedValue),
 						Passed:      !ruleResult.Passed, // 反转结果
-						Description: fmt.Sprintf("容器 %s 内存使用率为 %.2f%%", container.Name, container.Memory.Utilization),
+						Description: pa.describe(rule, pod, ruleResult, i18n.Sprintf("容器 %s 内存使用率为 %.2f%%", container.Name, container.Memory.Utilization)),
 						Remediation: ruleResult.Remediation,
+						Container:   container.Name,
 					}
 					
 					items = append(items, item)
@@ -477,8 +509,9 @@ func (pa *PodAnalyzer) analyzePodResources(pod *models.Pod) []AnalysisItem {
 						Value:       "true",
 						Threshold:   "false",
 						Passed:      !ruleResult.Passed, // 反转结果
-						Description: fmt.Sprintf("容器 %s 缺少资源限制", container.Name),
+						Description: pa.describe(rule, pod, ruleResult, i18n.Sprintf("容器 %s 缺少资源限制", container.Name)),
 						Remediation: ruleResult.Remediation,
+						Container:   container.Name,
 					}
 					
 					items = append(items, item)
@@ -497,6 +530,7 @@ func (pa *PodAnalyzer) analyzePodStability(pod *models.Pod) []AnalysisItem {
 	// 获取所有Pod稳定性相关规则
 	filter := rules.RuleFilter{
 		Categories: []string{"pod"},
+		Resource:   podResource(pod),
 	}
 	allRules := pa.rulesEngine.GetRules(filter)
 
@@ -520,7 +554,7 @@ func (pa *PodAnalyzer) analyzePodStability(pod *models.Pod) []AnalysisItem {
 				Value:       fmt.Sprintf("%d", pod.TotalRestarts),
 				Threshold:   fmt.Sprintf("%v", ruleResult.ExpectedValue),
 				Passed:      !ruleResult.Passed, // 反转结果
-				Description: fmt.Sprintf("Pod总重启次数为 %d", pod.TotalRestarts),
+				Description: pa.describe(rule, pod, ruleResult, i18n.Sprintf("Pod总重启次数为 %d", pod.TotalRestarts)),
 				Remediation: ruleResult.Remediation,
 			}
 			
@@ -528,9 +562,59 @@ func (pa *PodAnalyzer) analyzePodStability(pod *models.Pod) []AnalysisItem {
 		}
 	}
 
+	// 检查容器是否崩溃
+	for _, container := range pod.Containers {
+		reason, crashed := containerCrashReason(container.State)
+		if !crashed {
+			continue
+		}
+		for _, rule := range allRules {
+			if rule.Condition.Metric == "container_crash" {
+				// 评估规则
+				ruleResult, err := pa.rulesEngine.EvaluateRule(rule, "boolean", true)
+				if err != nil {
+					// 记录错误并继续
+					continue
+				}
+
+				// 创建分析项
+				item := AnalysisItem{
+					RuleID:      ruleResult.RuleID,
+					Name:        ruleResult.RuleName,
+					Category:    rule.Category,
+					Severity:    ruleResult.Severity,
+					Metric:      "container_crash",
+					Value:       "true",
+					Threshold:   "false",
+					Passed:      !ruleResult.Passed, // 反转结果
+					Description: pa.describe(rule, pod, ruleResult, i18n.Sprintf("容器 %s 崩溃: %s", container.Name, reason)),
+					Remediation: ruleResult.Remediation,
+					Container:   container.Name,
+				}
+
+				items = append(items, item)
+			}
+		}
+	}
+
 	return items
 }
 
+// containerCrashReason 判断容器是否处于崩溃状态（CrashLoopBackOff 或异常退出），并返回原因
+func containerCrashReason(state corev1.ContainerState) (string, bool) {
+	if state.Waiting != nil && state.Waiting.Reason == "CrashLoopBackOff" {
+		return state.Waiting.Reason, true
+	}
+	if state.Terminated != nil && state.Terminated.ExitCode != 0 {
+		reason := state.Terminated.Reason
+		if reason == "" {
+			reason = "Error"
+		}
+		return i18n.Sprintf("%s (退出码 %d)", reason, state.Terminated.ExitCode), true
+	}
+	return "", false
+}
+
 // analyzePodConfig 分析Pod配置
 func (pa *PodAnalyzer) analyzePodConfig(pod *models.Pod) []AnalysisItem {
 	items := make([]AnalysisItem, 0)
@@ -538,6 +622,7 @@ func (pa *PodAnalyzer) analyzePodConfig(pod *models.Pod) []AnalysisItem {
 	// 获取所有Pod配置相关规则
 	filter := rules.RuleFilter{
 		Categories: []string{"pod"},
+		Resource:   podResource(pod),
 	}
 	allRules := pa.rulesEngine.GetRules(filter)
 
@@ -562,7 +647,7 @@ func (pa *PodAnalyzer) analyzePodConfig(pod *models.Pod) []AnalysisItem {
 					Value:       "true",
 					Threshold:   "false",
 					Passed:      !ruleResult.Passed, // 反转结果
-					Description: "Pod缺少健康检查探针",
+					Description: pa.describe(rule, pod, ruleResult, i18n.T("Pod缺少健康检查探针")),
 					Remediation: ruleResult.Remediation,
 				}
 				
@@ -574,39 +659,159 @@ func (pa *PodAnalyzer) analyzePodConfig(pod *models.Pod) []AnalysisItem {
 	return items
 }
 
-// calculateHealthScore 计算Pod健康评分
-func (pa *PodAnalyzer) calculateHealthScore(items []AnalysisItem) int {
-	if len(items) == 0 {
-		return 100
+// analyzeJSONPathRules 分析按 jsonpath 从原始Pod对象取值的规则，条件成立表示存在问题
+func (pa *PodAnalyzer) analyzeJSONPathRules(pod *models.Pod) []AnalysisItem {
+	items := make([]AnalysisItem, 0)
+	if pod.Object == nil {
+		return items
 	}
 
-	// 基础分数
-	baseScore := 100
-	
-	// 不同严重程度的扣分
-	deductions := map[string]int{
-		"critical": 20,
-		"warning":  10,
-		"info":     5,
+	filter := rules.RuleFilter{
+		Categories: []string{"pod"},
+		Resource:   podResource(pod),
 	}
-	
-	// 计算扣分
-	totalDeduction := 0
-	for _, item := range items {
-		if !item.Passed {
-			if deduction, exists := deductions[item.Severity]; exists {
-				totalDeduction += deduction
+	for _, rule := range pa.rulesEngine.GetRules(filter) {
+		if rule.Condition.Metric != rules.JSONPathMetric {
+			continue
+		}
+		ruleResult, err := pa.rulesEngine.EvaluateRule(rule, rules.ObjectMetricType, pod.Object)
+		if err != nil {
+			// 记录错误并继续
+			continue
+		}
+
+		items = append(items, AnalysisItem{
+			RuleID:      ruleResult.RuleID,
+			Name:        ruleResult.RuleName,
+			Category:    rule.Category,
+			Severity:    ruleResult.Severity,
+			Metric:      rule.Condition.Path,
+			Value:       fmt.Sprintf("%v", ruleResult.ActualValue),
+			Threshold:   fmt.Sprintf("%v", ruleResult.ExpectedValue),
+			Passed:      !ruleResult.Passed, // 反转结果
+			Description: pa.describe(rule, pod, ruleResult, ruleResult.Message),
+			Remediation: ruleResult.Remediation,
+		})
+	}
+
+	return items
+}
+
+// analyzeCompoundRules 分析 all/any/not 组合条件规则，各子条件的指标值由 GetMetricValue 获取，条件成立表示存在问题
+func (pa *PodAnalyzer) analyzeCompoundRules(pod *models.Pod) []AnalysisItem {
+	items := make([]AnalysisItem, 0)
+
+	filter := rules.RuleFilter{
+		Categories: []string{"pod"},
+		Resource:   podResource(pod),
+	}
+	source := rules.MetricSource(func(metric string) (interface{}, string, bool) {
+		return GetMetricValue(pod, metric)
+	})
+	for _, rule := range pa.rulesEngine.GetRules(filter) {
+		if !rule.Condition.IsCompound() {
+			continue
+		}
+		ruleResult, err := pa.rulesEngine.EvaluateRule(rule, "", source)
+		if err != nil {
+			// 记录错误并继续
+			continue
+		}
+
+		items = append(items, AnalysisItem{
+			RuleID:      ruleResult.RuleID,
+			Name:        ruleResult.RuleName,
+			Category:    rule.Category,
+			Severity:    ruleResult.Severity,
+			Metric:      rules.DescribeCondition(rule.Condition),
+			Value:       fmt.Sprintf("%v", ruleResult.ActualValue),
+			Threshold:   fmt.Sprintf("%v", ruleResult.ExpectedValue),
+			Passed:      !ruleResult.Passed, // 反转结果
+			Description: pa.describe(rule, pod, ruleResult, ruleResult.Message),
+			Remediation: ruleResult.Remediation,
+		})
+	}
+
+	return items
+}
+
+// GetMetricValue 获取Pod指定指标的实际值及其验证器类型，需与 catalog.go 中注册的指标保持一致。
+// 容器级指标按Pod汇总：使用率取各容器的最大值，缺少资源限制、崩溃为任一容器满足即成立；Pod处于Running状态时非Running时长为0
+func GetMetricValue(pod *models.Pod, metric string) (interface{}, string, bool) {
+	switch metric {
+	case "pod_not_running_duration":
+		if pod.Phase == corev1.PodRunning {
+			return 0.0, "numeric", true
+		}
+		return time.Since(pod.CreationTime).Minutes(), "numeric", true
+	case "pod_cpu_utilization", "pod_memory_utilization":
+		max := 0.0
+		for _, container := range pod.Containers {
+			utilization := container.CPU.Utilization
+			if metric == "pod_memory_utilization" {
+				utilization = container.Memory.Utilization
+			}
+			if utilization > max {
+				max = utilization
+			}
+		}
+		return max, "numeric", true
+	case "pod_missing_resource_limits":
+		for _, container := range pod.Containers {
+			cpuLimit := container.Limits.Cpu()
+			memoryLimit := container.Limits.Memory()
+			if (cpuLimit == nil || cpuLimit.IsZero()) && (memoryLimit == nil || memoryLimit.IsZero()) {
+				return true, "boolean", true
 			}
 		}
+		return false, "boolean", true
+	case "pod_restart_count":
+		return float64(pod.TotalRestarts), "numeric", true
+	case "container_crash":
+		for _, container := range pod.Containers {
+			if _, crashed := containerCrashReason(container.State); crashed {
+				return true, "boolean", true
+			}
+		}
+		return false, "boolean", true
+	case "pod_missing_probes":
+		return !pod.HasLivenessProbe && !pod.HasReadinessProbe, "boolean", true
+	case rules.JSONPathMetric:
+		return pod.Object, rules.ObjectMetricType, pod.Object != nil
+	default:
+		return nil, "", false
 	}
-	
-	// 计算最终分数，确保不低于0
-	score := baseScore - totalDeduction
-	if score < 0 {
-		score = 0
+}
+
+// podResource 返回用于匹配规则作用范围的Pod信息
+func podResource(pod *models.Pod) *rules.ResourceMeta {
+	return &rules.ResourceMeta{
+		Kind:      "Pod",
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+		Labels:    pod.Labels,
 	}
-	
-	return score
+}
+
+// describe 返回分析项的描述：Pod规则描述问题状态，条件成立（存在问题）且规则设置了消息模板时使用模板生成的消息
+func (pa *PodAnalyzer) describe(rule rules.Rule, pod *models.Pod, result *rules.RuleResult, fallback string) string {
+	if result.Passed {
+		if message, ok := pa.rulesEngine.RenderMessage(rule, podResource(pod), result); ok {
+			return message
+		}
+	}
+	return fallback
+}
+
+// calculateHealthScore 按规则配置中的评分模型计算Pod健康评分
+func (pa *PodAnalyzer) calculateHealthScore(items []AnalysisItem) rules.ScoreBreakdown {
+	failed := make([]rules.ScoreItem, 0, len(items))
+	for _, item := range items {
+		if !item.Passed {
+			failed = append(failed, rules.ScoreItem{RuleID: item.RuleID, Severity: item.Severity})
+		}
+	}
+	return pa.rulesEngine.Scoring().Score(failed)
 }
 
 // formatDuration 格式化时间间隔
@@ -654,4 +859,18 @@ func getContainerState(state corev1.ContainerState) string {
 		return fmt.Sprintf("Terminated (exit code: %d)", state.Terminated.ExitCode)
 	}
 	return "Unknown"
-} 
\ No newline at end of file
+} 
+
+// durationItems 返回分析项中与持续条件相关的字段，供规则引擎处理配置了 duration 的规则
+func durationItems(items []AnalysisItem) []rules.ConditionItem {
+	conditions := make([]rules.ConditionItem, len(items))
+	for i := range items {
+		conditions[i] = rules.ConditionItem{
+			RuleID:       items[i].RuleID,
+			Passed:       &items[i].Passed,
+			Description:  &items[i].Description,
+			PendingSince: &items[i].PendingSince,
+		}
+	}
+	return conditions
+}
@@ -0,0 +1,18 @@
+package pod
+
+import "github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+
+// 注册Pod分析器产生的指标，供规则检查使用，需与 GetMetricValue 保持一致
+func init() {
+	rules.RegisterCompoundKind("pod")
+	rules.RegisterMetrics(
+		rules.MetricSpec{Name: "pod_not_running_duration", Kind: "pod", Type: "numeric", Description: "Pod处于非Running状态的时长(分钟)", Runtime: true},
+		rules.MetricSpec{Name: "pod_cpu_utilization", Kind: "pod", Type: "numeric", Description: "容器CPU使用率(%)", Runtime: true},
+		rules.MetricSpec{Name: "pod_memory_utilization", Kind: "pod", Type: "numeric", Description: "容器内存使用率(%)", Runtime: true},
+		rules.MetricSpec{Name: "pod_missing_resource_limits", Kind: "pod", Type: "boolean", Description: "容器是否缺少资源限制"},
+		rules.MetricSpec{Name: "pod_restart_count", Kind: "pod", Type: "numeric", Description: "Pod总重启次数", Runtime: true},
+		rules.MetricSpec{Name: "container_crash", Kind: "pod", Type: "boolean", Description: "容器是否崩溃", Runtime: true},
+		rules.MetricSpec{Name: "pod_missing_probes", Kind: "pod", Type: "boolean", Description: "Pod是否缺少健康检查探针"},
+		rules.JSONPathMetricSpec("pod"),
+	)
+}
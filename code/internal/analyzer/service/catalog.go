@@ -0,0 +1,23 @@
+package service
+
+import "github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+
+// 注册Service分析器产生的指标，供规则检查使用，需与 GetMetricValue 保持一致
+func init() {
+	rules.RegisterCompoundKind("service")
+	rules.RegisterMetrics(
+		rules.MetricSpec{Name: "is_loadbalancer_type", Kind: "service", Type: "boolean", Description: "是否为LoadBalancer类型"},
+		rules.MetricSpec{Name: "is_nodeport_type", Kind: "service", Type: "boolean", Description: "是否为NodePort类型"},
+		rules.MetricSpec{Name: "min_port", Kind: "service", Type: "numeric", Description: "最小端口号"},
+		rules.MetricSpec{Name: "max_port", Kind: "service", Type: "numeric", Description: "最大端口号"},
+		rules.MetricSpec{Name: "has_sensitive_annotations", Kind: "service", Type: "boolean", Description: "注解中是否包含敏感信息"},
+		rules.MetricSpec{Name: "has_ready_endpoints", Kind: "service", Type: "boolean", Description: "是否有就绪的端点", Runtime: true},
+		rules.MetricSpec{Name: "has_matching_pods", Kind: "service", Type: "boolean", Description: "是否有就绪且运行中的匹配Pod", Runtime: true},
+		rules.MetricSpec{Name: "has_labels", Kind: "service", Type: "map", Description: "Service标签"},
+		rules.MetricSpec{Name: "has_selector", Kind: "service", Type: "boolean", Description: "是否设置了选择器"},
+		rules.MetricSpec{Name: "has_load_balancer_source_ranges", Kind: "service", Type: "boolean", Description: "是否限制了LoadBalancer来源地址"},
+		rules.MetricSpec{Name: "namespace", Kind: "service", Type: "string", Description: "命名空间"},
+		rules.MetricSpec{Name: "type", Kind: "service", Type: "string", Description: "Service类型"},
+		rules.JSONPathMetricSpec("service"),
+	)
+}
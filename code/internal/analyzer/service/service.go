@@ -1,17 +1,194 @@
 package service
 
 import (
+	"context"
+	"fmt"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
 )
 
+// RulesEngine 规则引擎接口
+type RulesEngine interface {
+	// GetRules 获取规则
+	GetRules(filter rules.RuleFilter) []rules.Rule
+	// EvaluateRuleFor 评估规则对指定资源的检查结果，未通过时按规则的消息模板生成消息
+	EvaluateRuleFor(rule rules.Rule, resource *rules.ResourceMeta, metricType string, actualValue interface{}) (*rules.RuleResult, error)
+	// ApplyConditionDurations 处理配置了 duration 的规则
+	ApplyConditionDurations(resource string, items []rules.ConditionItem)
+}
+
+// AnalysisItem 单个分析项目
+type AnalysisItem struct {
+	// 规则ID
+	RuleID string `json:"rule_id"`
+	// 规则名称
+	Name string `json:"name"`
+	// 类别
+	Category string `json:"category"`
+	// 严重程度：critical, error, warning, info
+	Severity string `json:"severity"`
+	// 检查的指标
+	Metric string `json:"metric"`
+	// 指标值
+	Value string `json:"value"`
+	// 阈值
+	Threshold string `json:"threshold"`
+	// 比较结果 (是否通过)
+	Passed bool `json:"passed"`
+	// 描述
+	Description string `json:"description"`
+	// 建议的修复措施
+	Remediation string `json:"remediation"`
+	// 配置了持续时间的规则条件首次成立的时间
+	PendingSince *time.Time `json:"pending_since,omitempty"`
+}
+
+// AnalysisResult 表示单个 Service 的分析结果
+type AnalysisResult struct {
+	// Service名称
+	ServiceName string `json:"service_name"`
+	// Service命名空间
+	Namespace string `json:"namespace"`
+	// 分析结果项目列表
+	Items []AnalysisItem `json:"items"`
+	// 分析时间
+	AnalyzedAt time.Time `json:"analyzed_at"`
+	// 被分析的Service
+	Service models.Service `json:"service"`
+}
+
 // ServiceAnalyzer Service 安全分析器
-type ServiceAnalyzer struct{}
+type ServiceAnalyzer struct {
+	rulesEngine RulesEngine
+	collector   *collector.ServiceCollector
+}
 
 // NewServiceAnalyzer 创建 Service 分析器
-func NewServiceAnalyzer() *ServiceAnalyzer {
-	return &ServiceAnalyzer{}
+func NewServiceAnalyzer(rulesEngine RulesEngine, collector *collector.ServiceCollector) *ServiceAnalyzer {
+	return &ServiceAnalyzer{
+		rulesEngine: rulesEngine,
+		collector:   collector,
+	}
+}
+
+// AnalyzeService 分析单个 Service
+// Service规则描述的是期望状态（如有就绪端点），规则引擎返回Passed=true即表示检查通过，无需反转
+func (a *ServiceAnalyzer) AnalyzeService(service *models.Service) *AnalysisResult {
+	result := &AnalysisResult{
+		ServiceName: service.Name,
+		Namespace:   service.Namespace,
+		Items:       make([]AnalysisItem, 0),
+		AnalyzedAt:  time.Now(),
+		Service:     *service,
+	}
+
+	filter := rules.RuleFilter{
+		Categories: []string{"service"},
+		Resource: &rules.ResourceMeta{
+			Kind:      "Service",
+			Name:      service.Name,
+			Namespace: service.Namespace,
+			Labels:    service.Labels,
+		},
+	}
+	source := rules.MetricSource(func(metric string) (interface{}, string, bool) {
+		return a.GetMetricValue(service, metric)
+	})
+	for _, rule := range a.rulesEngine.GetRules(filter) {
+		metric := rule.Condition.Metric
+		actualValue, metricType, ok := a.GetMetricValue(service, metric)
+		if rule.Condition.IsCompound() {
+			// 组合条件由规则引擎按需获取各子条件的指标值
+			metric = rules.DescribeCondition(rule.Condition)
+			actualValue, metricType, ok = source, "", true
+		} else if metric == rules.JSONPathMetric {
+			metric = rule.Condition.Path
+		}
+		if !ok {
+			continue
+		}
+
+		ruleResult, err := a.rulesEngine.EvaluateRuleFor(rule, filter.Resource, metricType, actualValue)
+		if err != nil {
+			// 记录错误并继续
+			fmt.Fprint(os.Stderr, i18n.Sprintf("规则评估失败 (%s, Service %s/%s): %v\n", rule.ID, service.Namespace, service.Name, err))
+			continue
+		}
+
+		result.Items = append(result.Items, AnalysisItem{
+			RuleID:      ruleResult.RuleID,
+			Name:        ruleResult.RuleName,
+			Category:    rule.Category,
+			Severity:    ruleResult.Severity,
+			Metric:      metric,
+			Value:       fmt.Sprintf("%v", ruleResult.ActualValue),
+			Threshold:   fmt.Sprintf("%v", ruleResult.ExpectedValue),
+			Passed:      ruleResult.Passed,
+			Description: ruleResult.Message,
+			Remediation: ruleResult.Remediation,
+		})
+	}
+
+	// 处理需要持续一段时间才报告的规则
+	a.rulesEngine.ApplyConditionDurations(fmt.Sprintf("Service/%s/%s", service.Namespace, service.Name), durationItems(result.Items))
+
+	return result
+}
+
+// AnalyzeServicesInNamespace 分析命名空间中的所有 Service
+func (a *ServiceAnalyzer) AnalyzeServicesInNamespace(namespace string) ([]*AnalysisResult, error) {
+	if a.collector == nil {
+		return nil, fmt.Errorf("未设置 ServiceCollector")
+	}
+	services, err := a.collector.GetServices(context.TODO(), namespace)
+	if err != nil {
+		return nil, fmt.Errorf("获取 Service 列表失败: %w", err)
+	}
+	results := make([]*AnalysisResult, 0, len(services))
+	for i := range services {
+		results = append(results, a.AnalyzeService(&services[i]))
+	}
+	return results, nil
+}
+
+// GetMetricValue 获取 Service 指定指标的实际值及其验证器类型
+func (a *ServiceAnalyzer) GetMetricValue(service *models.Service, metric string) (interface{}, string, bool) {
+	switch metric {
+	case "is_loadbalancer_type":
+		return a.IsLoadBalancerType(service), "boolean", true
+	case "is_nodeport_type":
+		return a.IsNodePortType(service), "boolean", true
+	case "min_port":
+		return a.GetMinPort(service), "numeric", true
+	case "max_port":
+		return a.GetMaxPort(service), "numeric", true
+	case "has_sensitive_annotations":
+		return a.HasSensitiveAnnotations(service), "boolean", true
+	case "has_ready_endpoints":
+		return a.HasReadyEndpoints(service), "boolean", true
+	case "has_matching_pods":
+		return a.HasMatchingPods(service), "boolean", true
+	case "has_labels":
+		return service.Labels, "map", true
+	case "has_selector":
+		return a.HasSelector(service), "boolean", true
+	case "has_load_balancer_source_ranges":
+		return len(service.LoadBalancerSourceRanges) > 0, "boolean", true
+	case "namespace":
+		return service.Namespace, "string", true
+	case "type":
+		return service.Type, "string", true
+	case rules.JSONPathMetric:
+		return service.Object, rules.ObjectMetricType, service.Object != nil
+	default:
+		return nil, "", false
+	}
 }
 
 // CheckServiceTypeSecurity 检查服务类型安全性
@@ -43,6 +220,21 @@ func (a *ServiceAnalyzer) GetMinPort(service *models.Service) int32 {
 	return minPort
 }
 
+// GetMaxPort 获取最大端口号
+func (a *ServiceAnalyzer) GetMaxPort(service *models.Service) int32 {
+	if len(service.Ports) == 0 {
+		return 0
+	}
+
+	maxPort := service.Ports[0].Port
+	for _, port := range service.Ports {
+		if port.Port > maxPort {
+			maxPort = port.Port
+		}
+	}
+	return maxPort
+}
+
 // HasReadyEndpoints 检查是否有就绪的端点
 func (a *ServiceAnalyzer) HasReadyEndpoints(service *models.Service) bool {
 	return service.ReadyEndpoints > 0
@@ -160,3 +352,17 @@ func (a *ServiceAnalyzer) HasAnnotation(service *models.Service, key string) boo
 	_, exists := service.Annotations[key]
 	return exists
 }
+
+// durationItems 返回分析项中与持续条件相关的字段，供规则引擎处理配置了 duration 的规则
+func durationItems(items []AnalysisItem) []rules.ConditionItem {
+	conditions := make([]rules.ConditionItem, len(items))
+	for i := range items {
+		conditions[i] = rules.ConditionItem{
+			RuleID:       items[i].RuleID,
+			Passed:       &items[i].Passed,
+			Description:  &items[i].Description,
+			PendingSince: &items[i].PendingSince,
+		}
+	}
+	return conditions
+}
@@ -7,9 +7,11 @@ import (
 	"strings"
 
 
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
+	"k8s.io/apimachinery/pkg/labels"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/api/core/v1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -28,8 +30,12 @@ type Client struct {
 	// ContextName 是使用的kubeconfig上下文名称
 	ContextName string
 	// MetricsClient 是获取指标数据的客户端
-	MetricsClient *versioned.Clientset
+	MetricsClient versioned.Interface
+	// DynamicClient 是按 GroupVersionResource 访问任意资源（包括CRD）的客户端
+	DynamicClient dynamic.Interface
 	Config *rest.Config // 新增字段
+	// Store 离线分析的对象集合，设置时从中读取对象而不访问 API Server
+	Store *ObjectStore
 }
 
 // NewClient 创建一个新的Kubernetes客户端
@@ -77,17 +83,30 @@ func NewClient(configPath string, contextName string) (*Client, error) {
 		return nil, fmt.Errorf("创建Metrics客户端失败: %w", err)
 	}
 
+	// 创建 dynamic client
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("创建Dynamic客户端失败: %w", err)
+	}
+
 	return &Client{
 		Clientset: clientset, // *kubernetes.Clientset 实现了 kubernetes.Interface
 		ConfigPath: configPath,
 		ContextName: contextName,
 		MetricsClient: metricsClient,
+		DynamicClient: dynamicClient,
 		Config: config, // 赋值
 	}, nil
 }
 
 // GetServerVersion 获取Kubernetes集群版本
 func (c *Client) GetServerVersion() (string, error) {
+	if c.Store != nil {
+		if c.Store.serverVersion == "" {
+			return "", fmt.Errorf("快照中没有记录集群版本")
+		}
+		return c.Store.serverVersion, nil
+	}
 	version, err := c.Clientset.Discovery().ServerVersion()
 	if err != nil {
 		return "", fmt.Errorf("获取集群版本失败: %w", err)
@@ -184,6 +203,9 @@ func SwitchContext(configPath string, contextName string) error {
 
 // 获取所有 Node 原生对象
 func (c *Client) ListRawNodes(ctx context.Context) ([]v1.Node, error) {
+	if c.Store != nil {
+		return storeList[v1.Node](c.Store, ""), nil
+	}
 	nodes, err := c.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
@@ -193,11 +215,17 @@ func (c *Client) ListRawNodes(ctx context.Context) ([]v1.Node, error) {
 
 // 获取单个 Pod 原生对象
 func (c *Client) GetRawPod(ctx context.Context, namespace, name string) (*v1.Pod, error) {
+	if c.Store != nil {
+		return storeGet[v1.Pod](c.Store, v1.Resource("pods"), namespace, name)
+	}
 	return c.Clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 // 获取所有 Pod 原生对象
 func (c *Client) ListRawPods(ctx context.Context, namespace string) ([]v1.Pod, error) {
+	if c.Store != nil {
+		return storeList[v1.Pod](c.Store, namespace), nil
+	}
 	pods, err := c.Clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
@@ -207,6 +235,9 @@ func (c *Client) ListRawPods(ctx context.Context, namespace string) ([]v1.Pod, e
 
 // 获取所有 Node 原生 metrics
 func (c *Client) ListRawNodeMetrics(ctx context.Context) ([]metricsv1beta1.NodeMetrics, error) {
+	if c.Store != nil {
+		return storeList[metricsv1beta1.NodeMetrics](c.Store, ""), nil
+	}
 	metrics, err := c.MetricsClient.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
@@ -216,21 +247,33 @@ func (c *Client) ListRawNodeMetrics(ctx context.Context) ([]metricsv1beta1.NodeM
 
 // 获取单个 Node 原生对象
 func (c *Client) GetRawNode(ctx context.Context, name string) (*v1.Node, error) {
+	if c.Store != nil {
+		return storeGet[v1.Node](c.Store, v1.Resource("nodes"), "", name)
+	}
 	return c.Clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
 }
 
 // 获取单个 Node 原生 metrics
 func (c *Client) GetRawNodeMetrics(ctx context.Context, name string) (*metricsv1beta1.NodeMetrics, error) {
+	if c.Store != nil {
+		return storeGet[metricsv1beta1.NodeMetrics](c.Store, metricsv1beta1.SchemeGroupVersion.WithResource("nodes").GroupResource(), "", name)
+	}
 	return c.MetricsClient.MetricsV1beta1().NodeMetricses().Get(ctx, name, metav1.GetOptions{})
 } 
 
 // 获取单个 Pod 原生 metrics
 func (c *Client) GetRawPodMetrics(ctx context.Context, namespace, name string) (*metricsv1beta1.PodMetrics, error) {
+	if c.Store != nil {
+		return storeGet[metricsv1beta1.PodMetrics](c.Store, metricsv1beta1.SchemeGroupVersion.WithResource("pods").GroupResource(), namespace, name)
+	}
 	return c.MetricsClient.MetricsV1beta1().PodMetricses(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 // 获取所有 Pod 原生 metrics
 func (c *Client) ListRawPodMetrics(ctx context.Context, namespace string) ([]metricsv1beta1.PodMetrics, error) {
+	if c.Store != nil {
+		return storeList[metricsv1beta1.PodMetrics](c.Store, namespace), nil
+	}
 	metrics, err := c.MetricsClient.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
@@ -238,8 +281,40 @@ func (c *Client) ListRawPodMetrics(ctx context.Context, namespace string) ([]met
 	return metrics.Items, nil
 }
 
+// 获取所有命名空间的标签，key 为命名空间名称
+func (c *Client) ListNamespaceLabels(ctx context.Context) (map[string]map[string]string, error) {
+	namespaces, err := c.ListRawNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]map[string]string, len(namespaces))
+	for _, ns := range namespaces {
+		result[ns.Name] = ns.Labels
+	}
+	return result, nil
+}
+
+// 获取指定命名空间的标签
+func (c *Client) GetNamespaceLabels(ctx context.Context, name string) (map[string]string, error) {
+	if c.Store != nil {
+		namespace, err := storeGet[v1.Namespace](c.Store, v1.Resource("namespaces"), "", name)
+		if err != nil {
+			return nil, err
+		}
+		return namespace.Labels, nil
+	}
+	namespace, err := c.Clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return namespace.Labels, nil
+}
+
 // 获取 Pod 相关事件
 func (c *Client) GetRawPodEvents(ctx context.Context, namespace, name string) ([]v1.Event, error) {
+	if c.Store != nil {
+		return c.Store.podEvents(namespace, name), nil
+	}
 	fieldSelector := fmt.Sprintf("involvedObject.kind=Pod,involvedObject.name=%s,involvedObject.namespace=%s", name, namespace)
 	events, err := c.Clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
 		FieldSelector: fieldSelector,
@@ -252,6 +327,9 @@ func (c *Client) GetRawPodEvents(ctx context.Context, namespace, name string) ([
 
 // 获取 Pod 日志
 func (c *Client) GetRawPodLogs(ctx context.Context, namespace, name, container string, lines int) ([]string, error) {
+	if c.Store != nil {
+		return nil, fmt.Errorf("离线分析时无法获取Pod日志")
+	}
 	tailLines := int64(lines)
 	podLogOptions := v1.PodLogOptions{
 		Container: container,
@@ -281,6 +359,9 @@ func (c *Client) GetRawPodLogs(ctx context.Context, namespace, name, container s
 
 // 获取所有 Deployment 原生对象
 func (c *Client) ListRawDeployments(ctx context.Context, namespace string) ([]appsv1.Deployment, error) {
+	if c.Store != nil {
+		return storeList[appsv1.Deployment](c.Store, namespace), nil
+	}
 	deployments, err := c.Clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
@@ -292,21 +373,39 @@ func (c *Client) ListRawDeployments(ctx context.Context, namespace string) ([]ap
 
 // GetServices 获取指定命名空间的所有 Service
 func (c *Client) GetServices(ctx context.Context, namespace string) (*v1.ServiceList, error) {
+	if c.Store != nil {
+		return &v1.ServiceList{Items: storeList[v1.Service](c.Store, namespace)}, nil
+	}
 	return c.Clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
 }
 
 // GetService 获取指定的 Service
 func (c *Client) GetService(ctx context.Context, namespace, name string) (*v1.Service, error) {
+	if c.Store != nil {
+		return storeGet[v1.Service](c.Store, v1.Resource("services"), namespace, name)
+	}
 	return c.Clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 // GetEndpoints 获取指定的 Endpoints
 func (c *Client) GetEndpoints(ctx context.Context, namespace, name string) (*v1.Endpoints, error) {
+	if c.Store != nil {
+		return storeGet[v1.Endpoints](c.Store, v1.Resource("endpoints"), namespace, name)
+	}
 	return c.Clientset.CoreV1().Endpoints(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 // GetPodsBySelector 根据标签选择器获取 Pod
 func (c *Client) GetPodsBySelector(ctx context.Context, namespace string, selector map[string]string) (*v1.PodList, error) {
+	if c.Store != nil {
+		pods := &v1.PodList{}
+		for _, pod := range storeList[v1.Pod](c.Store, namespace) {
+			if labels.SelectorFromSet(selector).Matches(labels.Set(pod.Labels)) {
+				pods.Items = append(pods.Items, pod)
+			}
+		}
+		return pods, nil
+	}
 	labelSelector := metav1.FormatLabelSelector(&metav1.LabelSelector{
 		MatchLabels: selector,
 	})
@@ -317,6 +416,9 @@ func (c *Client) GetPodsBySelector(ctx context.Context, namespace string, select
 
 // ListRawServices 获取所有 Service 原生对象
 func (c *Client) ListRawServices(ctx context.Context, namespace string) ([]v1.Service, error) {
+	if c.Store != nil {
+		return storeList[v1.Service](c.Store, namespace), nil
+	}
 	services, err := c.Clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, err
@@ -324,3 +426,39 @@ func (c *Client) ListRawServices(ctx context.Context, namespace string) ([]v1.Se
 	return services.Items, nil
 }
 
+
+// ListRawEndpoints 获取所有 Endpoints 原生对象
+func (c *Client) ListRawEndpoints(ctx context.Context, namespace string) ([]v1.Endpoints, error) {
+	if c.Store != nil {
+		return storeList[v1.Endpoints](c.Store, namespace), nil
+	}
+	endpoints, err := c.Clientset.CoreV1().Endpoints(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return endpoints.Items, nil
+}
+
+// ListRawEvents 获取所有 Event 原生对象
+func (c *Client) ListRawEvents(ctx context.Context, namespace string) ([]v1.Event, error) {
+	if c.Store != nil {
+		return storeList[v1.Event](c.Store, namespace), nil
+	}
+	events, err := c.Clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return events.Items, nil
+}
+
+// ListRawNamespaces 获取所有 Namespace 原生对象
+func (c *Client) ListRawNamespaces(ctx context.Context) ([]v1.Namespace, error) {
+	if c.Store != nil {
+		return storeList[v1.Namespace](c.Store, ""), nil
+	}
+	namespaces, err := c.Clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return namespaces.Items, nil
+}
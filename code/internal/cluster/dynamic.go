@@ -0,0 +1,74 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ParseGroupVersionKind 解析 group/version/kind 形式的资源类型，核心组可以写成 version/kind（如 v1/ConfigMap）
+func ParseGroupVersionKind(value string) (schema.GroupVersionKind, error) {
+	i := strings.LastIndex(value, "/")
+	if i <= 0 || i == len(value)-1 {
+		return schema.GroupVersionKind{}, fmt.Errorf("资源类型 %q 格式错误，应为 group/version/kind，如 cert-manager.io/v1/Certificate 或 v1/ConfigMap", value)
+	}
+	gv, err := schema.ParseGroupVersion(value[:i])
+	if err != nil || gv.Version == "" {
+		return schema.GroupVersionKind{}, fmt.Errorf("资源类型 %q 的 group/version 无效", value)
+	}
+	return gv.WithKind(value[i+1:]), nil
+}
+
+// ResolveResource 通过 discovery 查找资源类型对应的 GroupVersionResource 及其描述（Kind 的规范写法、是否属于命名空间）；
+// kind 不区分大小写，也可以使用资源的复数或单数名称
+func (c *Client) ResolveResource(gvk schema.GroupVersionKind) (schema.GroupVersionResource, metav1.APIResource, error) {
+	var resources *metav1.APIResourceList
+	var err error
+	if c.Store != nil {
+		resources, err = c.Store.resourcesForGroupVersion(gvk.GroupVersion().String())
+	} else {
+		resources, err = c.Clientset.Discovery().ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	}
+	if err != nil {
+		return schema.GroupVersionResource{}, metav1.APIResource{}, fmt.Errorf("获取 %s 的资源列表失败: %w", gvk.GroupVersion(), err)
+	}
+	for _, resource := range resources.APIResources {
+		// 跳过子资源，如 deployments/status
+		if strings.Contains(resource.Name, "/") {
+			continue
+		}
+		if strings.EqualFold(resource.Kind, gvk.Kind) || strings.EqualFold(resource.Name, gvk.Kind) || strings.EqualFold(resource.SingularName, gvk.Kind) {
+			return gvk.GroupVersion().WithResource(resource.Name), resource, nil
+		}
+	}
+	return schema.GroupVersionResource{}, metav1.APIResource{}, fmt.Errorf("集群中不存在资源类型 %s", gvk)
+}
+
+// ListRawResources 通过 dynamic client 获取任意资源的原生对象，namespace 为空时获取所有命名空间；
+// 集群级资源忽略 namespace
+func (c *Client) ListRawResources(ctx context.Context, gvr schema.GroupVersionResource, namespaced bool, namespace string) ([]unstructured.Unstructured, error) {
+	if c.Store != nil {
+		if !namespaced {
+			namespace = ""
+		}
+		return c.Store.unstructuredList(gvr, namespace)
+	}
+	if c.DynamicClient == nil {
+		return nil, fmt.Errorf("未初始化Dynamic客户端")
+	}
+	var list *unstructured.UnstructuredList
+	var err error
+	if namespaced {
+		list, err = c.DynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	} else {
+		list, err = c.DynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
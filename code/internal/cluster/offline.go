@@ -0,0 +1,121 @@
+package cluster
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ObjectStore 离线分析使用的只读对象集合，如集群快照或资源清单中的对象。
+// Client 设置了 Store 时各读取方法从集合中按类型、命名空间和名称查找对象，不访问 API Server
+type ObjectStore struct {
+	objects       []runtime.Object
+	resources     []*metav1.APIResourceList
+	serverVersion string
+}
+
+// NewObjectStore 创建对象集合；objects 中的对象应已设置 GroupVersionKind，resources 为对象涉及的资源类型，
+// 用于解析 inspect resource 指定的资源类型
+func NewObjectStore(objects []runtime.Object, resources []*metav1.APIResourceList) *ObjectStore {
+	return &ObjectStore{objects: objects, resources: resources}
+}
+
+// SetServerVersion 设置快照中记录的集群版本
+func (s *ObjectStore) SetServerVersion(version string) {
+	s.serverVersion = version
+}
+
+// NewOfflineClient 创建由对象集合支撑的集群客户端，name 为集群名称
+func NewOfflineClient(name string, store *ObjectStore) *Client {
+	return &Client{ContextName: name, Store: store}
+}
+
+// storeList 返回集合中类型为 T 且位于 namespace 的对象副本，namespace 为空时返回所有命名空间的对象
+func storeList[T any, PT interface {
+	*T
+	metav1.Object
+	DeepCopy() *T
+}](s *ObjectStore, namespace string) []T {
+	items := make([]T, 0)
+	for _, obj := range s.objects {
+		typed, ok := obj.(PT)
+		if !ok || (namespace != "" && typed.GetNamespace() != namespace) {
+			continue
+		}
+		items = append(items, *typed.DeepCopy())
+	}
+	return items
+}
+
+// storeGet 返回集合中类型为 T 的指定对象的副本，不存在时返回与 API Server 相同的 NotFound 错误
+func storeGet[T any, PT interface {
+	*T
+	metav1.Object
+	DeepCopy() *T
+}](s *ObjectStore, resource schema.GroupResource, namespace, name string) (*T, error) {
+	for _, obj := range s.objects {
+		typed, ok := obj.(PT)
+		if ok && typed.GetNamespace() == namespace && typed.GetName() == name {
+			return typed.DeepCopy(), nil
+		}
+	}
+	return nil, apierrors.NewNotFound(resource, name)
+}
+
+// podEvents 返回关联到指定 Pod 的事件，与在线时按 involvedObject 字段选择器查询的结果一致
+func (s *ObjectStore) podEvents(namespace, name string) []v1.Event {
+	events := make([]v1.Event, 0)
+	for _, event := range storeList[v1.Event](s, namespace) {
+		involved := event.InvolvedObject
+		if involved.Kind == "Pod" && involved.Name == name && involved.Namespace == namespace {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// resourcesForGroupVersion 返回集合中指定 group/version 的资源类型
+func (s *ObjectStore) resourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	for _, list := range s.resources {
+		if list.GroupVersion == groupVersion {
+			return list, nil
+		}
+	}
+	return nil, apierrors.NewNotFound(schema.GroupResource{Group: groupVersion}, "")
+}
+
+// unstructuredList 返回集合中属于 gvr 的对象，转换为非结构化对象；namespace 为空时返回所有命名空间的对象
+func (s *ObjectStore) unstructuredList(gvr schema.GroupVersionResource, namespace string) ([]unstructured.Unstructured, error) {
+	items := make([]unstructured.Unstructured, 0)
+	for _, obj := range s.objects {
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		if gvk.GroupVersion() != gvr.GroupVersion() {
+			continue
+		}
+		if plural, _ := meta.UnsafeGuessKindToResource(gvk); plural.Resource != gvr.Resource {
+			continue
+		}
+		var u *unstructured.Unstructured
+		if typed, ok := obj.(*unstructured.Unstructured); ok {
+			u = typed.DeepCopy()
+		} else {
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+			if err != nil {
+				return nil, fmt.Errorf("转换 %s 对象失败: %w", gvk.Kind, err)
+			}
+			u = &unstructured.Unstructured{Object: content}
+			u.SetGroupVersionKind(gvk)
+		}
+		if namespace != "" && u.GetNamespace() != namespace {
+			continue
+		}
+		items = append(items, *u)
+	}
+	return items, nil
+}
@@ -23,12 +23,13 @@ func (dc *DeploymentCollector) GetDeployments(ctx context.Context, namespace str
 	}
 	result := make([]models.Deployment, 0, len(deployments))
 	for _, d := range deployments {
-		result = append(result, convertDeploymentToModel(&d))
+		result = append(result, ConvertDeploymentToModel(&d))
 	}
 	return result, nil
 }
 
-func convertDeploymentToModel(d *appsv1.Deployment) models.Deployment {
+// ConvertDeploymentToModel 将Kubernetes Deployment转换为内部Deployment模型
+func ConvertDeploymentToModel(d *appsv1.Deployment) models.Deployment {
 	containers := make([]models.DeploymentContainer, 0, len(d.Spec.Template.Spec.Containers))
 	for _, c := range d.Spec.Template.Spec.Containers {
 		containers = append(containers, models.DeploymentContainer{
@@ -50,6 +51,7 @@ func convertDeploymentToModel(d *appsv1.Deployment) models.Deployment {
 		AvailableReplicas: d.Status.AvailableReplicas,
 		Strategy:    string(d.Spec.Strategy.Type),
 		Containers:  containers,
+		Object:      toObject(d),
 	}
 }
 
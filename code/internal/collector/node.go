@@ -10,7 +10,6 @@ import (
 	
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
-	"k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
 // NodeCollector 接口，统一节点采集入口
@@ -22,23 +21,15 @@ type NodeCollector interface {
 }
 
 // nodeCollectorImpl 节点数据收集器实现
+// 节点指标统一通过 cluster.Client 的 MetricsClient 获取
 type nodeCollectorImpl struct {
 	client *cluster.Client
-	//用于访问 Kubernetes Metrics API 的客户端，用于获取节点指标
-	metricsClient *versioned.Clientset
 }
 
 // NewNodeCollector 创建一个新的节点收集器
 func NewNodeCollector(client *cluster.Client) (NodeCollector, error) {
-	// 创建metrics客户端
-	metricsClient, err := versioned.NewForConfig(client.Config)
-	if err != nil {
-		return nil, fmt.Errorf("无法创建metrics客户端: %w", err)
-	}
-
 	return &nodeCollectorImpl{
 		client: client,
-		metricsClient: metricsClient,
 	}, nil
 }
 
@@ -240,6 +231,8 @@ func convertNodeToModel(node *corev1.Node, usage corev1.ResourceList, allocated
 		Ready:        ready,
 		Schedulable:  schedulable,
 		Labels:       node.Labels,
+		Annotations:  node.Annotations,
+		Object:       toObject(node),
 		Taints:       node.Spec.Taints,
 		RunningPods:  int(podsQ.AsApproximateFloat64()),
 		CustomMetrics: make(map[string]models.CustomMetric),
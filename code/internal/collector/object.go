@@ -0,0 +1,14 @@
+package collector
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// toObject 将Kubernetes对象转换为通用的字段映射，供规则中的 jsonpath 指标使用；转换失败时返回nil
+func toObject(obj interface{}) map[string]interface{} {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil
+	}
+	return content
+}
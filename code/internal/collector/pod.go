@@ -3,6 +3,7 @@ package collector
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
@@ -38,7 +39,7 @@ func (pc *PodCollector) GetPods(ctx context.Context, namespace string) (*models.
 	podMetricsList, err := pc.client.ListRawPodMetrics(ctx, namespace)
 	podMetricsMap := make(map[string]map[string]corev1.ResourceList) // namespace/podName -> containerName -> metrics
 	if err != nil {
-		fmt.Printf("警告: 获取Pod指标失败: %v\n", err)
+		fmt.Fprintf(os.Stderr, "警告: 获取Pod指标失败: %v\n", err)
 	} else {
 		for _, metric := range podMetricsList {
 			key := fmt.Sprintf("%s/%s", metric.Namespace, metric.Name)
@@ -60,7 +61,7 @@ func (pc *PodCollector) GetPods(ctx context.Context, namespace string) (*models.
 		events, err := pc.client.GetRawPodEvents(ctx, pod.Namespace, pod.Name)
 		modelEvents := make([]models.Event, 0, len(events))
 		if err != nil {
-			fmt.Printf("警告: 获取Pod %s/%s 的事件失败: %v\n", pod.Namespace, pod.Name, err)
+			fmt.Fprintf(os.Stderr, "警告: 获取Pod %s/%s 的事件失败: %v\n", pod.Namespace, pod.Name, err)
 		} else {
 			for _, event := range events {
 				modelEvents = append(modelEvents, models.Event{
@@ -72,7 +73,7 @@ func (pc *PodCollector) GetPods(ctx context.Context, namespace string) (*models.
 				})
 			}
 		}
-		modelPod := convertPodToModel(&pod, podMetricsMap, modelEvents)
+		modelPod := ConvertPodToModel(&pod, podMetricsMap, modelEvents)
 		podList.Items = append(podList.Items, modelPod)
 		podList.TotalCount++
 		switch pod.Status.Phase {
@@ -100,7 +101,7 @@ func (pc *PodCollector) GetPod(ctx context.Context, namespace, name string) (*mo
 	podMetric, err := pc.client.GetRawPodMetrics(ctx, namespace, name)
 	podMetricsMap := make(map[string]map[string]corev1.ResourceList)
 	if err != nil {
-		fmt.Printf("警告: 获取Pod指标失败: %v\n", err)
+		fmt.Fprintf(os.Stderr, "警告: 获取Pod指标失败: %v\n", err)
 	} else if podMetric != nil {
 		key := fmt.Sprintf("%s/%s", podMetric.Namespace, podMetric.Name)
 		podMetricsMap[key] = make(map[string]corev1.ResourceList)
@@ -112,7 +113,7 @@ func (pc *PodCollector) GetPod(ctx context.Context, namespace, name string) (*mo
 	modelEvents := []models.Event{}
 	events, err := pc.client.GetRawPodEvents(ctx, namespace, name)
 	if err != nil {
-		fmt.Printf("警告: 获取Pod事件失败: %v\n", err)
+		fmt.Fprintf(os.Stderr, "警告: 获取Pod事件失败: %v\n", err)
 	} else {
 		for _, event := range events {
 			modelEvents = append(modelEvents, models.Event{
@@ -124,7 +125,7 @@ func (pc *PodCollector) GetPod(ctx context.Context, namespace, name string) (*mo
 			})
 		}
 	}
-	modelPod := convertPodToModel(pod, podMetricsMap, modelEvents)
+	modelPod := ConvertPodToModel(pod, podMetricsMap, modelEvents)
 	return &modelPod, nil
 }
 
@@ -133,8 +134,8 @@ func (pc *PodCollector) GetPodLogs(ctx context.Context, namespace, name string,
 	return pc.client.GetRawPodLogs(ctx, namespace, name, containerName, lines)
 }
 
-// convertPodToModel 将Kubernetes Pod转换为内部Pod模型
-func convertPodToModel(pod *corev1.Pod, metricsMap map[string]map[string]corev1.ResourceList, events []models.Event) models.Pod {
+// ConvertPodToModel 将Kubernetes Pod转换为内部Pod模型，metricsMap 和 events 为空时不填充资源使用量和事件
+func ConvertPodToModel(pod *corev1.Pod, metricsMap map[string]map[string]corev1.ResourceList, events []models.Event) models.Pod {
 	// 计算总重启次数
 	totalRestarts := 0
 	for _, containerStatus := range pod.Status.ContainerStatuses {
@@ -189,6 +190,7 @@ func convertPodToModel(pod *corev1.Pod, metricsMap map[string]map[string]corev1.
 		NodeName:          pod.Spec.NodeName,
 		Labels:            pod.Labels,
 		Annotations:       pod.Annotations,
+		Object:            toObject(pod),
 		TotalRestarts:     totalRestarts,
 		RunningDuration:   runningDuration,
 		Events:            events,
@@ -0,0 +1,59 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ResourceCollector 任意资源类型的数据收集器，通过 dynamic client 获取资源
+type ResourceCollector struct {
+	client *cluster.Client
+}
+
+// NewResourceCollector 创建任意资源类型的收集器
+func NewResourceCollector(client *cluster.Client) *ResourceCollector {
+	return &ResourceCollector{client: client}
+}
+
+// GetResources 获取指定资源类型的全部资源，namespace 为空时获取所有命名空间
+func (rc *ResourceCollector) GetResources(ctx context.Context, gvk schema.GroupVersionKind, namespace string) ([]models.Resource, error) {
+	gvr, apiResource, err := rc.client.ResolveResource(gvk)
+	if err != nil {
+		return nil, err
+	}
+	gvk.Kind = apiResource.Kind
+	items, err := rc.client.ListRawResources(ctx, gvr, apiResource.Namespaced, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("获取 %s 列表失败: %w", gvr.Resource, err)
+	}
+	result := make([]models.Resource, 0, len(items))
+	for i := range items {
+		result = append(result, ConvertResourceToModel(&items[i], gvk))
+	}
+	return result, nil
+}
+
+// ConvertResourceToModel 将原生对象转换为资源模型，对象未携带 apiVersion/kind 时使用请求的资源类型
+func ConvertResourceToModel(u *unstructured.Unstructured, gvk schema.GroupVersionKind) models.Resource {
+	apiVersion, kind := u.GetAPIVersion(), u.GetKind()
+	if apiVersion == "" {
+		apiVersion = gvk.GroupVersion().String()
+	}
+	if kind == "" {
+		kind = gvk.Kind
+	}
+	return models.Resource{
+		APIVersion:  apiVersion,
+		Kind:        kind,
+		Name:        u.GetName(),
+		Namespace:   u.GetNamespace(),
+		Labels:      u.GetLabels(),
+		Annotations: u.GetAnnotations(),
+		Object:      u.UnstructuredContent(),
+	}
+}
@@ -3,6 +3,7 @@ package collector
 import (
 	"context"
 	"fmt"
+	"os"
 
 	v1 "k8s.io/api/core/v1"
 
@@ -67,7 +68,7 @@ func (c *ServiceCollector) GetServices(ctx context.Context, namespace string) ([
 		serviceInfo, err := c.buildServiceInfo(ctx, &service)
 		if err != nil {
 			// 记录错误但继续处理其他 Service
-			fmt.Printf("处理 Service %s/%s 失败: %v\n", service.Namespace, service.Name, err)
+			fmt.Fprintf(os.Stderr, "处理 Service %s/%s 失败: %v\n", service.Namespace, service.Name, err)
 			continue
 		}
 		serviceInfos = append(serviceInfos, serviceInfo)
@@ -78,8 +79,7 @@ func (c *ServiceCollector) GetServices(ctx context.Context, namespace string) ([
 
 // buildServiceInfo 构建完整的 Service 模型
 func (c *ServiceCollector) buildServiceInfo(ctx context.Context, service *v1.Service) (models.Service, error) {
-	// 使用 models 包中的转换函数
-	serviceInfo := models.FromK8sService(service)
+	serviceInfo := ConvertServiceToModel(service)
 
 	// 获取 Endpoints 信息
 	endpoints, err := c.getEndpointsForService(ctx, service)
@@ -109,6 +109,14 @@ func (c *ServiceCollector) buildServiceInfo(ctx context.Context, service *v1.Ser
 	return serviceInfo, nil
 }
 
+// ConvertServiceToModel 将Kubernetes Service转换为内部Service模型，不包含需要查询集群的端点和匹配Pod信息
+func ConvertServiceToModel(service *v1.Service) models.Service {
+	// 使用 models 包中的转换函数
+	serviceInfo := models.FromK8sService(service)
+	serviceInfo.Object = toObject(service)
+	return serviceInfo
+}
+
 // getEndpointsForService 获取 Service 对应的 Endpoints
 func (c *ServiceCollector) getEndpointsForService(ctx context.Context, service *v1.Service) ([]models.Endpoint, error) {
 	endpoints, err := c.client.GetEndpoints(ctx, service.Namespace, service.Name)
@@ -174,4 +182,3 @@ func (c *ServiceCollector) getMatchingPods(ctx context.Context, service *v1.Serv
 
 	return podInfos, nil
 }
-w
\ No newline at end of file
@@ -0,0 +1,212 @@
+package i18n
+
+// english 英文消息目录，键为中文原文
+var english = map[string]string{
+	"管理Kubernetes集群连接": "Manage Kubernetes cluster connections",
+	"管理Kubernetes集群连接，包括添加、列出、切换和删除集群配置。": "Manage Kubernetes cluster connections, including adding, listing, switching and removing cluster configurations.",
+	"列出所有可用的集群":                                   "List all available clusters",
+	"列出kubeconfig中所有可用的集群上下文。":                    "List all available cluster contexts in the kubeconfig.",
+	"切换到指定的集群上下文":                                 "Switch to the specified cluster context",
+	"切换当前活动的Kubernetes集群上下文。":                     "Switch the currently active Kubernetes cluster context.",
+	"添加新的集群配置":                                    "Add a new cluster configuration",
+	"添加新的Kubernetes集群配置到安全存储。":                    "Add a new Kubernetes cluster configuration to secure storage.",
+	"显示当前集群信息":                                    "Show current cluster information",
+	"显示当前连接的Kubernetes集群的详细信息。":                   "Show details of the currently connected Kubernetes cluster.",
+	"要添加的kubeconfig文件路径":                          "path of the kubeconfig file to add",
+	"集群的名称":                                       "name of the cluster",
+	"警告: 读取 %s 命名空间标签失败，无法按标签确定环境: %v\n":          "Warning: failed to read the labels of namespace %s, the environment cannot be determined from labels: %v\n",
+	"警告: 保存条件状态失败: %v\n":                          "Warning: failed to save condition state: %v\n",
+	"检查Deployment资源并生成报告":                         "Inspect Deployments and generate a report",
+	"检查Kubernetes集群中的Deployment资源配置与合规性，并生成详细报告。": "Inspect the configuration and compliance of Deployments in the Kubernetes cluster and generate a detailed report.",
+	"检查Deployment失败: %v\n":                        "Failed to inspect Deployments: %v\n",
+	"node [节点名称]":                                 "node [node-name]",
+	"检查节点资源并生成报告":                                 "Inspect nodes and generate a report",
+	"检查Kubernetes集群中的节点资源状态并生成详细报告，包括CPU、内存使用情况和潜在问题。": "Inspect the status of nodes in the Kubernetes cluster and generate a detailed report, including CPU and memory usage and potential problems.",
+	"检查节点失败: %v\n":          "Failed to inspect nodes: %v\n",
+	"pod [pod名称] [-n 命名空间]": "pod [pod-name] [-n namespace]",
+	"检查Pod资源并生成报告":          "Inspect Pods and generate a report",
+	"检查Kubernetes集群中的Pod资源状态并生成详细报告，包括状态、资源使用情况和潜在问题。": "Inspect the status of Pods in the Kubernetes cluster and generate a detailed report, including phase, resource usage and potential problems.",
+	"检查Pod失败: %v\n":          "Failed to inspect Pods: %v\n",
+	"要检查的命名空间":               "namespace to inspect",
+	"获取Pod日志":                "fetch Pod logs",
+	"获取的日志行数":                "number of log lines to fetch",
+	"对问题Pod实时获取最新日志":         "fetch the latest logs of problem Pods",
+	"警告: 获取容器 %s 日志失败: %v\n": "Warning: failed to fetch logs of container %s: %v\n",
+	"容器 %s 日志:\n":            "Logs of container %s:\n",
+	"\n=== 问题Pod的实时日志 ===":   "\n=== Live logs of problem Pods ===",
+	"\nPod %s/%s 有问题: %s\n":  "\nPod %s/%s has a problem: %s\n",
+	"容器 %s 最新日志:\n":          "Latest logs of container %s:\n",
+	"检查任意资源类型（包括CRD）并生成报告":   "Inspect any resource kind (including CRDs) and generate a report",
+	"通过 dynamic client 获取任意资源类型（包括CRD，如 cert-manager.io/v1/Certificate）并评估规则，\n规则的 category 为资源 Kind 的小写形式（如 certificate），只能使用通用指标 name、namespace、has_labels、has_annotations 和 jsonpath。\n核心组资源可以写成 version/kind，如 v1/ConfigMap。": "Fetch any resource kind (including CRDs such as cert-manager.io/v1/Certificate) through the dynamic client and evaluate rules.\nThe rule category is the lowercase Kind (such as certificate), and only the generic metrics name, namespace, has_labels, has_annotations and jsonpath can be used.\nCore group resources can be written as version/kind, such as v1/ConfigMap.",
+	"检查资源失败: %v\n": "Failed to inspect resources: %v\n",
+	"只检查指定命名空间的资源，默认检查所有命名空间":                  "only inspect resources in this namespace; all namespaces by default",
+	"检查Service资源并生成报告":                         "Inspect Services and generate a report",
+	"检查Kubernetes集群中的Service资源配置与合规性，并生成详细报告。": "Inspect the configuration and compliance of Services in the Kubernetes cluster and generate a detailed report.",
+	"检查Service失败: %v\n":                        "Failed to inspect Services: %v\n",
+	"获取命名空间 %s 的Service失败: %v\n":               "Failed to get Services in namespace %s: %v\n",
+	"报告已写入文件: %s\n":                            "Report written to file: %s\n",
+	"检查Kubernetes资源":                           "Inspect Kubernetes resources",
+	"检查Kubernetes集群中的资源状态并生成详细报告，可以检测资源配置问题和潜在风险。": "Inspect the status of resources in the Kubernetes cluster and generate a detailed report that detects configuration problems and potential risks.",
+	"kubeconfig文件路径":                   "path to the kubeconfig file",
+	"要使用的kubeconfig上下文":                "kubeconfig context to use",
+	"报告输出格式 (text, json, yaml, sarif)": "report output format (text, json, yaml, sarif)",
+	"禁用颜色输出":                           "disable colored output",
+	"自定义规则配置文件或目录路径，可重复指定，后面的规则按ID覆盖前面的规则":            "custom rules file or directory; repeatable, later rules override earlier ones by ID",
+	"将报告写入文件而不是标准输出":                                  "write the report to a file instead of standard output",
+	"只显示有问题的资源":                                       "only show resources with issues",
+	"持续条件状态文件路径 (默认为$HOME/.k8s-inspector/state.json)": "path of the condition state file (default $HOME/.k8s-inspector/state.json)",
+	"豁免文件路径，被有效豁免的发现项单独列出":                            "path of the waiver file; findings covered by an active waiver are listed separately",
+	"在报告中列出构成健康评分的每一项扣分":                              "list every deduction that makes up the health score in the report",
+	"与默认规则包一起加载的内置规则包，如 security，可重复指定":               "built-in rule pack to load together with the default pack, such as security; repeatable",
+	"只使用包含任一指定标签的规则，可重复指定或用逗号分隔":                      "only use rules carrying any of these tags; repeatable or comma separated",
+	"指定巡检使用的环境（如 prod、staging），覆盖规则配置中按集群确定的环境":       "environment to inspect with, such as prod or staging; overrides the environment determined from the rules configuration",
+	"从集群快照离线分析，不连接集群；快照可以是 inspector snapshot 生成的归档、kubectl get -o json/yaml 导出的文件或包含这些文件的目录": "analyze offline from a cluster snapshot without connecting to the cluster; the snapshot can be an archive created by inspector snapshot, files exported with kubectl get -o json/yaml, or a directory containing them",
+	"只使用对应任一指定控制项的规则，格式为 framework 或 framework:id，如 cis-kubernetes:5.2.2":                     "only use rules mapped to any of these controls, as framework or framework:id, such as cis-kubernetes:5.2.2",
+	"K8s-Resource-Inspector是一个Kubernetes资源配置审计和合规检查工具":                                        "K8s-Resource-Inspector is a Kubernetes resource configuration audit and compliance tool",
+	"K8s-Resource-Inspector是一个专注于Kubernetes资源配置审计、合规检查和最佳实践验证的多集群资源巡检工具。\n它能够帮助DevOps团队和平台工程师快速识别集群中的配置问题、安全风险和潜在的性能瓶颈，\n确保集群资源符合企业标准和最佳实践。": "K8s-Resource-Inspector is a multi-cluster inspection tool focused on Kubernetes resource configuration auditing, compliance checks and best-practice validation.\nIt helps DevOps teams and platform engineers quickly identify configuration problems, security risks and potential performance bottlenecks,\nand keeps cluster resources in line with company standards and best practices.",
+	"kubeconfig文件路径 (默认为$HOME/.kube/config)": "path to the kubeconfig file (default $HOME/.kube/config)",
+	"要使用的kubeconfig上下文名称":                    "name of the kubeconfig context to use",
+	"启用详细输出":                                 "enable verbose output",
+	"输出语言 (zh, en)，默认按 LANG 环境变量选择":          "output language (zh, en); chosen from the LANG environment variable by default",
+	"从文件创建或更新资源":                             "Create or update resources from a file",
+	"从YAML文件创建或更新Kubernetes资源。支持单个资源文件或包含多个资源的文件。": "Create or update Kubernetes resources from a YAML file. Files may contain a single resource or multiple resources.",
+	"包含资源定义的YAML文件路径": "path of the YAML file containing resource definitions",
+	"获取Kubernetes资源":  "Get Kubernetes resources",
+	"获取并显示Kubernetes集群中的资源信息。支持的资源类型: pods, services, deployments": "Get and display resources in the Kubernetes cluster. Supported resource types: pods, services, deployments",
+	"查看Kubernetes命名空间":           "View Kubernetes namespaces",
+	"查看并显示Kubernetes集群中的命名空间信息。": "View and display namespaces in the Kubernetes cluster.",
+	"管理Kubernetes资源":             "Manage Kubernetes resources",
+	"获取和显示Kubernetes集群中的资源信息。":   "Get and display resources in the Kubernetes cluster.",
+	"要查询的命名空间":                   "namespace to query",
+	"是否查询所有命名空间":                 "query all namespaces",
+	"管理巡检规则":                     "Manage inspection rules",
+	"管理巡检规则，包括导出内置的默认规则包、检查和测试规则文件以及查看可用指标。": "Manage inspection rules: export the built-in rule packs, lint and test rules files, and list available metrics.",
+	"export [规则包...]": "export [pack...]",
+	"导出内置的默认规则包":      "Export the built-in rule packs",
+	"将编译进二进制的默认规则包（node、pod、deployment、service）和专题规则包（security）写入指定目录，便于在此基础上定制。\n未指定规则包时导出全部规则包，导出的文件可以通过 inspect 命令的 --rules-file 参数使用。": "Write the default rule packs compiled into the binary (node, pod, deployment, service) and the topical packs (security) to a directory as a starting point for customization.\nAll packs are exported when none is given; the exported files can be used with the --rules-file flag of the inspect commands.",
+	"lint [规则文件或目录...]": "lint [rules-file-or-dir...]",
+	"按指标目录检查规则文件":       "Lint rules files against the metric catalog",
+	"检查规则文件中的指标名称、操作符和阈值类型是否与分析器产生的指标一致，\n以及环境阈值的键是否对应 clusterEnvironments 中的环境。\n多个文件或目录按 --rules-file 的方式合并后检查；未指定时检查内置的默认规则包。\n存在错误时以非零状态码退出。": "Check that metric names, operators and threshold types in rules files match the metrics produced by the analyzers,\nand that environment threshold keys match environments in clusterEnvironments.\nMultiple files or directories are merged like --rules-file before linting; the built-in rule packs are linted when none is given.\nExits with a non-zero status when errors are found.",
+	"test <测试套件文件或目录...>": "test <suite-file-or-dir...>",
+	"使用测试夹具验证规则结果":        "Verify rule results with test fixtures",
+	"执行规则测试套件：将测试夹具中的Kubernetes对象（YAML/JSON清单，NodeMetrics 和 PodMetrics 作为指标数据）\n交给真实的采集器、分析器和规则引擎，并与套件中期望的规则结果（pass、fail、pending、skip）比较。\n存在未通过的用例时以非零状态码退出。": "Run rule test suites: Kubernetes objects from test fixtures (YAML/JSON manifests, with NodeMetrics and PodMetrics as metric data)\nare passed through the real collectors, analyzers and rules engine, and compared with the expected rule results (pass, fail, pending, skip) in the suite.\nExits with a non-zero status when any case fails.",
+	"metrics [资源类型...]": "metrics [kind...]",
+	"列出规则可用的指标":         "List metrics available to rules",
+	"列出各分析器产生的指标及其值类型和可用的操作符，未指定资源类型时列出全部。": "List the metrics produced by each analyzer with their value types and operators; all kinds are listed when none is given.",
+	"规则包导出目录":  "directory to export rule packs to",
+	"覆盖已存在的文件": "overwrite existing files",
+	"%s (条件自 %s 起成立，尚未达到规则要求的持续时间)": "%s (condition true since %s, required duration not reached yet)",
+	"Pod处于%s状态%s":             "Pod has been %s for %s",
+	"容器 %s CPU使用率为 %.2f%%":    "container %s CPU utilization is %.2f%%",
+	"容器 %s 内存使用率为 %.2f%%":     "container %s memory utilization is %.2f%%",
+	"容器 %s 缺少资源限制":            "container %s has no resource limits",
+	"Pod总重启次数为 %d":            "Pod restarted %d times in total",
+	"容器 %s 崩溃: %s":            "container %s crashed: %s",
+	"%s (退出码 %d)":             "%s (exit code %d)",
+	"Pod缺少健康检查探针":             "Pod has no health check probes",
+	"节点: %s\n":                "Node: %s\n",
+	"角色: %s\n":                "Roles: %s\n",
+	"角色: 未知\n":                "Roles: unknown\n",
+	"状态: %s\n":                "Status: %s\n",
+	"可调度: %v\n":               "Schedulable: %v\n",
+	"地址:\n":                   "Addresses:\n",
+	"创建时间: %s\n":              "Created: %s\n",
+	"节点信息:\n":                 "Node info:\n",
+	"  内核版本: %s\n":            "  Kernel version: %s\n",
+	"未知":                      "unknown",
+	"  操作系统: %s\n":            "  OS image: %s\n",
+	"  容器运行时: %s\n":           "  Container runtime: %s\n",
+	"  Kubelet版本: %s\n":       "  Kubelet version: %s\n",
+	"  架构: %s\n":              "  Architecture: %s\n",
+	"资源使用情况:\n":               "Resource usage:\n",
+	"    总量: %s\n":            "    Capacity: %s\n",
+	"    可分配: %s\n":           "    Allocatable: %s\n",
+	"    已分配: %s\n":           "    Allocated: %s\n",
+	"    已使用: %s\n":           "    Used: %s\n",
+	"    利用率: %.2f%%\n":       "    Utilization: %.2f%%\n",
+	"    分配率: %.2f%%\n":       "    Allocation rate: %.2f%%\n",
+	"  内存:\n":                 "  Memory:\n",
+	"  临时存储:\n":               "  Ephemeral storage:\n",
+	"Pod数量: %d/%d (%.2f%%)\n": "Pods: %d/%d (%.2f%%)\n",
+	"压力状态:\n":                 "Pressure:\n",
+	"  内存压力: %v\n":            "  Memory pressure: %v\n",
+	"  CPU压力: %v\n":           "  CPU pressure: %v\n",
+	"  磁盘压力: %v\n":            "  Disk pressure: %v\n",
+	"  网络压力: %v\n":            "  Network pressure: %v\n",
+	"  PID压力: %v\n":           "  PID pressure: %v\n",
+	"健康评分: %d/100\n":          "Health score: %d/100\n",
+	"副本数: %d/%d 可用\n":         "Replicas: %d/%d available\n",
+	"更新策略: %s\n":              "Strategy: %s\n",
+	"容器: %s (%s, 拉取策略: %s)\n": "Container: %s (%s, pull policy: %s)\n",
+	"检查结果: %d 通过, %d 未通过\n":   "Checks: %d passed, %d failed\n",
+	"类型: %s\n":                "Type: %s\n",
+	"端口: %d/%s -> %s\n":       "Port: %d/%s -> %s\n",
+	"就绪端点: %d, 匹配Pod: %d\n":   "Ready endpoints: %d, matching Pods: %d\n",
+	"API版本: %s\n":             "API version: %s\n",
+	"%s (健康评分: %d/%d)\n":      "%s (health score: %d/%d)\n",
+	"  无扣分\n":                 "  no deductions\n",
+	"严重程度 %s":                 "severity %s",
+	"规则权重, 严重程度 %s":           "rule weight, severity %s",
+	", 类别 %s":                 ", category %s",
+	", 权重 %d 受类别上限限制":         ", weight %d limited by the category cap",
+	"  分数低于下限 %d，按下限计\n":      "  score below floor %d, raised to the floor\n",
+	"%d 通过, %d 未通过":           "%d passed, %d failed",
+	", %d 豁免":                 ", %d waived",
+	"      检查: %s; 规则: %s\n":  "      checks: %s; rules: %s\n",
+	"规则 %s 的豁免已于 %s 过期 (负责人: %s, 原因: %s)": "waiver for rule %s expired on %s (owner: %s, reason: %s)",
+	"修复被豁免的问题，或由负责人确认后延长豁免到期日期":           "Fix the waived issue, or have the owner confirm and extend the waiver expiry date",
+	"豁免注解 %s 无效: %v": "waiver annotation %s is invalid: %v",
+	"注解值应包含 reason、owner 和 expires，如 {\"reason\": \"...\", \"owner\": \"...\", \"expires\": \"2026-12-31\"}": "The annotation value must contain reason, owner and expires, such as {\"reason\": \"...\", \"owner\": \"...\", \"expires\": \"2026-12-31\"}",
+	"不成立":                "false",
+	"成立":                 "true",
+	"%s: 检查通过 (%s)":      "%s: check passed (%s)",
+	"%s: 检查失败, 触发子条件 %s": "%s: check failed, triggered by %s",
+	"%s: 检查通过 (值: %s)":   "%s: check passed (value: %s)",
+	"应大于 %s":             "should be greater than %s",
+	"应大于等于 %s":           "should be at least %s",
+	"应小于 %s":             "should be less than %s",
+	"应小于等于 %s":           "should be at most %s",
+	"应等于 %s":             "should equal %s",
+	"不应等于 %s":            "should not equal %s",
+	"应包含 %s":             "should contain %s",
+	"应包含标签 '%s' 且值不为空":   "should have a non-empty label '%s'",
+	"应包含标签 %v 且值不为空":     "should have non-empty labels %v",
+	"应包含指定标签且值不为空":       "should have the required labels with non-empty values",
+	"应匹配正则表达式 %s":        "should match regular expression %s",
+	"应为 %s 之一":           "should be one of %s",
+	"不应为 %s 之一":          "should not be one of %s",
+	"应在区间 %s 内":          "should be within %s",
+	"应以 %s 开头":           "should start with %s",
+	"应以 %s 结尾":           "should end with %s",
+	"版本应低于 %s":           "version should be lower than %s",
+	"版本应不低于 %s":          "version should be at least %s",
+	"不满足条件 %s %s":        "does not satisfy %s %s",
+	"%s: 检查失败, 值 %s %s":  "%s: check failed, value %s %s",
+	"<无值>":               "<none>",
+	"采集集群状态快照，用于离线分析":    "Capture a snapshot of cluster state for offline analysis",
+	"采集集群中的命名空间、节点、Pod、Deployment、Service、Endpoints、事件以及节点和Pod指标，\n写入 gzip 压缩的 tar 归档，归档中的 manifest.json 记录集群名称、集群版本和采集时间。\n生成的快照可以通过 inspect 命令的 --from-snapshot 参数离线分析。\n默认删除 last-applied-configuration 注解，并替换敏感注解和容器环境变量的明文值，不采集 Secret。": "Capture namespaces, nodes, pods, deployments, services, endpoints, events and node and pod metrics from the cluster\ninto a gzip-compressed tar archive whose manifest.json records the cluster name, server version and capture time.\nThe snapshot can be analyzed offline with the --from-snapshot flag of the inspect commands.\nBy default the last-applied-configuration annotation is removed and the values of sensitive annotations and container environment variables are replaced; Secrets are never captured.",
+	"采集集群快照失败: %v\n":                    "Failed to capture cluster snapshot: %v\n",
+	"快照归档路径 (默认为 <集群名称>-<采集时间>.tar.gz)": "snapshot archive path (default <cluster name>-<capture time>.tar.gz)",
+	"只采集指定命名空间的资源，默认采集所有命名空间":           "only capture resources in the given namespace; captures all namespaces by default",
+	"写入快照的集群名称 (默认为kubeconfig上下文名称)":    "cluster name recorded in the snapshot (defaults to the kubeconfig context name)",
+	"保留注解和环境变量的原始值，不做脱敏":                "keep the original values of annotations and environment variables instead of redacting them",
+	"警告: %s\n": "Warning: %s\n",
+	"集群 %s 的快照已写入 %s，共 %d 个对象\n": "Snapshot of cluster %s written to %s with %d objects\n",
+	"lint -f <文件|目录|->":          "lint -f <file|dir|->",
+	"检查资源清单，无需连接集群":              "Check resource manifests without connecting to a cluster",
+	"解析多文档YAML或JSON资源清单（Deployment、Service、Pod、StatefulSet、CRD等），通过与采集器相同的模型转换评估规则，\n只使用不依赖集群运行时数据的规则（资源使用率、重启次数、端点等指标以及配置了 duration 的规则会被跳过）。\nDeployment、Pod、Service 使用对应的分析器，其他资源类型按 inspect resource 的方式使用通用指标检查。\n存在未豁免的发现项时以状态码 2 退出，可通过 --fail-on 和 --max-findings 调整阈值，可用于在合并请求前检查清单。": "Parse multi-document YAML or JSON manifests (Deployment, Service, Pod, StatefulSet, CRDs...) and evaluate rules through the same model conversion as the collectors.\nOnly rules that do not need cluster runtime data are used (rules on utilization, restarts, endpoints and rules with a duration are skipped).\nDeployments, Pods and Services use their analyzers; other kinds are checked with the generic metrics, like inspect resource.\nExits with status 2 when unwaived findings exist (adjust the threshold with --fail-on and --max-findings), so it can gate pull requests.",
+	"检查资源清单失败: %v\n": "Failed to check manifests: %v\n",
+	"要检查的清单文件或目录，- 表示从标准输入读取，可重复指定":                                       "manifest file or directory to check, - reads from standard input; repeatable",
+	"清单中未指定命名空间的资源使用的命名空间":                                                "namespace for manifest resources that do not set one",
+	"存在该级别及以上的未豁免发现项时以状态码 2 退出 (critical, error, warning, info)":          "exit with status 2 when unwaived findings at or above this severity exist (critical, error, warning, info)",
+	"允许的未豁免发现项数量（只统计 --fail-on 级别及以上），超过时以状态码 2 退出，-1 表示不限制":              "number of unwaived findings allowed (counting only --fail-on severity and above); exit with status 2 when exceeded, -1 means no limit",
+	"存在该级别及以上的未豁免发现项时以状态码 2 退出 (critical, error, warning, info)，默认为 info": "exit with status 2 when unwaived findings at or above this severity exist (critical, error, warning, info); defaults to info",
+	"允许的未豁免发现项数量（只统计 --fail-on 级别及以上），超过时以状态码 2 退出，默认不允许任何发现项":            "number of unwaived findings allowed (counting only --fail-on severity and above); exit with status 2 when exceeded, no findings are allowed by default",
+	"发现 %d 个 %s 及以上级别的问题":                                                 "Found %d issues at severity %s or above",
+	"发现 %d 个 %s 及以上级别的问题，超过 --max-findings 允许的 %d 个":                      "Found %d issues at severity %s or above, more than the %d allowed by --max-findings",
+	"按指定间隔（如 5m）重复巡检直到收到中断信号，规则配置启用 autoReload 时在后台重载规则；0 表示只巡检一次":        "repeat the inspection at this interval (such as 5m) until interrupted, reloading rules in the background when autoReload is enabled in the rules config; 0 inspects once",
+	"警告: 重载规则文件 %s 失败，继续使用原有规则: %v\n":                                     "Warning: failed to reload rules file %s, keeping the previous rules: %v\n",
+	"规则文件 %s 已重新加载，共 %d 条规则\n":                                            "Rules file %s reloaded, %d rules\n",
+	"规则评估失败 (%s, Deployment %s/%s): %v\n":                                 "Failed to evaluate rule (%s, Deployment %s/%s): %v\n",
+	"规则评估失败 (%s, Service %s/%s): %v\n":                                    "Failed to evaluate rule (%s, Service %s/%s): %v\n",
+}
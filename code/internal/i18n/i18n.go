@@ -0,0 +1,111 @@
+// Package i18n 提供命令行帮助、规则评估消息和报告输出的多语言支持；
+// 消息以中文原文作为键，当前语言为英文时从消息目录中查找译文，缺少译文时使用原文
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Language 输出语言
+type Language string
+
+const (
+	// Chinese 中文，默认语言
+	Chinese Language = "zh"
+	// English 英文
+	English Language = "en"
+)
+
+// Languages 支持的语言
+var Languages = []Language{Chinese, English}
+
+// catalogs 各语言的消息目录，键为中文原文
+var catalogs = map[Language]map[string]string{
+	English: english,
+}
+
+var (
+	mu      sync.RWMutex
+	current = Chinese
+)
+
+// SetLanguage 设置当前语言
+func SetLanguage(lang Language) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = lang
+}
+
+// Current 返回当前语言
+func Current() Language {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// ParseLanguage 解析语言名称，支持 en、zh 以及 en_US.UTF-8、zh-CN 等区域设置写法
+func ParseLanguage(name string) (Language, error) {
+	value := strings.ToLower(strings.TrimSpace(name))
+	if i := strings.IndexAny(value, "_-.@"); i >= 0 {
+		value = value[:i]
+	}
+	for _, lang := range Languages {
+		if value == string(lang) {
+			return lang, nil
+		}
+	}
+	names := make([]string, 0, len(Languages))
+	for _, lang := range Languages {
+		names = append(names, string(lang))
+	}
+	return "", fmt.Errorf("不支持的语言 %q (可用: %s)", name, strings.Join(names, ", "))
+}
+
+// Detect 确定输出语言：优先使用 --lang 指定的语言，未指定时依次读取 LC_ALL、LC_MESSAGES 和 LANG 环境变量，
+// 环境变量为不支持的语言（如 C、POSIX）时使用中文
+func Detect(flag string) (Language, error) {
+	if flag != "" {
+		return ParseLanguage(flag)
+	}
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		if lang, err := ParseLanguage(value); err == nil {
+			return lang, nil
+		}
+		break
+	}
+	return Chinese, nil
+}
+
+// T 返回消息在当前语言中的译文
+func T(message string) string {
+	return Translate(Current(), message)
+}
+
+// Sprintf 按当前语言翻译格式字符串后格式化
+func Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Translate 返回消息在指定语言中的译文，没有译文时返回原文
+func Translate(lang Language, message string) string {
+	if translated, ok := catalogs[lang][message]; ok {
+		return translated
+	}
+	return message
+}
+
+// Has 判断指定语言的消息目录中是否有该消息的译文，中文始终返回true
+func Has(lang Language, message string) bool {
+	if lang == Chinese {
+		return true
+	}
+	_, ok := catalogs[lang][message]
+	return ok
+}
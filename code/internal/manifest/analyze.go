@@ -0,0 +1,128 @@
+package manifest
+
+import (
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/generic"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/pod"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/service"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// runtimeKinds 由集群在运行时生成的资源类型，清单中的这些对象（如导出的集群状态）不参与检查
+var runtimeKinds = map[string]bool{
+	"Endpoints":     true,
+	"EndpointSlice": true,
+	"Event":         true,
+	"NodeMetrics":   true,
+	"PodMetrics":    true,
+}
+
+// Analyze 分析清单中的对象并生成报告：Deployment、Pod、Service 使用对应的专用分析器，
+// 没有专用分析器的资源类型（如 StatefulSet、CRD）使用通用分析器，节点、Endpoints 等只有运行时数据才有意义的资源类型跳过。
+// 规则引擎应通过 SetStaticOnly 只使用不依赖运行时数据的规则；发现项带有资源在清单中的位置。
+// 对象无法分析时返回带有资源位置的错误，避免清单中的资源被静默跳过
+func Analyze(engine *rules.Engine, objects []Object, clusterName, namespace string) (*report.Report, error) {
+	var (
+		deployments []*deployment.AnalysisResult
+		pods        []*pod.AnalysisResult
+		services    []*service.AnalysisResult
+		resources   = make(map[string][]*generic.AnalysisResult)
+		kinds       []string
+	)
+	deploymentAnalyzer := deployment.NewDeploymentAnalyzer(engine, nil)
+	podAnalyzer := pod.NewPodAnalyzer(engine)
+	serviceAnalyzer := service.NewServiceAnalyzer(engine, nil)
+	resourceAnalyzer := generic.NewResourceAnalyzer(engine, nil)
+	sources := make(map[string]report.SourceLocation, len(objects))
+
+	for _, obj := range objects {
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		if runtimeKinds[gvk.Kind] {
+			continue
+		}
+		switch o := obj.Object.(type) {
+		case *appsv1.Deployment:
+			deployments = append(deployments, deploymentAnalyzer.AnalyzeDeployment(collector.ConvertDeploymentToModel(o)))
+		case *corev1.Pod:
+			model := collector.ConvertPodToModel(o, nil, nil)
+			result, err := podAnalyzer.AnalyzePod(&model)
+			if err != nil {
+				return nil, fmt.Errorf("分析Pod %s/%s 失败 (%s:%d): %w", o.Namespace, o.Name, obj.File, obj.Line, err)
+			}
+			pods = append(pods, result)
+		case *corev1.Service:
+			model := collector.ConvertServiceToModel(o)
+			services = append(services, serviceAnalyzer.AnalyzeService(&model))
+		default:
+			// 有专用分析器但不能静态检查的资源类型（如节点）跳过
+			category := generic.RuleCategory(gvk.Kind)
+			if len(rules.CatalogMetrics(category)) > 0 {
+				continue
+			}
+			u, err := toUnstructured(obj.Object)
+			if err != nil {
+				return nil, fmt.Errorf("转换 %s 失败 (%s:%d): %w", gvk.Kind, obj.File, obj.Line, err)
+			}
+			model := collector.ConvertResourceToModel(u, gvk)
+			if _, exists := resources[category]; !exists {
+				kinds = append(kinds, category)
+			}
+			resources[category] = append(resources[category], resourceAnalyzer.AnalyzeResource(&model))
+		}
+
+		if accessor, err := meta.Accessor(obj.Object); err == nil {
+			key := report.SourceKey(gvk.Kind, accessor.GetNamespace(), accessor.GetName())
+			sources[key] = report.SourceLocation{File: obj.File, Line: obj.Line}
+		}
+	}
+
+	generator := report.NewGenerator(clusterName, namespace)
+	allRules := engine.GetRules(rules.RuleFilter{})
+	reports := make([]*report.Report, 0, len(kinds)+3)
+	if len(deployments) > 0 {
+		reports = append(reports, generator.GenerateDeploymentReport(deployments, allRules))
+	}
+	if len(pods) > 0 {
+		reports = append(reports, generator.GeneratePodReport(pods, allRules))
+	}
+	if len(services) > 0 {
+		reports = append(reports, generator.GenerateServiceReport(services, allRules))
+	}
+	for _, category := range kinds {
+		rulesList := engine.GetRules(rules.RuleFilter{Categories: []string{category}})
+		reports = append(reports, generator.GenerateResourceReport(resources[category], rulesList))
+	}
+
+	merged := report.Merge(reports...)
+	if len(reports) == 0 {
+		// 没有可检查的对象时保留报告头部信息
+		merged.Timestamp = time.Now()
+		merged.ClusterName = clusterName
+		merged.Namespace = namespace
+	}
+	merged.AttachSources(sources)
+	return merged, nil
+}
+
+// toUnstructured 将对象转换为非结构化对象，供通用分析器使用
+func toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u, nil
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: content}, nil
+}
@@ -0,0 +1,198 @@
+// Package manifest 读取尚未部署的资源清单，通过与采集器相同的模型转换和分析器评估不依赖集群运行时数据的规则，
+// 用于在合并请求前检查清单，无需连接集群。
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/snapshot"
+)
+
+// Stdin 表示从标准输入读取清单的路径
+const Stdin = "-"
+
+// clusterScopedKinds 集群级的内置资源类型，这些对象不补全命名空间；其余资源类型（包括CRD）视为命名空间级资源
+var clusterScopedKinds = map[string]bool{
+	"Namespace":                      true,
+	"Node":                           true,
+	"NodeMetrics":                    true,
+	"PersistentVolume":               true,
+	"StorageClass":                   true,
+	"ClusterRole":                    true,
+	"ClusterRoleBinding":             true,
+	"CustomResourceDefinition":       true,
+	"PriorityClass":                  true,
+	"RuntimeClass":                   true,
+	"IngressClass":                   true,
+	"CSIDriver":                      true,
+	"CSINode":                        true,
+	"VolumeAttachment":               true,
+	"APIService":                     true,
+	"MutatingWebhookConfiguration":   true,
+	"ValidatingWebhookConfiguration": true,
+}
+
+// Object 清单中的一个Kubernetes对象及其所在位置
+type Object struct {
+	runtime.Object
+	// 对象所在的清单文件，标准输入为 -
+	File string
+	// 对象所在YAML文档的起始行号，List 中的对象为 List 所在文档的行号
+	Line int
+}
+
+// Load 读取清单文件、目录或标准输入（路径为 -）中的对象，目录中的 .json、.yaml、.yml 文件按路径顺序读取；
+// 没有命名空间的命名空间级对象使用 namespace
+func Load(paths []string, stdin io.Reader, namespace string) ([]Object, error) {
+	objects := make([]Object, 0)
+	for _, path := range paths {
+		var decoded []Object
+		var err error
+		if path == Stdin {
+			decoded, err = decodeReader(Stdin, stdin)
+		} else {
+			decoded, err = loadPath(path)
+		}
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("清单 %s 中没有Kubernetes对象", strings.Join(paths, ", "))
+	}
+
+	for _, obj := range objects {
+		accessor, err := meta.Accessor(obj.Object)
+		if err != nil {
+			return nil, fmt.Errorf("读取对象元数据失败 (%s:%d): %w", obj.File, obj.Line, err)
+		}
+		if accessor.GetNamespace() == "" && !clusterScopedKinds[obj.GetObjectKind().GroupVersionKind().Kind] {
+			accessor.SetNamespace(namespace)
+		}
+	}
+	return objects, nil
+}
+
+// loadPath 读取清单文件或目录
+func loadPath(path string) ([]Object, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取清单失败: %w", err)
+	}
+	if !info.IsDir() {
+		return decodeFile(path)
+	}
+
+	objects := make([]Object, 0)
+	err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !isManifestFile(file) {
+			return nil
+		}
+		decoded, err := decodeFile(file)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, decoded...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// decodeFile 解析清单文件
+func decodeFile(file string) ([]Object, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("读取清单文件失败: %w", err)
+	}
+	defer f.Close()
+	return decodeReader(file, f)
+}
+
+// decodeReader 按 --- 分隔符拆分多文档YAML并解析每个文档，记录文档第一个有效行的行号；file 用于位置和错误信息
+func decodeReader(file string, r io.Reader) ([]Object, error) {
+	objects := make([]Object, 0)
+	var doc bytes.Buffer
+	start := 0
+	flush := func() error {
+		if start == 0 {
+			doc.Reset()
+			return nil
+		}
+		decoded, err := snapshot.DecodeDocument(doc.Bytes())
+		if err != nil {
+			return fmt.Errorf("解析清单文件失败 (%s:%d): %w", file, start, err)
+		}
+		for _, obj := range decoded {
+			objects = append(objects, Object{Object: obj, File: file, Line: start})
+		}
+		doc.Reset()
+		start = 0
+		return nil
+	}
+
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		text, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("读取清单文件失败 (%s): %w", file, err)
+		}
+		if isSeparator(text) {
+			if flushErr := flush(); flushErr != nil {
+				return nil, flushErr
+			}
+		} else {
+			if start == 0 && !isBlankOrComment(text) {
+				start = line
+			}
+			doc.WriteString(text)
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// isSeparator 判断是否为YAML文档分隔行，分隔符后只允许空白和注释
+func isSeparator(line string) bool {
+	if !strings.HasPrefix(line, "---") {
+		return false
+	}
+	rest := strings.TrimSpace(line[3:])
+	return rest == "" || strings.HasPrefix(rest, "#")
+}
+
+// isBlankOrComment 判断是否为空行或注释行
+func isBlankOrComment(line string) bool {
+	line = strings.TrimSpace(line)
+	return line == "" || strings.HasPrefix(line, "#")
+}
+
+// isManifestFile 判断文件是否为清单文件
+func isManifestFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
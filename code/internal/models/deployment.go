@@ -9,6 +9,8 @@ type Deployment struct {
 	AvailableReplicas int32       `json:"availableReplicas"`
 	Strategy    string            `json:"strategy"`
 	Containers  []DeploymentContainer       `json:"containers"`
+	// 原始对象，供规则中的 jsonpath 指标使用
+	Object map[string]interface{} `json:"-"`
 }
 
 type DeploymentContainer struct {
@@ -98,6 +98,10 @@ type Node struct {
 	Schedulable bool
 	// 节点标签
 	Labels map[string]string
+	// 节点注解
+	Annotations map[string]string
+	// 原始对象，供规则中的 jsonpath 指标使用
+	Object map[string]interface{}
 	// 节点污点
 	Taints []corev1.Taint
 	// 节点信息
@@ -50,6 +50,8 @@ type Pod struct {
 	Priority int32
 	// 调度到节点的时间
 	ScheduledTime *time.Time
+	// 原始对象，供规则中的 jsonpath 指标使用
+	Object map[string]interface{}
 }
 
 // Container 表示容器及其资源使用情况
@@ -0,0 +1,13 @@
+package models
+
+// Resource 表示通过 dynamic client 获取的任意资源（包括CRD）的简化模型
+type Resource struct {
+	APIVersion  string            `json:"apiVersion"`
+	Kind        string            `json:"kind"`
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	// 原始对象，供规则中的 jsonpath 指标使用
+	Object map[string]interface{} `json:"-"`
+}
@@ -1,6 +1,11 @@
 package models
 
-import v1 "k8s.io/api/core/v1"
+import (
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
 
 // Service 表示 Kubernetes Service 的简化模型
 type Service struct {
@@ -11,11 +16,16 @@ type Service struct {
 	Type        string            `json:"type"`
 	Ports       []ServicePort     `json:"ports"`
 	Selector    map[string]string `json:"selector"`
+	// LoadBalancer 允许访问的源地址段
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
 	
 	// 连通性相关信息
 	Endpoints      []Endpoint `json:"endpoints"`
 	MatchingPods   []ServicePod `json:"matchingPods"`
 	ReadyEndpoints int        `json:"readyEndpoints"`
+
+	// 原始对象，供规则中的 jsonpath 指标使用
+	Object map[string]interface{} `json:"-"`
 }
 
 // ServicePort 表示 Service 端口配置
@@ -95,9 +105,11 @@ func FromK8sService(k8sService *v1.Service) Service {
 		Annotations: k8sService.Annotations,
 		Type:        string(k8sService.Spec.Type),
 		Selector:    k8sService.Spec.Selector,
+
+		LoadBalancerSourceRanges: k8sService.Spec.LoadBalancerSourceRanges,
 	}
 
-	// 转换端口信息
+	// 转换端口信息，未设置的协议和目标端口按API服务器的默认值填充（资源清单中通常省略）
 	for _, port := range k8sService.Spec.Ports {
 		servicePort := ServicePort{
 			Name:       port.Name,
@@ -105,6 +117,12 @@ func FromK8sService(k8sService *v1.Service) Service {
 			Port:       port.Port,
 			TargetPort: port.TargetPort.String(),
 		}
+		if servicePort.Protocol == "" {
+			servicePort.Protocol = string(v1.ProtocolTCP)
+		}
+		if port.TargetPort == (intstr.IntOrString{}) {
+			servicePort.TargetPort = strconv.Itoa(int(port.Port))
+		}
 		if port.NodePort != 0 {
 			servicePort.NodePort = port.NodePort
 		}
@@ -0,0 +1,167 @@
+package report
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// ControlStatus 合规控制项的检查状态
+type ControlStatus string
+
+const (
+	// ControlPass 对应规则的检查全部通过
+	ControlPass ControlStatus = "PASS"
+	// ControlFail 至少一项检查未通过
+	ControlFail ControlStatus = "FAIL"
+	// ControlWaived 未通过的检查全部被有效豁免
+	ControlWaived ControlStatus = "WAIVED"
+	// ControlNotEvaluated 没有资源被对应规则检查
+	ControlNotEvaluated ControlStatus = "NOT_EVALUATED"
+)
+
+// ControlResult 合规框架中单个控制项的检查结果
+type ControlResult struct {
+	// 框架标识
+	Framework string `json:"framework"`
+	// 控制项编号
+	ControlID string `json:"controlID"`
+	// 控制项标题
+	Title string `json:"title,omitempty"`
+	// 对应的规则ID
+	Rules []string `json:"rules"`
+	// 通过的检查数
+	Passed int `json:"passed"`
+	// 未通过的检查数
+	Failed int `json:"failed"`
+	// 被豁免的检查数，不计入 Failed
+	Waived int `json:"waived,omitempty"`
+	// 检查状态
+	Status ControlStatus `json:"status"`
+}
+
+// checkCounts 单条规则的检查结果计数
+type checkCounts struct {
+	passed int
+	failed int
+}
+
+// ruleChecks 按规则ID统计检查结果，用于生成合规汇总
+type ruleChecks map[string]*checkCounts
+
+// record 记录一次检查结果
+func (c ruleChecks) record(ruleID string, passed bool) {
+	counts, ok := c[ruleID]
+	if !ok {
+		counts = &checkCounts{}
+		c[ruleID] = counts
+	}
+	if passed {
+		counts.passed++
+	} else {
+		counts.failed++
+	}
+}
+
+// newCompliance 按规则对应的控制项汇总检查结果；category 不为空时只汇总该类别的规则，
+// 没有对应控制项的规则和已禁用的规则不参与汇总
+func newCompliance(rulesList []rules.Rule, category string, checks ruleChecks) []ControlResult {
+	index := make(map[string]int)
+	var results []ControlResult
+	for _, rule := range rulesList {
+		if !rule.Enabled || (category != "" && rule.Category != category) {
+			continue
+		}
+		counts := checks[rule.ID]
+		for _, control := range rule.Controls {
+			key := control.String()
+			i, ok := index[key]
+			if !ok {
+				i = len(results)
+				index[key] = i
+				results = append(results, ControlResult{
+					Framework: control.Framework,
+					ControlID: control.ID,
+					Title:     control.Title,
+					Rules:     make([]string, 0, 1),
+				})
+			}
+			result := &results[i]
+			if result.Title == "" {
+				result.Title = control.Title
+			}
+			result.Rules = append(result.Rules, rule.ID)
+			if counts != nil {
+				result.Passed += counts.passed
+				result.Failed += counts.failed
+			}
+		}
+	}
+
+	for i := range results {
+		results[i].updateStatus()
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Framework != results[j].Framework {
+			return results[i].Framework < results[j].Framework
+		}
+		return lessControlID(results[i].ControlID, results[j].ControlID)
+	})
+	return results
+}
+
+// lessControlID 比较控制项编号，按点分隔的各段比较，数字段按数值比较，使 5.2.2 排在 5.2.12 之前
+func lessControlID(a, b string) bool {
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		if partsA[i] == partsB[i] {
+			continue
+		}
+		numA, errA := strconv.Atoi(partsA[i])
+		numB, errB := strconv.Atoi(partsB[i])
+		if errA == nil && errB == nil {
+			return numA < numB
+		}
+		return partsA[i] < partsB[i]
+	}
+	return len(partsA) < len(partsB)
+}
+
+// updateStatus 按检查计数更新控制项状态
+func (c *ControlResult) updateStatus() {
+	switch {
+	case c.Failed > 0:
+		c.Status = ControlFail
+	case c.Waived > 0:
+		c.Status = ControlWaived
+	case c.Passed > 0:
+		c.Status = ControlPass
+	default:
+		c.Status = ControlNotEvaluated
+	}
+}
+
+// waiveCompliance 将规则的一次未通过检查计为被豁免
+func (r *Report) waiveCompliance(ruleID string) {
+	for i := range r.Compliance {
+		control := &r.Compliance[i]
+		if control.Failed == 0 || !containsRule(control.Rules, ruleID) {
+			continue
+		}
+		control.Failed--
+		control.Waived++
+		control.updateStatus()
+	}
+}
+
+// containsRule 判断规则ID是否在列表中
+func containsRule(ruleIDs []string, ruleID string) bool {
+	for _, id := range ruleIDs {
+		if id == ruleID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+)
+
+// severityRanks 严重性级别由低到高的排序
+var severityRanks = map[Severity]int{
+	SeverityInfo:     1,
+	SeverityWarning:  2,
+	SeverityError:    3,
+	SeverityCritical: 4,
+}
+
+// ParseSeverity 解析严重性名称（不区分大小写）: critical、error、warning、info
+func ParseSeverity(name string) (Severity, error) {
+	severity := Severity(strings.ToUpper(strings.TrimSpace(name)))
+	if _, ok := severityRanks[severity]; !ok {
+		return "", fmt.Errorf("未知的严重性级别 %q (可用: critical, error, warning, info)", name)
+	}
+	return severity, nil
+}
+
+// AtLeast 判断严重性是否不低于 threshold
+func (s Severity) AtLeast(threshold Severity) bool {
+	return severityRanks[s] >= severityRanks[threshold]
+}
+
+// CountAtOrAbove 统计严重性不低于 threshold 的未豁免发现项数量
+func (r *Report) CountAtOrAbove(threshold Severity) int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity.AtLeast(threshold) {
+			count++
+		}
+	}
+	return count
+}
@@ -2,11 +2,13 @@ package report
 
 import (
 	"strconv"
-	"strings"
 	"time"
 
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/generic"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/node"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/pod"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/service"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
 )
 
@@ -53,6 +55,7 @@ func (g *DefaultGenerator) GenerateNodeReport(results []node.AnalysisResult, rul
 
 	// 处理每个分析结果
 	resourcesWithIssues := make(map[string]bool)
+	checks := make(ruleChecks)
 	
 	for _, result := range results {
 		// 从分析结果中获取节点详情
@@ -95,6 +98,7 @@ func (g *DefaultGenerator) GenerateNodeReport(results []node.AnalysisResult, rul
 		
 		// 查找未通过的分析项
 		for _, item := range result.Items {
+			checks.record(item.RuleID, item.Passed)
 			if !item.Passed {
 				resourcesWithIssues[result.NodeName] = true
 				
@@ -109,6 +113,7 @@ func (g *DefaultGenerator) GenerateNodeReport(results []node.AnalysisResult, rul
 					Severity:       severity,
 					Recommendation: item.Remediation,
 					Details:        make(map[string]interface{}),
+					PendingSince:   item.PendingSince,
 				}
 				
 				// 添加资源指标到详情
@@ -130,6 +135,8 @@ func (g *DefaultGenerator) GenerateNodeReport(results []node.AnalysisResult, rul
 	
 	// 更新摘要
 	report.Summary.ResourcesWithIssues = len(resourcesWithIssues)
+	report.Compliance = newCompliance(rulesList, "node", checks)
+	report.Rules = newRuleInfos(rulesList, "node")
 	
 	return report
 }
@@ -147,7 +154,11 @@ func (g *DefaultGenerator) GeneratePodReport(results []*pod.AnalysisResult, rule
 	}
 
 	// 添加Pod详情
+	report.PodDetails = make([]PodDetail, 0, len(results))
+	checks := make(ruleChecks)
 	for _, result := range results {
+		report.PodDetails = append(report.PodDetails, createPodDetailFromAnalysisResult(result))
+
 		podDisplayName := "pod "
 		if result.Namespace != "" {
 			podDisplayName += result.Namespace + "/" + result.PodName
@@ -156,25 +167,17 @@ func (g *DefaultGenerator) GeneratePodReport(results []*pod.AnalysisResult, rule
 		}
 		// 添加发现项
 		for _, item := range result.Items {
+			checks.record(item.RuleID, item.Passed)
 			if !item.Passed {
 				severity := mapSeverity(item.Severity)
-				// 统一Message内容
-				msg := item.Description
-				// 替换“容器 xxx 缺少资源限制”为“Pod <namespace>/<pod-name> 缺少资源限制”
-				if strings.Contains(msg, "容器") && strings.Contains(msg, "缺少资源限制") {
-					msg = podDisplayName + " 缺少资源限制"
-				} else if strings.Contains(msg, "容器") {
-					msg = strings.ReplaceAll(msg, "容器", podDisplayName)
-				} else if strings.Contains(msg, "Pod缺少健康检查探针") {
-					msg = podDisplayName + " 缺少健康检查探针"
-				}
 				finding := Finding{
 					ResourceName: podDisplayName,
 					ResourceKind: "Pod",
 					RuleID:       item.RuleID,
-					Message:      msg,
+					Message:      item.Description,
 					Severity:     severity,
 					Recommendation: item.Remediation,
+					PendingSince:   item.PendingSince,
 					Details: map[string]interface{}{
 						"metric":    item.Metric,
 						"value":     item.Value,
@@ -182,6 +185,10 @@ func (g *DefaultGenerator) GeneratePodReport(results []*pod.AnalysisResult, rule
 						"namespace": result.Namespace,
 					},
 				}
+				// 容器级检查在详情中记录容器名称
+				if item.Container != "" {
+					finding.Details["container"] = item.Container
+				}
 
 				report.Findings = append(report.Findings, finding)
 				report.Summary.FindingCounts[severity]++
@@ -192,10 +199,235 @@ func (g *DefaultGenerator) GeneratePodReport(results []*pod.AnalysisResult, rule
 	// 更新统计信息
 	report.Summary.TotalResources = len(results)
 	report.Summary.ResourcesWithIssues = countResourcesWithIssues(results)
+	report.Compliance = newCompliance(rules, "pod", checks)
+	report.Rules = newRuleInfos(rules, "pod")
 
 	return report
 }
 
+// GenerateDeploymentReport 从Deployment分析结果创建报告
+func (g *DefaultGenerator) GenerateDeploymentReport(results []*deployment.AnalysisResult, rulesList []rules.Rule) *Report {
+	report := newReport(g, len(results))
+	report.DeploymentDetails = make([]DeploymentDetail, 0, len(results))
+	checks := make(ruleChecks)
+
+	for _, result := range results {
+		detail := createDeploymentDetailFromAnalysisResult(result)
+		resourceName := result.Namespace + "/" + result.DeploymentName
+
+		for _, item := range result.Items {
+			checks.record(item.RuleID, item.Passed)
+			if item.Passed {
+				detail.ChecksPassed++
+				continue
+			}
+			detail.ChecksFailed++
+			report.addFinding(Finding{
+				ResourceName:   resourceName,
+				ResourceKind:   "Deployment",
+				RuleID:         item.RuleID,
+				Message:        item.Description,
+				Severity:       mapSeverity(item.Severity),
+				Recommendation: item.Remediation,
+				PendingSince:   item.PendingSince,
+				Details: map[string]interface{}{
+					"metric":    item.Metric,
+					"value":     item.Value,
+					"threshold": item.Threshold,
+					"namespace": result.Namespace,
+				},
+			})
+		}
+
+		if detail.ChecksFailed > 0 {
+			report.Summary.ResourcesWithIssues++
+		}
+		report.DeploymentDetails = append(report.DeploymentDetails, detail)
+	}
+	report.Compliance = newCompliance(rulesList, "deployment", checks)
+	report.Rules = newRuleInfos(rulesList, "deployment")
+
+	return report
+}
+
+// GenerateServiceReport 从Service分析结果创建报告
+func (g *DefaultGenerator) GenerateServiceReport(results []*service.AnalysisResult, rulesList []rules.Rule) *Report {
+	report := newReport(g, len(results))
+	report.ServiceDetails = make([]ServiceDetail, 0, len(results))
+	checks := make(ruleChecks)
+
+	for _, result := range results {
+		detail := createServiceDetailFromAnalysisResult(result)
+		resourceName := result.Namespace + "/" + result.ServiceName
+
+		for _, item := range result.Items {
+			checks.record(item.RuleID, item.Passed)
+			if item.Passed {
+				detail.ChecksPassed++
+				continue
+			}
+			detail.ChecksFailed++
+			report.addFinding(Finding{
+				ResourceName:   resourceName,
+				ResourceKind:   "Service",
+				RuleID:         item.RuleID,
+				Message:        item.Description,
+				Severity:       mapSeverity(item.Severity),
+				Recommendation: item.Remediation,
+				PendingSince:   item.PendingSince,
+				Details: map[string]interface{}{
+					"metric":    item.Metric,
+					"value":     item.Value,
+					"threshold": item.Threshold,
+					"namespace": result.Namespace,
+				},
+			})
+		}
+
+		if detail.ChecksFailed > 0 {
+			report.Summary.ResourcesWithIssues++
+		}
+		report.ServiceDetails = append(report.ServiceDetails, detail)
+	}
+	report.Compliance = newCompliance(rulesList, "service", checks)
+	report.Rules = newRuleInfos(rulesList, "service")
+
+	return report
+}
+
+// GenerateResourceReport 从任意资源类型的分析结果创建报告
+func (g *DefaultGenerator) GenerateResourceReport(results []*generic.AnalysisResult, rulesList []rules.Rule) *Report {
+	report := newReport(g, len(results))
+	report.ResourceDetails = make([]ResourceDetail, 0, len(results))
+	checks := make(ruleChecks)
+
+	for _, result := range results {
+		res := result.Resource
+		detail := ResourceDetail{
+			APIVersion: res.APIVersion,
+			Kind:       res.Kind,
+			Name:       result.ResourceName,
+			Namespace:  result.Namespace,
+			Labels:     res.Labels,
+		}
+		resourceName := result.ResourceName
+		if result.Namespace != "" {
+			resourceName = result.Namespace + "/" + result.ResourceName
+		}
+
+		for _, item := range result.Items {
+			checks.record(item.RuleID, item.Passed)
+			if item.Passed {
+				detail.ChecksPassed++
+				continue
+			}
+			detail.ChecksFailed++
+			details := map[string]interface{}{
+				"metric":     item.Metric,
+				"value":      item.Value,
+				"threshold":  item.Threshold,
+				"apiVersion": res.APIVersion,
+			}
+			if result.Namespace != "" {
+				details["namespace"] = result.Namespace
+			}
+			report.addFinding(Finding{
+				ResourceName:   resourceName,
+				ResourceKind:   res.Kind,
+				RuleID:         item.RuleID,
+				Message:        item.Description,
+				Severity:       mapSeverity(item.Severity),
+				Recommendation: item.Remediation,
+				PendingSince:   item.PendingSince,
+				Details:        details,
+			})
+		}
+
+		if detail.ChecksFailed > 0 {
+			report.Summary.ResourcesWithIssues++
+		}
+		report.ResourceDetails = append(report.ResourceDetails, detail)
+	}
+	// 规则列表已按资源类型筛选
+	report.Compliance = newCompliance(rulesList, "", checks)
+	report.Rules = newRuleInfos(rulesList, "")
+
+	return report
+}
+
+// newReport 创建带有初始汇总信息的空报告
+func newReport(g *DefaultGenerator, totalResources int) *Report {
+	return &Report{
+		Timestamp:   time.Now(),
+		ClusterName: g.ClusterName,
+		Namespace:   g.Namespace,
+		Findings:    make([]Finding, 0),
+		Summary: ReportSummary{
+			TotalResources: totalResources,
+			FindingCounts: map[Severity]int{
+				SeverityInfo:     0,
+				SeverityWarning:  0,
+				SeverityError:    0,
+				SeverityCritical: 0,
+			},
+		},
+	}
+}
+
+// addFinding 添加发现项并更新严重性计数
+func (r *Report) addFinding(finding Finding) {
+	r.Findings = append(r.Findings, finding)
+	r.Summary.FindingCounts[finding.Severity]++
+}
+
+// createDeploymentDetailFromAnalysisResult 从分析结果创建Deployment详情
+func createDeploymentDetailFromAnalysisResult(result *deployment.AnalysisResult) DeploymentDetail {
+	dep := result.Deployment
+	detail := DeploymentDetail{
+		Name:              result.DeploymentName,
+		Namespace:         result.Namespace,
+		Replicas:          dep.Replicas,
+		AvailableReplicas: dep.AvailableReplicas,
+		Strategy:          dep.Strategy,
+		Labels:            dep.Labels,
+		Containers:        make([]DeploymentContainerDetail, 0, len(dep.Containers)),
+	}
+	for _, c := range dep.Containers {
+		detail.Containers = append(detail.Containers, DeploymentContainerDetail{
+			Name:            c.Name,
+			Image:           c.Image,
+			ImagePullPolicy: c.ImagePullPolicy,
+			Limits:          c.Resources.Limits,
+			Requests:        c.Resources.Requests,
+		})
+	}
+	return detail
+}
+
+// createServiceDetailFromAnalysisResult 从分析结果创建Service详情
+func createServiceDetailFromAnalysisResult(result *service.AnalysisResult) ServiceDetail {
+	svc := result.Service
+	detail := ServiceDetail{
+		Name:           result.ServiceName,
+		Namespace:      result.Namespace,
+		Type:           svc.Type,
+		Selector:       svc.Selector,
+		Labels:         svc.Labels,
+		ReadyEndpoints: svc.ReadyEndpoints,
+		MatchingPods:   len(svc.MatchingPods),
+	}
+	for _, p := range svc.Ports {
+		detail.Ports = append(detail.Ports, ServicePortDetail{
+			Name:       p.Name,
+			Protocol:   p.Protocol,
+			Port:       p.Port,
+			TargetPort: p.TargetPort,
+			NodePort:   p.NodePort,
+		})
+	}
+	return detail
+}
+
 // countResourcesWithIssues 计算有问题的Pod数量
 func countResourcesWithIssues(results []*pod.AnalysisResult) int {
 	count := 0
@@ -214,12 +446,57 @@ func countResourcesWithIssues(results []*pod.AnalysisResult) int {
 	return count
 }
 
+// createPodDetailFromAnalysisResult 从分析结果创建Pod详情
+func createPodDetailFromAnalysisResult(result *pod.AnalysisResult) PodDetail {
+	podDetail := PodDetail{
+		Name:            result.PodName,
+		Namespace:       result.Namespace,
+		Phase:           result.PodBasicInfo.Phase,
+		NodeName:        result.PodBasicInfo.NodeName,
+		IP:              result.PodBasicInfo.IP,
+		QOSClass:        result.PodBasicInfo.QOSClass,
+		CreationTime:    result.PodBasicInfo.CreationTime,
+		RunningDuration: result.PodBasicInfo.RunningDuration,
+		TotalRestarts:   result.PodBasicInfo.TotalRestarts,
+		HealthScore:     result.HealthScore,
+		ScoreExplanation: newScoreExplanation(result.ScoreBreakdown),
+		Containers:      make([]PodContainerDetail, 0, len(result.Containers)),
+	}
+
+	// 填充容器信息
+	for _, container := range result.Containers {
+		podDetail.Containers = append(podDetail.Containers, PodContainerDetail{
+			Name:         container.Name,
+			Image:        container.Image,
+			State:        container.State,
+			Ready:        container.Ready,
+			RestartCount: container.RestartCount,
+			HasProbes:    container.HasProbes,
+			CPU: ContainerResourceDetail{
+				Request:     container.CPU.Request,
+				Limit:       container.CPU.Limit,
+				Used:        container.CPU.Used,
+				Utilization: container.CPU.Utilization,
+			},
+			Memory: ContainerResourceDetail{
+				Request:     container.Memory.Request,
+				Limit:       container.Memory.Limit,
+				Used:        container.Memory.Used,
+				Utilization: container.Memory.Utilization,
+			},
+		})
+	}
+
+	return podDetail
+}
+
 // createNodeDetailFromAnalysisResult 从分析结果创建节点详情
 func (g *DefaultGenerator) createNodeDetailFromAnalysisResult(result *node.AnalysisResult) NodeDetail {
 	// 创建节点详情
 	nodeDetail := NodeDetail{
 		Name:            result.NodeName,
 		HealthScore:     result.HealthScore,
+		ScoreExplanation: newScoreExplanation(result.ScoreBreakdown),
 		Ready:           result.NodeBasicInfo.Ready,
 		RunningPods:     result.NodeBasicInfo.RunningPods,
 		TotalPods:       result.NodeBasicInfo.TotalPods,
@@ -0,0 +1,35 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSONFormatter 实现了用于JSON输出的Formatter接口
+type JSONFormatter struct {
+	// Indent 决定是否输出缩进格式的JSON
+	Indent bool
+}
+
+// NewJSONFormatter 创建一个新的JSON格式化器
+func NewJSONFormatter(indent bool) Formatter {
+	return &JSONFormatter{
+		Indent: indent,
+	}
+}
+
+// Format 将报告转换为带版本信息的JSON文档
+func (f *JSONFormatter) Format(report *Report) string {
+	var data []byte
+	var err error
+	if f.Indent {
+		data, err = json.MarshalIndent(NewDocument(report), "", "  ")
+	} else {
+		data, err = json.Marshal(NewDocument(report))
+	}
+	if err != nil {
+		// 序列化失败时仍输出合法的JSON，便于下游程序识别
+		return fmt.Sprintf(`{"apiVersion":%q,"kind":%q,"error":%q}`, ReportAPIVersion, ReportKind, err.Error())
+	}
+	return string(data)
+}
@@ -0,0 +1,80 @@
+package report
+
+import "sort"
+
+// Merge 将多份报告合并为一份，用于一次检查多种资源类型的场景；
+// 合并后的报告使用第一份报告的时间戳、集群名称和命名空间，合规控制项按框架和编号合并计数，规则按ID去重
+func Merge(reports ...*Report) *Report {
+	merged := &Report{
+		Findings: make([]Finding, 0),
+		Summary: ReportSummary{
+			FindingCounts: map[Severity]int{
+				SeverityInfo:     0,
+				SeverityWarning:  0,
+				SeverityError:    0,
+				SeverityCritical: 0,
+			},
+		},
+	}
+	index := make(map[string]int)
+	seenRules := make(map[string]bool)
+	for i, r := range reports {
+		if i == 0 {
+			merged.Timestamp = r.Timestamp
+			merged.ClusterName = r.ClusterName
+			merged.Namespace = r.Namespace
+		}
+		merged.NodeDetails = append(merged.NodeDetails, r.NodeDetails...)
+		merged.PodDetails = append(merged.PodDetails, r.PodDetails...)
+		merged.DeploymentDetails = append(merged.DeploymentDetails, r.DeploymentDetails...)
+		merged.ServiceDetails = append(merged.ServiceDetails, r.ServiceDetails...)
+		merged.ResourceDetails = append(merged.ResourceDetails, r.ResourceDetails...)
+		merged.Findings = append(merged.Findings, r.Findings...)
+		merged.Suppressed = append(merged.Suppressed, r.Suppressed...)
+		for _, rule := range r.Rules {
+			if !seenRules[rule.ID] {
+				seenRules[rule.ID] = true
+				merged.Rules = append(merged.Rules, rule)
+			}
+		}
+
+		merged.Summary.TotalResources += r.Summary.TotalResources
+		merged.Summary.ResourcesWithIssues += r.Summary.ResourcesWithIssues
+		merged.Summary.Suppressed += r.Summary.Suppressed
+		for severity, count := range r.Summary.FindingCounts {
+			merged.Summary.FindingCounts[severity] += count
+		}
+
+		for _, control := range r.Compliance {
+			key := control.Framework + ":" + control.ControlID
+			j, ok := index[key]
+			if !ok {
+				index[key] = len(merged.Compliance)
+				control.Rules = append([]string(nil), control.Rules...)
+				merged.Compliance = append(merged.Compliance, control)
+				continue
+			}
+			existing := &merged.Compliance[j]
+			for _, ruleID := range control.Rules {
+				if !containsRule(existing.Rules, ruleID) {
+					existing.Rules = append(existing.Rules, ruleID)
+				}
+			}
+			if existing.Title == "" {
+				existing.Title = control.Title
+			}
+			existing.Passed += control.Passed
+			existing.Failed += control.Failed
+			existing.Waived += control.Waived
+			existing.updateStatus()
+		}
+	}
+
+	sort.SliceStable(merged.Compliance, func(i, j int) bool {
+		if merged.Compliance[i].Framework != merged.Compliance[j].Framework {
+			return merged.Compliance[i].Framework < merged.Compliance[j].Framework
+		}
+		return lessControlID(merged.Compliance[i].ControlID, merged.Compliance[j].ControlID)
+	})
+	return merged
+}
@@ -0,0 +1,41 @@
+package report
+
+import "github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+
+// RuleInfo 报告中评估的规则的元数据，名称、描述和修复建议为当前语言的文本
+type RuleInfo struct {
+	// ID 规则ID
+	ID string
+	// Name 规则名称
+	Name string
+	// Description 规则描述
+	Description string
+	// Category 规则类别
+	Category string
+	// Severity 规则的严重性
+	Severity Severity
+	// Remediation 修复建议
+	Remediation string
+	// Tags 规则标签
+	Tags []string
+}
+
+// newRuleInfos 返回规则的元数据；category 不为空时只包含该类别的规则，已禁用的规则不包含
+func newRuleInfos(rulesList []rules.Rule, category string) []RuleInfo {
+	infos := make([]RuleInfo, 0, len(rulesList))
+	for _, rule := range rulesList {
+		if !rule.Enabled || (category != "" && rule.Category != category) {
+			continue
+		}
+		infos = append(infos, RuleInfo{
+			ID:          rule.ID,
+			Name:        rule.Name,
+			Description: rule.Description,
+			Category:    rule.Category,
+			Severity:    mapSeverity(rule.Severity),
+			Remediation: rule.Remediation,
+			Tags:        rule.Tags,
+		})
+	}
+	return infos
+}
@@ -0,0 +1,297 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// SARIF 输出的版本信息和工具信息
+const (
+	// SARIFVersion 输出的 SARIF 规范版本
+	SARIFVersion = "2.1.0"
+	// SARIFSchema SARIF 2.1.0 的 JSON Schema
+	SARIFSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+	// sarifToolName 写入 SARIF 的工具名称
+	sarifToolName = "inspector"
+	// sarifToolURI 工具的项目地址
+	sarifToolURI = "https://github.com/FreshMan1123/k8s-resource-inspector"
+	// sarifSourceRoot 清单文件相对路径的基准目录，由代码扫描平台解析为仓库根目录
+	sarifSourceRoot = "%SRCROOT%"
+)
+
+// SARIFFormatter 实现了用于 SARIF 2.1.0 输出的Formatter接口，供代码扫描平台展示发现项：
+// 每条规则对应一个 reportingDescriptor，发现项位于清单文件和行号（检查资源清单时），
+// 或位于 namespace/kind/name 形式的逻辑位置（巡检集群时）；被豁免的发现项带有 suppressions
+type SARIFFormatter struct{}
+
+// NewSARIFFormatter 创建一个新的SARIF格式化器
+func NewSARIFFormatter() Formatter {
+	return &SARIFFormatter{}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool              `json:"tool"`
+	Results    []sarifResult          `json:"results"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string                     `json:"name"`
+	InformationURI string                     `json:"informationUri"`
+	Rules          []sarifReportingDescriptor `json:"rules"`
+}
+
+type sarifReportingDescriptor struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name,omitempty"`
+	ShortDescription     *sarifMessage          `json:"shortDescription,omitempty"`
+	FullDescription      *sarifMessage          `json:"fullDescription,omitempty"`
+	Help                 *sarifMessage          `json:"help,omitempty"`
+	DefaultConfiguration sarifConfiguration     `json:"defaultConfiguration"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string                 `json:"ruleId"`
+	RuleIndex    int                    `json:"ruleIndex"`
+	Level        string                 `json:"level"`
+	Message      sarifMessage           `json:"message"`
+	Locations    []sarifLocation        `json:"locations"`
+	Suppressions []sarifSuppression     `json:"suppressions,omitempty"`
+	Properties   map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// Format 将报告转换为 SARIF 2.1.0 日志
+func (f *SARIFFormatter) Format(report *Report) string {
+	descriptors, ruleIndex := sarifRules(report)
+	results := make([]sarifResult, 0, len(report.Findings)+len(report.Suppressed))
+	for _, finding := range report.Findings {
+		results = append(results, newSARIFResult(finding, ruleIndex[finding.RuleID]))
+	}
+	for _, suppressed := range report.Suppressed {
+		result := newSARIFResult(suppressed.Finding, ruleIndex[suppressed.RuleID])
+		result.Suppressions = []sarifSuppression{{
+			Kind:          "external",
+			Justification: suppressed.Waiver.Reason,
+		}}
+		results = append(results, result)
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           sarifToolName,
+			InformationURI: sarifToolURI,
+			Rules:          descriptors,
+		}},
+		Results: results,
+	}
+	properties := make(map[string]interface{})
+	if report.ClusterName != "" {
+		properties["clusterName"] = report.ClusterName
+	}
+	if report.Namespace != "" {
+		properties["namespace"] = report.Namespace
+	}
+	if len(properties) > 0 {
+		run.Properties = properties
+	}
+
+	data, err := json.MarshalIndent(sarifLog{Schema: SARIFSchema, Version: SARIFVersion, Runs: []sarifRun{run}}, "", "  ")
+	if err != nil {
+		// 序列化失败时仍输出合法的SARIF，便于代码扫描平台识别
+		return fmt.Sprintf(`{"$schema":%q,"version":%q,"runs":[],"properties":{"error":%q}}`, SARIFSchema, SARIFVersion, err.Error())
+	}
+	return string(data)
+}
+
+// sarifRules 为报告中评估的规则创建 reportingDescriptor，并为只出现在发现项中的规则（如过期豁免）补充描述；
+// 返回规则ID到描述索引的映射
+func sarifRules(report *Report) ([]sarifReportingDescriptor, map[string]int) {
+	descriptors := make([]sarifReportingDescriptor, 0, len(report.Rules))
+	index := make(map[string]int, len(report.Rules))
+	for _, rule := range report.Rules {
+		if _, exists := index[rule.ID]; exists {
+			continue
+		}
+		index[rule.ID] = len(descriptors)
+		descriptors = append(descriptors, newSARIFDescriptor(rule))
+	}
+
+	findings := make([]Finding, 0, len(report.Findings)+len(report.Suppressed))
+	findings = append(findings, report.Findings...)
+	for _, suppressed := range report.Suppressed {
+		findings = append(findings, suppressed.Finding)
+	}
+	for _, finding := range findings {
+		if _, exists := index[finding.RuleID]; exists {
+			continue
+		}
+		index[finding.RuleID] = len(descriptors)
+		descriptors = append(descriptors, newSARIFDescriptor(RuleInfo{
+			ID:          finding.RuleID,
+			Severity:    finding.Severity,
+			Remediation: finding.Recommendation,
+		}))
+	}
+	return descriptors, index
+}
+
+// newSARIFDescriptor 将规则转换为 reportingDescriptor，修复建议作为帮助文本
+func newSARIFDescriptor(rule RuleInfo) sarifReportingDescriptor {
+	descriptor := sarifReportingDescriptor{
+		ID:                   rule.ID,
+		Name:                 rule.Name,
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Severity)},
+	}
+	shortDescription := rule.Name
+	if shortDescription == "" {
+		shortDescription = rule.ID
+	}
+	descriptor.ShortDescription = &sarifMessage{Text: shortDescription}
+	if rule.Description != "" {
+		descriptor.FullDescription = &sarifMessage{Text: rule.Description}
+	}
+	if rule.Remediation != "" {
+		descriptor.Help = &sarifMessage{Text: rule.Remediation}
+	}
+
+	properties := make(map[string]interface{})
+	if rule.Category != "" {
+		properties["category"] = rule.Category
+	}
+	if len(rule.Tags) > 0 {
+		properties["tags"] = rule.Tags
+	}
+	if len(properties) > 0 {
+		descriptor.Properties = properties
+	}
+	return descriptor
+}
+
+// newSARIFResult 将发现项转换为 result：带有清单位置的发现项使用物理位置，同时都带有资源的逻辑位置
+func newSARIFResult(finding Finding, ruleIndex int) sarifResult {
+	message := finding.Message
+	if message == "" {
+		message = finding.RuleID
+	}
+
+	namespace, name := findingNamespaceAndName(finding)
+	qualifiedName := finding.ResourceKind + "/" + name
+	if namespace != "" {
+		qualifiedName = namespace + "/" + qualifiedName
+	}
+	location := sarifLocation{
+		LogicalLocations: []sarifLogicalLocation{{
+			Name:               name,
+			FullyQualifiedName: qualifiedName,
+			Kind:               "resource",
+		}},
+	}
+	if finding.Source != nil && finding.Source.File != "" && finding.Source.File != "-" {
+		location.PhysicalLocation = &sarifPhysicalLocation{ArtifactLocation: newSARIFArtifactLocation(finding.Source.File)}
+		if finding.Source.Line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: finding.Source.Line}
+		}
+	}
+
+	properties := map[string]interface{}{
+		"severity":     string(finding.Severity),
+		"resourceKind": finding.ResourceKind,
+	}
+	if finding.Recommendation != "" {
+		properties["recommendation"] = finding.Recommendation
+	}
+	return sarifResult{
+		RuleID:     finding.RuleID,
+		RuleIndex:  ruleIndex,
+		Level:      sarifLevel(finding.Severity),
+		Message:    sarifMessage{Text: message},
+		Locations:  []sarifLocation{location},
+		Properties: properties,
+	}
+}
+
+// newSARIFArtifactLocation 返回清单文件的位置：相对路径相对于 %SRCROOT%，绝对路径使用 file URI
+func newSARIFArtifactLocation(file string) sarifArtifactLocation {
+	if filepath.IsAbs(file) {
+		return sarifArtifactLocation{URI: "file://" + filepath.ToSlash(file)}
+	}
+	return sarifArtifactLocation{
+		URI:       strings.TrimPrefix(filepath.ToSlash(filepath.Clean(file)), "./"),
+		URIBaseID: sarifSourceRoot,
+	}
+}
+
+// findingNamespaceAndName 返回发现项对应资源的命名空间和名称，集群级资源的命名空间为空
+func findingNamespaceAndName(finding Finding) (string, string) {
+	resource := findingResource(finding)
+	if i := strings.Index(resource, "/"); i >= 0 {
+		return resource[:i], resource[i+1:]
+	}
+	if i := strings.LastIndex(finding.ResourceName, "/"); i >= 0 {
+		return finding.ResourceName[:i], resource
+	}
+	return "", resource
+}
+
+// sarifLevel 将严重性转换为 SARIF 的级别：CRITICAL 和 ERROR 为 error，WARNING 为 warning，INFO 为 note
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityCritical, SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
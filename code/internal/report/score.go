@@ -0,0 +1,42 @@
+package report
+
+import "github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+
+// ScoreExplanation 解释资源健康评分的由来
+type ScoreExplanation struct {
+	// 满分
+	Base int `json:"base"`
+	// 最终分数
+	Score int `json:"score"`
+	// 扣分明细
+	Deductions []rules.ScoreDeduction `json:"deductions"`
+	// 分数下限
+	Floor int `json:"floor,omitempty"`
+	// 是否因下限提高了分数
+	FloorApplied bool `json:"floorApplied,omitempty"`
+}
+
+// newScoreExplanation 从评分明细创建评分解释
+func newScoreExplanation(breakdown rules.ScoreBreakdown) *ScoreExplanation {
+	deductions := breakdown.Deductions
+	if deductions == nil {
+		deductions = []rules.ScoreDeduction{}
+	}
+	return &ScoreExplanation{
+		Base:         rules.BaseScore,
+		Score:        breakdown.Score,
+		Deductions:   deductions,
+		Floor:        breakdown.Floor,
+		FloorApplied: breakdown.FloorApplied,
+	}
+}
+
+// HideScoreExplanations 移除报告中的评分解释，未启用 --explain-score 时只输出分数
+func (r *Report) HideScoreExplanations() {
+	for i := range r.NodeDetails {
+		r.NodeDetails[i].ScoreExplanation = nil
+	}
+	for i := range r.PodDetails {
+		r.PodDetails[i].ScoreExplanation = nil
+	}
+}
@@ -0,0 +1,37 @@
+package report
+
+import "fmt"
+
+// SourceLocation 表示资源在清单文件中的位置
+type SourceLocation struct {
+	// File 清单文件路径，从标准输入读取时为 -
+	File string `json:"file"`
+	// Line 资源所在YAML文档的起始行号，从1开始
+	Line int `json:"line,omitempty"`
+}
+
+// String 返回 文件:行号 形式的位置
+func (l SourceLocation) String() string {
+	if l.Line == 0 {
+		return l.File
+	}
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+// SourceKey 返回资源在 AttachSources 中使用的键，命名空间资源为 Kind/namespace/name，集群级资源为 Kind/name
+func SourceKey(kind, namespace, name string) string {
+	if namespace != "" {
+		return kind + "/" + namespace + "/" + name
+	}
+	return kind + "/" + name
+}
+
+// AttachSources 为发现项设置资源在清单文件中的位置，sources 的键由 SourceKey 生成
+func (r *Report) AttachSources(sources map[string]SourceLocation) {
+	for i := range r.Findings {
+		finding := &r.Findings[i]
+		if source, ok := sources[finding.ResourceKind+"/"+findingResource(*finding)]; ok {
+			finding.Source = &source
+		}
+	}
+}
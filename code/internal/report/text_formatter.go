@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
 )
 
 // TextFormatter 实现了用于文本输出的Formatter接口
@@ -28,12 +30,28 @@ func (f *TextFormatter) Format(report *Report) string {
 	
 	// 添加节点详细信息部分
 	f.writeNodeDetails(&sb, report)
+
+	// 添加Deployment详细信息部分
+	f.writeDeploymentDetails(&sb, report)
+
+	// 添加Service详细信息部分
+	f.writeServiceDetails(&sb, report)
+	f.writeResourceDetails(&sb, report)
+
+	// 添加评分解释部分
+	f.writeScoreExplanations(&sb, report)
 	
 	// 添加摘要部分
 	f.writeSummary(&sb, report)
 	
 	// 添加发现项部分
 	f.writeFindings(&sb, report)
+
+	// 添加被豁免的发现项部分
+	f.writeSuppressed(&sb, report)
+
+	// 添加合规汇总部分
+	f.writeCompliance(&sb, report)
 	
 	return sb.String()
 }
@@ -68,22 +86,22 @@ func (f *TextFormatter) writeNodeDetails(sb *strings.Builder, report *Report) {
 	
 	for _, node := range report.NodeDetails {
 		// 基本信息
-		sb.WriteString(fmt.Sprintf("节点: %s\n", node.Name))
+		sb.WriteString(i18n.Sprintf("节点: %s\n", node.Name))
 		
 		// 角色信息
 		if len(node.Roles) > 0 {
-			sb.WriteString(fmt.Sprintf("角色: %s\n", strings.Join(node.Roles, ", ")))
+			sb.WriteString(i18n.Sprintf("角色: %s\n", strings.Join(node.Roles, ", ")))
 		} else {
-			sb.WriteString("角色: 未知\n")
+			sb.WriteString(i18n.T("角色: 未知\n"))
 		}
 		
 		// 状态信息
-		sb.WriteString(fmt.Sprintf("状态: %s\n", getNodeStatusString(node.Ready)))
-		sb.WriteString(fmt.Sprintf("可调度: %v\n", node.Schedulable))
+		sb.WriteString(i18n.Sprintf("状态: %s\n", getNodeStatusString(node.Ready)))
+		sb.WriteString(i18n.Sprintf("可调度: %v\n", node.Schedulable))
 		
 		// 地址信息
 		if len(node.Addresses) > 0 {
-			sb.WriteString("地址:\n")
+			sb.WriteString(i18n.T("地址:\n"))
 			for addrType, addr := range node.Addresses {
 				sb.WriteString(fmt.Sprintf("  %s: %s\n", addrType, addr))
 			}
@@ -91,90 +109,207 @@ func (f *TextFormatter) writeNodeDetails(sb *strings.Builder, report *Report) {
 		
 		// 创建时间
 		if !node.CreationTime.IsZero() {
-			sb.WriteString(fmt.Sprintf("创建时间: %s\n", node.CreationTime.Format(time.RFC3339)))
+			sb.WriteString(i18n.Sprintf("创建时间: %s\n", node.CreationTime.Format(time.RFC3339)))
 		}
 		
 		// 节点信息
-		sb.WriteString("节点信息:\n")
-		sb.WriteString(fmt.Sprintf("  内核版本: %s\n", getValueOrDefault(node.NodeInfo.KernelVersion, "未知")))
-		sb.WriteString(fmt.Sprintf("  操作系统: %s\n", getValueOrDefault(node.NodeInfo.OSImage, "未知")))
-		sb.WriteString(fmt.Sprintf("  容器运行时: %s\n", getValueOrDefault(node.NodeInfo.ContainerRuntimeVersion, "未知")))
-		sb.WriteString(fmt.Sprintf("  Kubelet版本: %s\n", getValueOrDefault(node.NodeInfo.KubeletVersion, "未知")))
+		sb.WriteString(i18n.T("节点信息:\n"))
+		sb.WriteString(i18n.Sprintf("  内核版本: %s\n", getValueOrDefault(node.NodeInfo.KernelVersion, i18n.T("未知"))))
+		sb.WriteString(i18n.Sprintf("  操作系统: %s\n", getValueOrDefault(node.NodeInfo.OSImage, i18n.T("未知"))))
+		sb.WriteString(i18n.Sprintf("  容器运行时: %s\n", getValueOrDefault(node.NodeInfo.ContainerRuntimeVersion, i18n.T("未知"))))
+		sb.WriteString(i18n.Sprintf("  Kubelet版本: %s\n", getValueOrDefault(node.NodeInfo.KubeletVersion, i18n.T("未知"))))
 		// Kube-Proxy版本已被废弃，不再显示
-		sb.WriteString(fmt.Sprintf("  架构: %s\n", getValueOrDefault(node.NodeInfo.Architecture, "未知")))
+		sb.WriteString(i18n.Sprintf("  架构: %s\n", getValueOrDefault(node.NodeInfo.Architecture, i18n.T("未知"))))
 		
 		// 资源信息
-		sb.WriteString("资源使用情况:\n")
+		sb.WriteString(i18n.T("资源使用情况:\n"))
 		
 		// CPU资源
 		sb.WriteString("  CPU:\n")
 		if node.CPU.Capacity != "" {
-			sb.WriteString(fmt.Sprintf("    总量: %s\n", node.CPU.Capacity))
+			sb.WriteString(i18n.Sprintf("    总量: %s\n", node.CPU.Capacity))
 		}
 		if node.CPU.Allocatable != "" {
-			sb.WriteString(fmt.Sprintf("    可分配: %s\n", node.CPU.Allocatable))
+			sb.WriteString(i18n.Sprintf("    可分配: %s\n", node.CPU.Allocatable))
 		}
 		if node.CPU.Allocated != "" {
-			sb.WriteString(fmt.Sprintf("    已分配: %s\n", node.CPU.Allocated))
+			sb.WriteString(i18n.Sprintf("    已分配: %s\n", node.CPU.Allocated))
 		}
 		if node.CPU.Used != "" {
-			sb.WriteString(fmt.Sprintf("    已使用: %s\n", node.CPU.Used))
+			sb.WriteString(i18n.Sprintf("    已使用: %s\n", node.CPU.Used))
 		}
-		sb.WriteString(fmt.Sprintf("    利用率: %.2f%%\n", node.CPU.Utilization))
-		sb.WriteString(fmt.Sprintf("    分配率: %.2f%%\n", node.CPU.AllocationRate))
+		sb.WriteString(i18n.Sprintf("    利用率: %.2f%%\n", node.CPU.Utilization))
+		sb.WriteString(i18n.Sprintf("    分配率: %.2f%%\n", node.CPU.AllocationRate))
 		
 		// 内存资源
-		sb.WriteString("  内存:\n")
+		sb.WriteString(i18n.T("  内存:\n"))
 		if node.Memory.Capacity != "" {
-			sb.WriteString(fmt.Sprintf("    总量: %s\n", node.Memory.Capacity))
+			sb.WriteString(i18n.Sprintf("    总量: %s\n", node.Memory.Capacity))
 		}
 		if node.Memory.Allocatable != "" {
-			sb.WriteString(fmt.Sprintf("    可分配: %s\n", node.Memory.Allocatable))
+			sb.WriteString(i18n.Sprintf("    可分配: %s\n", node.Memory.Allocatable))
 		}
 		if node.Memory.Allocated != "" {
-			sb.WriteString(fmt.Sprintf("    已分配: %s\n", node.Memory.Allocated))
+			sb.WriteString(i18n.Sprintf("    已分配: %s\n", node.Memory.Allocated))
 		}
 		if node.Memory.Used != "" {
-			sb.WriteString(fmt.Sprintf("    已使用: %s\n", node.Memory.Used))
+			sb.WriteString(i18n.Sprintf("    已使用: %s\n", node.Memory.Used))
 		}
-		sb.WriteString(fmt.Sprintf("    利用率: %.2f%%\n", node.Memory.Utilization))
-		sb.WriteString(fmt.Sprintf("    分配率: %.2f%%\n", node.Memory.AllocationRate))
+		sb.WriteString(i18n.Sprintf("    利用率: %.2f%%\n", node.Memory.Utilization))
+		sb.WriteString(i18n.Sprintf("    分配率: %.2f%%\n", node.Memory.AllocationRate))
 		
 		// 临时存储资源
-		sb.WriteString("  临时存储:\n")
+		sb.WriteString(i18n.T("  临时存储:\n"))
 		if node.EphemeralStorage.Capacity != "" {
-			sb.WriteString(fmt.Sprintf("    总量: %s\n", node.EphemeralStorage.Capacity))
+			sb.WriteString(i18n.Sprintf("    总量: %s\n", node.EphemeralStorage.Capacity))
 		}
 		if node.EphemeralStorage.Allocatable != "" {
-			sb.WriteString(fmt.Sprintf("    可分配: %s\n", node.EphemeralStorage.Allocatable))
+			sb.WriteString(i18n.Sprintf("    可分配: %s\n", node.EphemeralStorage.Allocatable))
 		}
 		if node.EphemeralStorage.Allocated != "" {
-			sb.WriteString(fmt.Sprintf("    已分配: %s\n", node.EphemeralStorage.Allocated))
+			sb.WriteString(i18n.Sprintf("    已分配: %s\n", node.EphemeralStorage.Allocated))
 		}
 		if node.EphemeralStorage.Used != "" {
-			sb.WriteString(fmt.Sprintf("    已使用: %s\n", node.EphemeralStorage.Used))
+			sb.WriteString(i18n.Sprintf("    已使用: %s\n", node.EphemeralStorage.Used))
 		}
-		sb.WriteString(fmt.Sprintf("    利用率: %.2f%%\n", node.EphemeralStorage.Utilization))
-		sb.WriteString(fmt.Sprintf("    分配率: %.2f%%\n", node.EphemeralStorage.AllocationRate))
+		sb.WriteString(i18n.Sprintf("    利用率: %.2f%%\n", node.EphemeralStorage.Utilization))
+		sb.WriteString(i18n.Sprintf("    分配率: %.2f%%\n", node.EphemeralStorage.AllocationRate))
 		
 		// Pod信息
-		sb.WriteString(fmt.Sprintf("Pod数量: %d/%d (%.2f%%)\n", node.RunningPods, node.TotalPods, 
+		sb.WriteString(i18n.Sprintf("Pod数量: %d/%d (%.2f%%)\n", node.RunningPods, node.TotalPods, 
 			calculatePodPercentage(node.RunningPods, node.TotalPods)))
 		
 		// 压力状态
-		sb.WriteString("压力状态:\n")
-		sb.WriteString(fmt.Sprintf("  内存压力: %v\n", node.PressureStatus.MemoryPressure))
-		sb.WriteString(fmt.Sprintf("  CPU压力: %v\n", node.PressureStatus.CPUPressure))
-		sb.WriteString(fmt.Sprintf("  磁盘压力: %v\n", node.PressureStatus.DiskPressure))
-		sb.WriteString(fmt.Sprintf("  网络压力: %v\n", node.PressureStatus.NetworkPressure))
-		sb.WriteString(fmt.Sprintf("  PID压力: %v\n", node.PressureStatus.PIDPressure))
+		sb.WriteString(i18n.T("压力状态:\n"))
+		sb.WriteString(i18n.Sprintf("  内存压力: %v\n", node.PressureStatus.MemoryPressure))
+		sb.WriteString(i18n.Sprintf("  CPU压力: %v\n", node.PressureStatus.CPUPressure))
+		sb.WriteString(i18n.Sprintf("  磁盘压力: %v\n", node.PressureStatus.DiskPressure))
+		sb.WriteString(i18n.Sprintf("  网络压力: %v\n", node.PressureStatus.NetworkPressure))
+		sb.WriteString(i18n.Sprintf("  PID压力: %v\n", node.PressureStatus.PIDPressure))
 		
 		// 健康评分
-		sb.WriteString(fmt.Sprintf("健康评分: %d/100\n", node.HealthScore))
+		sb.WriteString(i18n.Sprintf("健康评分: %d/100\n", node.HealthScore))
 		sb.WriteString("\n")
 	}
 }
 
+// writeDeploymentDetails 添加Deployment详细信息部分到字符串构建器
+func (f *TextFormatter) writeDeploymentDetails(sb *strings.Builder, report *Report) {
+	if len(report.DeploymentDetails) == 0 {
+		return
+	}
+
+	sb.WriteString("DEPLOYMENT DETAILS\n")
+	sb.WriteString("----------------------------------------\n\n")
+
+	for _, dep := range report.DeploymentDetails {
+		sb.WriteString(fmt.Sprintf("Deployment: %s/%s\n", dep.Namespace, dep.Name))
+		sb.WriteString(i18n.Sprintf("副本数: %d/%d 可用\n", dep.AvailableReplicas, dep.Replicas))
+		if dep.Strategy != "" {
+			sb.WriteString(i18n.Sprintf("更新策略: %s\n", dep.Strategy))
+		}
+		for _, c := range dep.Containers {
+			sb.WriteString(i18n.Sprintf("容器: %s (%s, 拉取策略: %s)\n", c.Name, c.Image, getValueOrDefault(c.ImagePullPolicy, i18n.T("未知"))))
+		}
+		sb.WriteString(i18n.Sprintf("检查结果: %d 通过, %d 未通过\n", dep.ChecksPassed, dep.ChecksFailed))
+		sb.WriteString("\n")
+	}
+}
+
+// writeServiceDetails 添加Service详细信息部分到字符串构建器
+func (f *TextFormatter) writeServiceDetails(sb *strings.Builder, report *Report) {
+	if len(report.ServiceDetails) == 0 {
+		return
+	}
+
+	sb.WriteString("SERVICE DETAILS\n")
+	sb.WriteString("----------------------------------------\n\n")
+
+	for _, svc := range report.ServiceDetails {
+		sb.WriteString(fmt.Sprintf("Service: %s/%s\n", svc.Namespace, svc.Name))
+		sb.WriteString(i18n.Sprintf("类型: %s\n", getValueOrDefault(svc.Type, i18n.T("未知"))))
+		for _, port := range svc.Ports {
+			sb.WriteString(i18n.Sprintf("端口: %d/%s -> %s\n", port.Port, port.Protocol, port.TargetPort))
+		}
+		sb.WriteString(i18n.Sprintf("就绪端点: %d, 匹配Pod: %d\n", svc.ReadyEndpoints, svc.MatchingPods))
+		sb.WriteString(i18n.Sprintf("检查结果: %d 通过, %d 未通过\n", svc.ChecksPassed, svc.ChecksFailed))
+		sb.WriteString("\n")
+	}
+}
+
+// writeResourceDetails 添加任意资源详细信息部分到字符串构建器
+func (f *TextFormatter) writeResourceDetails(sb *strings.Builder, report *Report) {
+	if len(report.ResourceDetails) == 0 {
+		return
+	}
+
+	sb.WriteString("RESOURCE DETAILS\n")
+	sb.WriteString("----------------------------------------\n\n")
+
+	for _, res := range report.ResourceDetails {
+		name := res.Name
+		if res.Namespace != "" {
+			name = res.Namespace + "/" + res.Name
+		}
+		sb.WriteString(fmt.Sprintf("%s: %s\n", res.Kind, name))
+		sb.WriteString(i18n.Sprintf("API版本: %s\n", res.APIVersion))
+		sb.WriteString(i18n.Sprintf("检查结果: %d 通过, %d 未通过\n", res.ChecksPassed, res.ChecksFailed))
+		sb.WriteString("\n")
+	}
+}
+
+// writeScoreExplanations 添加健康评分的扣分明细到字符串构建器，仅在报告包含评分解释时输出
+func (f *TextFormatter) writeScoreExplanations(sb *strings.Builder, report *Report) {
+	var sections []string
+	for _, node := range report.NodeDetails {
+		if node.ScoreExplanation != nil {
+			sections = append(sections, formatScoreExplanation("Node: "+node.Name, node.ScoreExplanation))
+		}
+	}
+	for _, pod := range report.PodDetails {
+		if pod.ScoreExplanation != nil {
+			sections = append(sections, formatScoreExplanation(fmt.Sprintf("Pod: %s/%s", pod.Namespace, pod.Name), pod.ScoreExplanation))
+		}
+	}
+	if len(sections) == 0 {
+		return
+	}
+
+	sb.WriteString("SCORE EXPLANATION\n")
+	sb.WriteString("----------------------------------------\n\n")
+	for _, section := range sections {
+		sb.WriteString(section)
+		sb.WriteString("\n")
+	}
+}
+
+// formatScoreExplanation 格式化单个资源的评分解释
+func formatScoreExplanation(title string, explanation *ScoreExplanation) string {
+	var sb strings.Builder
+	sb.WriteString(i18n.Sprintf("%s (健康评分: %d/%d)\n", title, explanation.Score, explanation.Base))
+	if len(explanation.Deductions) == 0 {
+		sb.WriteString(i18n.T("  无扣分\n"))
+	}
+	for _, d := range explanation.Deductions {
+		source := i18n.Sprintf("严重程度 %s", d.Severity)
+		if d.Source == "rule" {
+			source = i18n.Sprintf("规则权重, 严重程度 %s", d.Severity)
+		}
+		line := fmt.Sprintf("  -%-3d %s (%s", d.Points, d.RuleID, source)
+		if d.Category != "" {
+			line += i18n.Sprintf(", 类别 %s", d.Category)
+		}
+		if d.Capped {
+			line += i18n.Sprintf(", 权重 %d 受类别上限限制", d.Weight)
+		}
+		sb.WriteString(line + ")\n")
+	}
+	if explanation.FloorApplied {
+		sb.WriteString(i18n.Sprintf("  分数低于下限 %d，按下限计\n", explanation.Floor))
+	}
+	return sb.String()
+}
+
 // getNodeStatusString 根据节点就绪状态返回状态字符串
 func getNodeStatusString(ready bool) string {
 	if ready {
@@ -212,6 +347,9 @@ func (f *TextFormatter) writeSummary(sb *strings.Builder, report *Report) {
 	f.writeSeverityCount(sb, "ERROR", report.Summary.FindingCounts[SeverityError])
 	f.writeSeverityCount(sb, "WARNING", report.Summary.FindingCounts[SeverityWarning])
 	f.writeSeverityCount(sb, "INFO", report.Summary.FindingCounts[SeverityInfo])
+	if report.Summary.Suppressed > 0 {
+		sb.WriteString(fmt.Sprintf("  %-8s %d\n", "WAIVED", report.Summary.Suppressed))
+	}
 	
 	sb.WriteString("\n")
 }
@@ -295,11 +433,19 @@ func (f *TextFormatter) writeFindings(sb *strings.Builder, report *Report) {
 			
 			sb.WriteString(fmt.Sprintf("[%s] Rule: %s\n", severityStr, finding.RuleID))
 			sb.WriteString(fmt.Sprintf("Message: %s\n", finding.Message))
+
+			if finding.Source != nil {
+				sb.WriteString(fmt.Sprintf("Source: %s\n", finding.Source))
+			}
 			
 			if finding.Recommendation != "" {
 				sb.WriteString(fmt.Sprintf("Recommendation: %s\n", finding.Recommendation))
 			}
 			
+			if finding.PendingSince != nil {
+				sb.WriteString(fmt.Sprintf("Pending Since: %s\n", finding.PendingSince.Format("2006-01-02 15:04:05")))
+			}
+			
 			// 添加相关详情
 			if cpuUtil, ok := finding.Details["cpu_utilization"]; ok {
 				sb.WriteString(fmt.Sprintf("CPU Utilization: %.1f%%\n", cpuUtil))
@@ -312,4 +458,64 @@ func (f *TextFormatter) writeFindings(sb *strings.Builder, report *Report) {
 		
 		resourceCount++
 	}
-} 
\ No newline at end of file
+}
+
+// writeSuppressed 添加被豁免的发现项部分到字符串构建器
+func (f *TextFormatter) writeSuppressed(sb *strings.Builder, report *Report) {
+	if len(report.Suppressed) == 0 {
+		return
+	}
+
+	sb.WriteString("\nSUPPRESSED FINDINGS\n")
+	sb.WriteString("----------------------------------------\n\n")
+
+	for i, suppressed := range report.Suppressed {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("Resource: %s/%s\n", suppressed.ResourceKind, suppressed.ResourceName))
+		sb.WriteString(fmt.Sprintf("[%s] Rule: %s\n", suppressed.Severity, suppressed.RuleID))
+		sb.WriteString(fmt.Sprintf("Message: %s\n", suppressed.Message))
+		sb.WriteString(fmt.Sprintf("Waived by: %s (expires %s, %s)\n", suppressed.Waiver.Owner, suppressed.Waiver.Expires, suppressed.Waiver.Source))
+		sb.WriteString(fmt.Sprintf("Reason: %s\n", suppressed.Waiver.Reason))
+	}
+}
+
+// writeCompliance 添加按合规框架控制项汇总的检查结果到字符串构建器
+func (f *TextFormatter) writeCompliance(sb *strings.Builder, report *Report) {
+	if len(report.Compliance) == 0 {
+		return
+	}
+
+	sb.WriteString("\nCOMPLIANCE SUMMARY\n")
+	sb.WriteString("----------------------------------------\n")
+
+	framework := ""
+	for _, control := range report.Compliance {
+		if control.Framework != framework {
+			framework = control.Framework
+			sb.WriteString(fmt.Sprintf("\n%s\n", framework))
+		}
+		status := fmt.Sprintf("[%s]", control.Status)
+		if f.ColorEnabled {
+			switch control.Status {
+			case ControlPass:
+				status = "\033[32m" + status + "\033[0m" // 绿色
+			case ControlFail:
+				status = "\033[31m" + status + "\033[0m" // 红色
+			case ControlWaived:
+				status = "\033[33m" + status + "\033[0m" // 黄色
+			}
+		}
+		line := fmt.Sprintf("  %s %s", status, control.ControlID)
+		if control.Title != "" {
+			line += " " + control.Title
+		}
+		sb.WriteString(line + "\n")
+		counts := i18n.Sprintf("%d 通过, %d 未通过", control.Passed, control.Failed)
+		if control.Waived > 0 {
+			counts += i18n.Sprintf(", %d 豁免", control.Waived)
+		}
+		sb.WriteString(i18n.Sprintf("      检查: %s; 规则: %s\n", counts, strings.Join(control.Rules, ", ")))
+	}
+}
@@ -1,11 +1,15 @@
 package report
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/generic"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/node"
-	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
 	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/pod"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/service"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
 )
 
 // Severity 定义报告发现项的重要性级别
@@ -33,7 +37,7 @@ type NodeDetail struct {
 	Addresses map[string]string `json:"addresses"`
 	// 节点创建时间
 	CreationTime time.Time `json:"creationTime"`
-	
+
 	// 节点信息
 	NodeInfo struct {
 		// 内核版本
@@ -48,7 +52,7 @@ type NodeDetail struct {
 		// Architecture
 		Architecture string `json:"architecture"`
 	} `json:"nodeInfo"`
-	
+
 	// 节点压力状态
 	PressureStatus struct {
 		// CPU压力状态
@@ -62,7 +66,7 @@ type NodeDetail struct {
 		// PID压力状态
 		PIDPressure bool `json:"pidPressure"`
 	} `json:"pressureStatus"`
-	
+
 	// CPU资源指标
 	CPU struct {
 		// 资源总量
@@ -78,7 +82,7 @@ type NodeDetail struct {
 		// 资源分配率
 		AllocationRate float64 `json:"allocationRate"`
 	} `json:"cpu"`
-	
+
 	// 内存资源指标
 	Memory struct {
 		// 资源总量
@@ -94,7 +98,7 @@ type NodeDetail struct {
 		// 资源分配率
 		AllocationRate float64 `json:"allocationRate"`
 	} `json:"memory"`
-	
+
 	// 临时存储资源指标
 	EphemeralStorage struct {
 		// 资源总量
@@ -110,7 +114,7 @@ type NodeDetail struct {
 		// 资源分配率
 		AllocationRate float64 `json:"allocationRate"`
 	} `json:"ephemeralStorage"`
-	
+
 	// CPU利用率 (保留向后兼容)
 	CPUUtilization float64 `json:"cpuUtilization"`
 	// 内存利用率 (保留向后兼容)
@@ -125,6 +129,160 @@ type NodeDetail struct {
 	PodUtilization float64 `json:"podUtilization"`
 	// 健康评分
 	HealthScore int `json:"healthScore"`
+	// 健康评分的扣分明细，仅在 --explain-score 时输出
+	ScoreExplanation *ScoreExplanation `json:"scoreExplanation,omitempty"`
+}
+
+// PodDetail 表示Pod的详细信息
+type PodDetail struct {
+	// Pod名称
+	Name string `json:"name"`
+	// Pod命名空间
+	Namespace string `json:"namespace"`
+	// Pod状态
+	Phase string `json:"phase"`
+	// 所在节点名称
+	NodeName string `json:"nodeName,omitempty"`
+	// Pod IP地址
+	IP string `json:"ip,omitempty"`
+	// QoS类别
+	QOSClass string `json:"qosClass,omitempty"`
+	// Pod创建时间
+	CreationTime time.Time `json:"creationTime"`
+	// Pod运行时长
+	RunningDuration string `json:"runningDuration,omitempty"`
+	// 总重启次数
+	TotalRestarts int `json:"totalRestarts"`
+	// 容器详情
+	Containers []PodContainerDetail `json:"containers,omitempty"`
+	// 健康评分
+	HealthScore int `json:"healthScore"`
+	// 健康评分的扣分明细，仅在 --explain-score 时输出
+	ScoreExplanation *ScoreExplanation `json:"scoreExplanation,omitempty"`
+}
+
+// PodContainerDetail 表示Pod中单个容器的详细信息
+type PodContainerDetail struct {
+	// 容器名称
+	Name string `json:"name"`
+	// 容器镜像
+	Image string `json:"image"`
+	// 容器状态
+	State string `json:"state"`
+	// 容器就绪状态
+	Ready bool `json:"ready"`
+	// 重启次数
+	RestartCount int `json:"restartCount"`
+	// 是否配置了健康检查
+	HasProbes bool `json:"hasProbes"`
+	// CPU资源
+	CPU ContainerResourceDetail `json:"cpu"`
+	// 内存资源
+	Memory ContainerResourceDetail `json:"memory"`
+}
+
+// ContainerResourceDetail 表示容器单项资源的请求、限制和使用情况
+type ContainerResourceDetail struct {
+	// 请求量
+	Request string `json:"request,omitempty"`
+	// 限制量
+	Limit string `json:"limit,omitempty"`
+	// 实际使用量
+	Used string `json:"used,omitempty"`
+	// 利用率
+	Utilization float64 `json:"utilization"`
+}
+
+// DeploymentDetail 表示Deployment的详细信息
+type DeploymentDetail struct {
+	// Deployment名称
+	Name string `json:"name"`
+	// Deployment命名空间
+	Namespace string `json:"namespace"`
+	// 期望副本数
+	Replicas int32 `json:"replicas"`
+	// 可用副本数
+	AvailableReplicas int32 `json:"availableReplicas"`
+	// 更新策略
+	Strategy string `json:"strategy,omitempty"`
+	// 标签
+	Labels map[string]string `json:"labels,omitempty"`
+	// 容器详情
+	Containers []DeploymentContainerDetail `json:"containers,omitempty"`
+	// 通过的检查数量
+	ChecksPassed int `json:"checksPassed"`
+	// 未通过的检查数量
+	ChecksFailed int `json:"checksFailed"`
+}
+
+// DeploymentContainerDetail 表示Deployment中单个容器的配置
+type DeploymentContainerDetail struct {
+	// 容器名称
+	Name string `json:"name"`
+	// 容器镜像
+	Image string `json:"image"`
+	// 镜像拉取策略
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+	// 资源限制
+	Limits map[string]string `json:"limits,omitempty"`
+	// 资源请求
+	Requests map[string]string `json:"requests,omitempty"`
+}
+
+// ServiceDetail 表示Service的详细信息
+type ServiceDetail struct {
+	// Service名称
+	Name string `json:"name"`
+	// Service命名空间
+	Namespace string `json:"namespace"`
+	// Service类型
+	Type string `json:"type"`
+	// 端口配置
+	Ports []ServicePortDetail `json:"ports,omitempty"`
+	// 选择器
+	Selector map[string]string `json:"selector,omitempty"`
+	// 标签
+	Labels map[string]string `json:"labels,omitempty"`
+	// 就绪端点数量
+	ReadyEndpoints int `json:"readyEndpoints"`
+	// 匹配的Pod数量
+	MatchingPods int `json:"matchingPods"`
+	// 通过的检查数量
+	ChecksPassed int `json:"checksPassed"`
+	// 未通过的检查数量
+	ChecksFailed int `json:"checksFailed"`
+}
+
+// ServicePortDetail 表示Service的单个端口配置
+type ServicePortDetail struct {
+	// 端口名称
+	Name string `json:"name,omitempty"`
+	// 协议
+	Protocol string `json:"protocol"`
+	// 服务端口
+	Port int32 `json:"port"`
+	// 目标端口
+	TargetPort string `json:"targetPort"`
+	// 节点端口
+	NodePort int32 `json:"nodePort,omitempty"`
+}
+
+// ResourceDetail 表示通过 inspect resource 检查的任意资源的详细信息
+type ResourceDetail struct {
+	// 资源的 apiVersion
+	APIVersion string `json:"apiVersion"`
+	// 资源类型
+	Kind string `json:"kind"`
+	// 资源名称
+	Name string `json:"name"`
+	// 资源命名空间，集群级资源为空
+	Namespace string `json:"namespace,omitempty"`
+	// 标签
+	Labels map[string]string `json:"labels,omitempty"`
+	// 通过的检查数量
+	ChecksPassed int `json:"checksPassed"`
+	// 未通过的检查数量
+	ChecksFailed int `json:"checksFailed"`
 }
 
 // Finding 表示分析过程中发现的单个问题
@@ -143,6 +301,10 @@ type Finding struct {
 	Recommendation string `json:"recommendation,omitempty"`
 	// Details 包含关于问题的额外上下文信息
 	Details map[string]interface{} `json:"details,omitempty"`
+	// PendingSince 对于配置了持续时间的规则，表示条件首次成立的时间
+	PendingSince *time.Time `json:"pendingSince,omitempty"`
+	// Source 资源在清单文件中的位置，仅在检查资源清单时设置
+	Source *SourceLocation `json:"source,omitempty"`
 }
 
 // Report 表示完整的分析报告
@@ -155,8 +317,22 @@ type Report struct {
 	Namespace string `json:"namespace,omitempty"`
 	// NodeDetails 包含所有节点的详细信息
 	NodeDetails []NodeDetail `json:"nodeDetails,omitempty"`
+	// PodDetails 包含所有Pod的详细信息
+	PodDetails []PodDetail `json:"podDetails,omitempty"`
+	// DeploymentDetails 包含所有Deployment的详细信息
+	DeploymentDetails []DeploymentDetail `json:"deploymentDetails,omitempty"`
+	// ServiceDetails 包含所有Service的详细信息
+	ServiceDetails []ServiceDetail `json:"serviceDetails,omitempty"`
+	// ResourceDetails 包含通过 inspect resource 检查的任意资源的详细信息
+	ResourceDetails []ResourceDetail `json:"resourceDetails,omitempty"`
 	// Findings 包含所有检测到的问题
 	Findings []Finding `json:"findings"`
+	// Suppressed 包含被有效豁免抑制的发现项
+	Suppressed []SuppressedFinding `json:"suppressedFindings,omitempty"`
+	// Compliance 按合规框架控制项汇总的检查结果，只包含设置了 controls 的规则
+	Compliance []ControlResult `json:"compliance,omitempty"`
+	// Rules 生成报告时评估的规则，供 SARIF 等需要规则元数据的格式使用，不输出到JSON/YAML报告
+	Rules []RuleInfo `json:"-"`
 	// Summary 包含报告的汇总统计信息
 	Summary ReportSummary `json:"summary"`
 }
@@ -169,6 +345,35 @@ type ReportSummary struct {
 	ResourcesWithIssues int `json:"resourcesWithIssues"`
 	// FindingCounts 按严重性级别统计的问题数量
 	FindingCounts map[Severity]int `json:"findingCounts"`
+	// Suppressed 被豁免抑制的发现项数量，不计入 FindingCounts
+	Suppressed int `json:"suppressed,omitempty"`
+}
+
+// 结构化输出（JSON/YAML）的版本信息，字段发生不兼容变更时需要升级版本
+const (
+	// ReportAPIVersion 报告结构的版本
+	ReportAPIVersion = "inspector.k8s/v1"
+	// ReportKind 报告结构的类型
+	ReportKind = "InspectionReport"
+)
+
+// Document 表示带版本信息的报告文档，是JSON/YAML输出的顶层结构
+type Document struct {
+	// APIVersion 报告结构的版本
+	APIVersion string `json:"apiVersion"`
+	// Kind 报告结构的类型
+	Kind string `json:"kind"`
+	// Report 报告内容，字段平铺在文档顶层
+	*Report
+}
+
+// NewDocument 为报告创建带版本信息的文档
+func NewDocument(report *Report) *Document {
+	return &Document{
+		APIVersion: ReportAPIVersion,
+		Kind:       ReportKind,
+		Report:     report,
+	}
 }
 
 // Generator 定义报告生成器的接口
@@ -177,10 +382,32 @@ type Generator interface {
 	GenerateNodeReport(results []node.AnalysisResult, rules []rules.Rule) *Report
 	// GeneratePodReport 从Pod分析结果创建报告
 	GeneratePodReport(results []*pod.AnalysisResult, rules []rules.Rule) *Report
+	// GenerateDeploymentReport 从Deployment分析结果创建报告
+	GenerateDeploymentReport(results []*deployment.AnalysisResult, rules []rules.Rule) *Report
+	// GenerateServiceReport 从Service分析结果创建报告
+	GenerateServiceReport(results []*service.AnalysisResult, rules []rules.Rule) *Report
+	// GenerateResourceReport 从任意资源类型的分析结果创建报告
+	GenerateResourceReport(results []*generic.AnalysisResult, rules []rules.Rule) *Report
 }
 
 // Formatter 定义报告输出格式化的接口
 type Formatter interface {
 	// Format 将报告转换为字符串表示
 	Format(report *Report) string
-} 
\ No newline at end of file
+}
+
+// NewFormatter 根据输出格式名称创建对应的格式化器
+func NewFormatter(format string, colorEnabled bool) (Formatter, error) {
+	switch format {
+	case "text", "":
+		return NewTextFormatter(colorEnabled), nil
+	case "json":
+		return NewJSONFormatter(true), nil
+	case "yaml":
+		return NewYAMLFormatter(), nil
+	case "sarif":
+		return NewSARIFFormatter(), nil
+	default:
+		return nil, fmt.Errorf("不支持的输出格式: %s", format)
+	}
+}
@@ -0,0 +1,124 @@
+package report
+
+import (
+	"strings"
+	"time"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/waiver"
+)
+
+// 豁免本身产生的发现项使用的规则ID
+const (
+	// RuleWaiverExpired 匹配发现项的豁免已过期
+	RuleWaiverExpired = "waiver-expired"
+	// RuleWaiverInvalid 资源上的豁免注解无法解析
+	RuleWaiverInvalid = "waiver-invalid"
+)
+
+// SuppressedFinding 表示被豁免的发现项
+type SuppressedFinding struct {
+	Finding
+	// Waiver 抑制该发现项的豁免
+	Waiver waiver.Waiver `json:"waiver"`
+}
+
+// ApplyWaivers 将有效豁免匹配的发现项移到 Suppressed 部分；
+// 只匹配到已过期豁免的发现项保留，并为过期豁免追加一条发现项，无效的豁免注解同样报告为发现项
+func ApplyWaivers(r *Report, waivers *waiver.Set, now time.Time) {
+	if waivers == nil {
+		return
+	}
+
+	resourcesBefore := resourcesWithFindings(r.Findings)
+	findings := make([]Finding, 0, len(r.Findings))
+	var expired []Finding
+	reportedExpired := make(map[string]bool)
+
+	for _, finding := range r.Findings {
+		resource := findingResource(finding)
+		w, active := waivers.Lookup(finding.RuleID, finding.ResourceKind, resource, now)
+		if w == nil {
+			findings = append(findings, finding)
+			continue
+		}
+		if active {
+			r.Suppressed = append(r.Suppressed, SuppressedFinding{Finding: finding, Waiver: *w})
+			r.Summary.FindingCounts[finding.Severity]--
+			r.Summary.Suppressed++
+			r.waiveCompliance(finding.RuleID)
+			continue
+		}
+
+		findings = append(findings, finding)
+		key := finding.ResourceKind + "|" + finding.ResourceName + "|" + finding.RuleID
+		if reportedExpired[key] {
+			continue
+		}
+		reportedExpired[key] = true
+		expired = append(expired, Finding{
+			ResourceName:   finding.ResourceName,
+			ResourceKind:   finding.ResourceKind,
+			RuleID:         RuleWaiverExpired,
+			Message:        i18n.Sprintf("规则 %s 的豁免已于 %s 过期 (负责人: %s, 原因: %s)", w.RuleID, w.Expires, w.Owner, w.Reason),
+			Severity:       SeverityWarning,
+			Recommendation: i18n.T("修复被豁免的问题，或由负责人确认后延长豁免到期日期"),
+			Details: map[string]interface{}{
+				"waived_rule": w.RuleID,
+				"owner":       w.Owner,
+				"expires":     w.Expires,
+				"source":      w.Source,
+			},
+			Source: finding.Source,
+		})
+	}
+	r.Findings = findings
+
+	for _, finding := range expired {
+		r.addFinding(finding)
+	}
+	for _, invalid := range waivers.Invalid() {
+		r.addFinding(Finding{
+			ResourceName:   invalid.Resource,
+			ResourceKind:   invalid.Kind,
+			RuleID:         RuleWaiverInvalid,
+			Message:        i18n.Sprintf("豁免注解 %s 无效: %v", invalid.Annotation, invalid.Err),
+			Severity:       SeverityWarning,
+			Recommendation: i18n.T(`注解值应包含 reason、owner 和 expires，如 {"reason": "...", "owner": "...", "expires": "2026-12-31"}`),
+		})
+	}
+
+	// 发现项全部被豁免的资源不再计入有问题的资源
+	resourcesAfter := resourcesWithFindings(r.Findings)
+	for resource := range resourcesBefore {
+		if !resourcesAfter[resource] {
+			r.Summary.ResourcesWithIssues--
+		}
+	}
+	for resource := range resourcesAfter {
+		if !resourcesBefore[resource] {
+			r.Summary.ResourcesWithIssues++
+		}
+	}
+}
+
+// findingResource 返回发现项对应资源的豁免标识，命名空间资源为 namespace/name，集群级资源为 name
+func findingResource(finding Finding) string {
+	name := finding.ResourceName
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if namespace, ok := finding.Details["namespace"].(string); ok && namespace != "" {
+		return namespace + "/" + name
+	}
+	return name
+}
+
+// resourcesWithFindings 返回存在发现项的资源集合
+func resourcesWithFindings(findings []Finding) map[string]bool {
+	resources := make(map[string]bool)
+	for _, finding := range findings {
+		resources[finding.ResourceKind+"/"+finding.ResourceName] = true
+	}
+	return resources
+}
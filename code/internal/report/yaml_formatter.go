@@ -0,0 +1,26 @@
+package report
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+)
+
+// YAMLFormatter 实现了用于YAML输出的Formatter接口
+// 使用与JSON相同的字段名（json标签），保证两种格式的结构一致
+type YAMLFormatter struct{}
+
+// NewYAMLFormatter 创建一个新的YAML格式化器
+func NewYAMLFormatter() Formatter {
+	return &YAMLFormatter{}
+}
+
+// Format 将报告转换为带版本信息的YAML文档
+func (f *YAMLFormatter) Format(report *Report) string {
+	data, err := yaml.Marshal(NewDocument(report))
+	if err != nil {
+		// 序列化失败时仍输出合法的YAML，便于下游程序识别
+		return fmt.Sprintf("apiVersion: %s\nkind: %s\nerror: %q\n", ReportAPIVersion, ReportKind, err.Error())
+	}
+	return string(data)
+}
@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"sort"
+	"sync"
+)
+
+// GenericKind 通用指标的资源类型；没有专用分析器的资源（如CRD）通过 inspect resource 检查，只能使用这些指标
+const GenericKind = "*"
+
+// MetricSpec 描述分析器产生的一个指标
+type MetricSpec struct {
+	// 指标名称，对应规则条件中的 metric
+	Name string
+	// 资源类型，对应规则的 category（node、pod、deployment、service），通用指标为 GenericKind
+	Kind string
+	// 值类型，即评估时使用的验证器类型（numeric、string、boolean、map、quantity）
+	Type string
+	// 可用的操作符，未指定时使用值类型支持的全部操作符
+	Operators []string
+	// 指标说明
+	Description string
+	// 指标是否依赖集群运行时数据（如资源使用量、重启次数、端点），静态清单检查时跳过使用这些指标的规则
+	Runtime bool
+}
+
+// SupportsOperator 判断指标是否支持指定操作符
+func (m MetricSpec) SupportsOperator(operator string) bool {
+	return containsString(m.Operators, operator)
+}
+
+// typeOperators 各值类型对应验证器支持的操作符
+var typeOperators = map[string][]string{
+	"numeric":  {">", ">=", "<", "<=", "==", "!=", "in", "not_in", "between"},
+	"quantity": {">", ">=", "<", "<=", "==", "!=", "in", "not_in", "between"},
+	"string":   {"==", "!=", "contains", "matches", "in", "not_in", "starts_with", "ends_with", "version_lt", "version_gte"},
+	"boolean":  {"==", "!="},
+	"map":      {"==", "contains", "has_non_empty", "in", "not_in", "starts_with", "ends_with"},
+}
+
+// TypeOperators 返回值类型支持的操作符
+func TypeOperators(valueType string) []string {
+	return append([]string(nil), typeOperators[valueType]...)
+}
+
+var (
+	catalogMu sync.RWMutex
+	// catalog 资源类型 -> 指标名称 -> 指标描述
+	catalog = make(map[string]map[string]MetricSpec)
+	// compoundKinds 分析器支持 all/any/not 组合条件的资源类型
+	compoundKinds = make(map[string]bool)
+)
+
+// RegisterCompoundKind 声明资源类型的分析器支持组合条件
+func RegisterCompoundKind(kind string) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	compoundKinds[kind] = true
+}
+
+// SupportsCompound 判断资源类型的分析器是否支持组合条件
+func SupportsCompound(kind string) bool {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	return compoundKinds[kind]
+}
+
+// RegisterMetrics 注册分析器产生的指标，通常在分析器包的 init 中调用；
+// 同一资源类型下重复注册的指标以后注册的为准
+func RegisterMetrics(specs ...MetricSpec) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+
+	for _, spec := range specs {
+		if len(spec.Operators) == 0 {
+			spec.Operators = TypeOperators(spec.Type)
+		}
+		if catalog[spec.Kind] == nil {
+			catalog[spec.Kind] = make(map[string]MetricSpec)
+		}
+		catalog[spec.Kind][spec.Name] = spec
+	}
+}
+
+// LookupMetric 查找指定资源类型的指标
+func LookupMetric(kind, name string) (MetricSpec, bool) {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	spec, ok := catalog[kind][name]
+	return spec, ok
+}
+
+// CatalogKinds 返回已注册指标的资源类型，按名称排序
+func CatalogKinds() []string {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	kinds := make([]string, 0, len(catalog))
+	for kind := range catalog {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// CatalogMetrics 返回指定资源类型的全部指标，按名称排序
+func CatalogMetrics(kind string) []MetricSpec {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	specs := make([]MetricSpec, 0, len(catalog[kind]))
+	for _, spec := range catalog[kind] {
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+	return specs
+}
+
+// RequiresRuntime 判断规则条件是否需要集群运行时数据：条件中任一指标依赖运行时数据，或条件需要持续一段时间才成立。
+// 资源类型没有专用分析器时按通用指标判断
+func RequiresRuntime(condition RuleCondition, kind string) bool {
+	if condition.Duration != nil {
+		return true
+	}
+	for _, child := range condition.All {
+		if RequiresRuntime(child, kind) {
+			return true
+		}
+	}
+	for _, child := range condition.Any {
+		if RequiresRuntime(child, kind) {
+			return true
+		}
+	}
+	if condition.Not != nil && RequiresRuntime(*condition.Not, kind) {
+		return true
+	}
+	if condition.IsCompound() {
+		return false
+	}
+	spec, ok := LookupMetric(kind, condition.Metric)
+	if !ok {
+		spec, _ = LookupMetric(GenericKind, condition.Metric)
+	}
+	return spec.Runtime
+}
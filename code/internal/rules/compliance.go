@@ -0,0 +1,63 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HasAnyTag 判断规则是否包含任一指定标签，不区分大小写
+func (r Rule) HasAnyTag(tags []string) bool {
+	for _, tag := range tags {
+		for _, ruleTag := range r.Tags {
+			if strings.EqualFold(ruleTag, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MatchesAnyControl 判断规则是否对应任一控制项选择器；
+// 选择器为 framework 时匹配该框架下的全部控制项，为 framework:id 时匹配单个控制项，均不区分大小写
+func (r Rule) MatchesAnyControl(selectors []string) bool {
+	for _, selector := range selectors {
+		framework, id, hasID := strings.Cut(selector, ":")
+		for _, control := range r.Controls {
+			if !strings.EqualFold(control.Framework, framework) {
+				continue
+			}
+			if !hasID || strings.EqualFold(control.ID, id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateControls 验证控制项的框架和编号，同一规则中的控制项不能重复
+func validateControls(controls []Control) error {
+	seen := make(map[string]bool, len(controls))
+	for i, control := range controls {
+		if control.Framework == "" || control.ID == "" {
+			return fmt.Errorf("第 %d 项缺少 framework 或 id", i+1)
+		}
+		if strings.Contains(control.Framework, ":") {
+			return fmt.Errorf("框架标识 %q 不能包含冒号", control.Framework)
+		}
+		key := strings.ToLower(control.String())
+		if seen[key] {
+			return fmt.Errorf("控制项重复: %s", control)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// SelectRules 限定引擎只使用包含任一标签或对应任一控制项的规则，用于只运行某个合规框架的检查；
+// 过滤条件自身设置了标签或控制项时以过滤条件为准，传入空列表表示不限定
+func (e *Engine) SelectRules(tags, controls []string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.selectTags = tags
+	e.selectControls = controls
+}
@@ -0,0 +1,177 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+)
+
+// evaluateCompoundRule 评估组合条件规则，指标值由source按需提供
+func (e *Engine) evaluateCompoundRule(rule Rule, source MetricSource) (*RuleResult, error) {
+	matched, decisive, err := e.evaluateCondition(rule.Condition, "", source)
+	if err != nil {
+		return nil, fmt.Errorf("验证失败: %w", err)
+	}
+
+	// 实际值按指标汇总决定结果的叶子条件
+	actual := make(map[string]interface{}, len(decisive))
+	for _, c := range decisive {
+		actual[c.Metric] = c.ActualValue
+	}
+
+	return &RuleResult{
+		RuleID:        rule.ID,
+		RuleName:      rule.Name,
+		Passed:        matched,
+		ActualValue:   actual,
+		ExpectedValue: DescribeCondition(rule.Condition),
+		Message:       e.formatCompoundMessage(rule, matched, decisive),
+		Conditions:    decisive,
+		Remediation:   rule.Remediation,
+		Severity:      rule.Severity,
+		EvaluatedAt:   time.Now(),
+	}, nil
+}
+
+// evaluateCondition 递归评估条件树，返回是否成立以及决定该结果的叶子条件
+func (e *Engine) evaluateCondition(condition RuleCondition, path string, source MetricSource) (bool, []ConditionResult, error) {
+	switch {
+	case len(condition.All) > 0:
+		var passed, failed []ConditionResult
+		for i, sub := range condition.All {
+			ok, decisive, err := e.evaluateCondition(sub, joinConditionPath(path, fmt.Sprintf("all[%d]", i)), source)
+			if err != nil {
+				return false, nil, err
+			}
+			if ok {
+				passed = append(passed, decisive...)
+			} else {
+				failed = append(failed, decisive...)
+			}
+		}
+		if len(failed) > 0 {
+			return false, failed, nil
+		}
+		return true, passed, nil
+
+	case len(condition.Any) > 0:
+		var passed, failed []ConditionResult
+		for i, sub := range condition.Any {
+			ok, decisive, err := e.evaluateCondition(sub, joinConditionPath(path, fmt.Sprintf("any[%d]", i)), source)
+			if err != nil {
+				return false, nil, err
+			}
+			if ok {
+				passed = append(passed, decisive...)
+			} else {
+				failed = append(failed, decisive...)
+			}
+		}
+		if len(passed) > 0 {
+			return true, passed, nil
+		}
+		return false, failed, nil
+
+	case condition.Not != nil:
+		ok, decisive, err := e.evaluateCondition(*condition.Not, joinConditionPath(path, "not"), source)
+		if err != nil {
+			return false, nil, err
+		}
+		return !ok, decisive, nil
+	}
+
+	// 叶子条件
+	var err error
+	actualValue, metricType, ok := source(condition.Metric)
+	if !ok {
+		return false, nil, fmt.Errorf("未知指标: %s", condition.Metric)
+	}
+	var matched bool
+	if condition.Metric == JSONPathMetric {
+		var values []interface{}
+		matched, values, err = e.evaluateJSONPath(condition, actualValue)
+		if err != nil {
+			return false, nil, fmt.Errorf("条件 %s: %w", path, err)
+		}
+		actualValue = formatJSONPathValues(values)
+	} else {
+		validator, err := e.GetValidator(metricType)
+		if err != nil {
+			return false, nil, err
+		}
+		matched, err = validator.Validate(condition.Metric, actualValue, condition, e.thresholdEnv(condition))
+		if err != nil {
+			return false, nil, fmt.Errorf("条件 %s: %w", path, err)
+		}
+	}
+
+	metric := condition.Metric
+	if metric == JSONPathMetric {
+		metric = condition.Path
+	}
+	return matched, []ConditionResult{{
+		Path:          path,
+		Metric:        metric,
+		Operator:      condition.Operator,
+		ActualValue:   actualValue,
+		ExpectedValue: e.threshold(condition),
+		Matched:       matched,
+	}}, nil
+}
+
+// formatCompoundMessage 格式化组合条件的结果消息，列出决定结果的子条件
+func (e *Engine) formatCompoundMessage(rule Rule, passed bool, decisive []ConditionResult) string {
+	parts := make([]string, 0, len(decisive))
+	for _, c := range decisive {
+		state := i18n.T("不成立")
+		if c.Matched {
+			state = i18n.T("成立")
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s=%v (%s %v %s)", c.Path, c.Metric, c.ActualValue, c.Operator, c.ExpectedValue, state))
+	}
+
+	if passed {
+		return i18n.Sprintf("%s: 检查通过 (%s)", rule.Name, strings.Join(parts, "; "))
+	}
+	return i18n.Sprintf("%s: 检查失败, 触发子条件 %s", rule.Name, strings.Join(parts, "; "))
+}
+
+// DescribeCondition 返回条件树的简短文本描述，如 all(replicas < 2, namespace == prod)
+func DescribeCondition(condition RuleCondition) string {
+	describeList := func(name string, subs []RuleCondition) string {
+		parts := make([]string, 0, len(subs))
+		for _, sub := range subs {
+			parts = append(parts, DescribeCondition(sub))
+		}
+		return fmt.Sprintf("%s(%s)", name, strings.Join(parts, ", "))
+	}
+
+	switch {
+	case len(condition.All) > 0:
+		return describeList("all", condition.All)
+	case len(condition.Any) > 0:
+		return describeList("any", condition.Any)
+	case condition.Not != nil:
+		return fmt.Sprintf("not(%s)", DescribeCondition(*condition.Not))
+	}
+
+	threshold := condition.Threshold
+	if threshold == nil && len(condition.Thresholds) > 0 {
+		threshold = condition.Thresholds
+	}
+	metric := condition.Metric
+	if metric == JSONPathMetric {
+		metric = condition.Path
+	}
+	return fmt.Sprintf("%s %s %v", metric, condition.Operator, threshold)
+}
+
+// joinConditionPath 拼接条件路径
+func joinConditionPath(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	return parent + "." + child
+}
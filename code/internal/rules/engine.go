@@ -2,10 +2,17 @@ package rules
 
 import (
 	"fmt"
+	"io/fs"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
 )
 
 // Engine 规则引擎
@@ -16,13 +23,32 @@ type Engine struct {
 	validators map[string]Validator
 	// 当前环境
 	environment string
+	// 持续条件状态
+	state *StateStore
+	// 命名空间标签，用于规则作用范围中的命名空间标签选择器
+	namespaceLabels map[string]map[string]string
+	// 限定使用的规则标签和控制项，由 SelectRules 设置
+	selectTags     []string
+	selectControls []string
+	// 是否只使用不依赖集群运行时数据的规则，由 SetStaticOnly 设置
+	staticOnly bool
+	// 保证一次分析中读取的规则和引擎设置不被重载或设置方法修改：
+	// Pass 持有读锁，Reload 和 Set 系列方法持有写锁
+	mu sync.RWMutex
 }
 
-// NewEngine 创建规则引擎
-func NewEngine(rulesFile string) (*Engine, error) {
-	// 创建规则加载器
-	loader := NewRuleLoader(rulesFile)
-	
+// NewEngine 创建规则引擎，可传入多个规则文件或目录，按顺序合并
+func NewEngine(rulesFiles ...string) (*Engine, error) {
+	return newEngine(NewRuleLoader(rulesFiles...))
+}
+
+// NewEngineFromFS 从指定文件系统（如内置规则包）创建规则引擎
+func NewEngineFromFS(fsys fs.FS, rulesFiles ...string) (*Engine, error) {
+	return newEngine(NewRuleLoaderFromFS(fsys, rulesFiles...))
+}
+
+// newEngine 使用规则加载器创建规则引擎
+func newEngine(loader *RuleLoader) (*Engine, error) {
 	// 加载规则
 	if err := loader.LoadRules(); err != nil {
 		return nil, err
@@ -33,6 +59,7 @@ func NewEngine(rulesFile string) (*Engine, error) {
 		loader:      loader,
 		validators:  make(map[string]Validator),
 		environment: "prod", // 默认环境
+		state:       NewMemoryStateStore(),
 	}
 
 	// 注册默认验证器
@@ -43,6 +70,8 @@ func NewEngine(rulesFile string) (*Engine, error) {
 
 // SetEnvironment 设置当前环境
 func (e *Engine) SetEnvironment(env string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	e.environment = env
 }
 
@@ -51,6 +80,106 @@ func (e *Engine) GetEnvironment() string {
 	return e.environment
 }
 
+// Scoring 返回规则配置中的健康评分模型，未配置时返回nil（使用默认扣分）
+func (e *Engine) Scoring() *ScoringConfig {
+	if config := e.loader.GetRulesConfig(); config != nil {
+		return config.Scoring
+	}
+	return nil
+}
+
+// SetStateStore 设置持续条件状态存储
+func (e *Engine) SetStateStore(store *StateStore) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.state = store
+}
+
+// SetNamespaceLabels 设置各命名空间的标签，供规则作用范围中的 namespaceSelector 使用
+func (e *Engine) SetNamespaceLabels(namespaceLabels map[string]map[string]string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.namespaceLabels = namespaceLabels
+}
+
+// UsesNamespaceSelectors 判断是否有规则的作用范围使用了命名空间标签选择器，
+// 为true时调用方需要通过 SetNamespaceLabels 提供命名空间标签
+func (e *Engine) UsesNamespaceSelectors() bool {
+	for _, rule := range e.loader.GetRules(RuleFilter{}) {
+		if rule.usesNamespaceSelector() {
+			return true
+		}
+	}
+	return false
+}
+
+// SaveState 持久化持续条件状态，本次巡检评估过的规则在未检查到的资源上的状态会被删除
+func (e *Engine) SaveState() error {
+	return e.state.Save()
+}
+
+// TrackConditions 更新资源上配置了 duration 的规则状态
+// failing 为该资源本轮检查未通过的规则ID集合，本轮通过的规则状态会被清除；
+// 返回当前未通过且配置了 duration 的规则状态，Pending=true 表示尚未持续足够时间，不应报告为失败
+func (e *Engine) TrackConditions(resource string, failing map[string]bool) map[string]ConditionState {
+	now := time.Now()
+	states := make(map[string]ConditionState)
+	for _, rule := range e.loader.GetRules(RuleFilter{}) {
+		if rule.Condition.Duration == nil {
+			continue
+		}
+		key := rule.ID + "|" + resource
+		e.state.track(rule.ID, key)
+		if !failing[rule.ID] {
+			e.state.clear(key)
+			continue
+		}
+		since := e.state.observe(key, now)
+		states[rule.ID] = ConditionState{
+			Since:   since,
+			Pending: now.Sub(since) < *rule.Condition.Duration,
+		}
+	}
+	return states
+}
+
+// ConditionItem 分析项中与持续条件相关的字段，由各分析器以指针形式提供给 ApplyConditionDurations 修改
+type ConditionItem struct {
+	// 规则ID
+	RuleID string
+	// 分析项是否通过
+	Passed *bool
+	// 分析项描述
+	Description *string
+	// 配置了持续时间的规则条件首次成立的时间
+	PendingSince **time.Time
+}
+
+// ApplyConditionDurations 处理配置了 duration 的规则：按 TrackConditions 更新资源上的条件状态，
+// 为未通过的分析项记录条件首次成立的时间，条件持续时间未达到要求的失败项暂不报告
+func (e *Engine) ApplyConditionDurations(resource string, items []ConditionItem) {
+	failing := make(map[string]bool)
+	for _, item := range items {
+		if !*item.Passed {
+			failing[item.RuleID] = true
+		}
+	}
+
+	states := e.TrackConditions(resource, failing)
+	for _, item := range items {
+		state, ok := states[item.RuleID]
+		if !ok || *item.Passed {
+			continue
+		}
+		since := state.Since
+		*item.PendingSince = &since
+		if state.Pending {
+			*item.Passed = true
+			*item.Description = i18n.Sprintf("%s (条件自 %s 起成立，尚未达到规则要求的持续时间)", *item.Description, since.Format("2006-01-02 15:04:05"))
+		}
+	}
+}
+
 // DetermineEnvironment 根据集群名称确定环境
 func (e *Engine) DetermineEnvironment(clusterName string) string {
 	return e.loader.GetEnvironment(clusterName)
@@ -65,6 +194,8 @@ func (e *Engine) registerDefaultValidators() {
 	// 注册布尔验证器
 	e.RegisterValidator("boolean", &BooleanValidator{})
 	e.RegisterValidator("map", &MapValidator{}) // 新增
+	// 注册资源数量验证器
+	e.RegisterValidator("quantity", &QuantityValidator{})
 }
 
 // RegisterValidator 注册验证器
@@ -83,16 +214,59 @@ func (e *Engine) GetValidator(metricType string) (Validator, error) {
 
 // GetRules 获取规则
 func (e *Engine) GetRules(filter RuleFilter) []Rule {
-	return e.loader.GetRules(filter)
+	// 补充资源所在命名空间的标签
+	if filter.Resource != nil && filter.Resource.NamespaceLabels == nil && filter.Resource.Namespace != "" {
+		res := *filter.Resource
+		res.NamespaceLabels = e.namespaceLabels[res.Namespace]
+		filter.Resource = &res
+	}
+	if len(filter.Tags) == 0 && len(filter.Controls) == 0 {
+		filter.Tags = e.selectTags
+		filter.Controls = e.selectControls
+	}
+	// 规则名称和修复建议使用当前语言
+	rules := e.loader.GetRules(filter)
+	lang := string(i18n.Current())
+	selected := rules[:0]
+	for _, rule := range rules {
+		if e.staticOnly && RequiresRuntime(rule.Condition, rule.Category) {
+			continue
+		}
+		selected = append(selected, rule.Localize(lang))
+	}
+	return selected
+}
+
+// SetStaticOnly 设置是否只使用不依赖集群运行时数据的规则，用于检查尚未部署的资源清单
+func (e *Engine) SetStaticOnly(staticOnly bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.staticOnly = staticOnly
 }
 
 // EvaluateRule 评估单个规则
+// 对于 all/any/not 组合条件，actualValue 需为 MetricSource，由引擎按需获取各子条件的指标值，
+// 此时 metricType 被忽略
 func (e *Engine) EvaluateRule(rule Rule, metricType string, actualValue interface{}) (*RuleResult, error) {
 	// 检查规则是否启用
 	if !rule.Enabled {
 		return nil, fmt.Errorf("规则未启用: %s", rule.Name)
 	}
 
+	// 组合条件
+	if rule.Condition.IsCompound() {
+		source, ok := actualValue.(MetricSource)
+		if !ok {
+			return nil, fmt.Errorf("规则 '%s' 为组合条件，实际值需为 MetricSource，实际类型: %T", rule.ID, actualValue)
+		}
+		return e.evaluateCompoundRule(rule, source)
+	}
+
+	// 通用 jsonpath 指标，actualValue 为原始对象
+	if rule.Condition.Metric == JSONPathMetric {
+		return e.evaluateJSONPathRule(rule, actualValue)
+	}
+
 	// 获取验证器
 	validator, err := e.GetValidator(metricType)
 	if err != nil {
@@ -100,10 +274,10 @@ func (e *Engine) EvaluateRule(rule Rule, metricType string, actualValue interfac
 	}
 
 	// 获取阈值
-	threshold := e.getThresholdValue(rule.Condition, e.environment)
+	threshold := e.threshold(rule.Condition)
 	
 	// 验证值
-	passed, err := validator.Validate(rule.Condition.Metric, actualValue, rule.Condition, e.environment)
+	passed, err := validator.Validate(rule.Condition.Metric, actualValue, rule.Condition, e.thresholdEnv(rule.Condition))
 	if err != nil {
 		return nil, fmt.Errorf("验证失败: %w", err)
 	}
@@ -124,47 +298,55 @@ func (e *Engine) EvaluateRule(rule Rule, metricType string, actualValue interfac
 	return result, nil
 }
 
-// getThresholdValue 获取适用于当前环境的阈值
-func (e *Engine) getThresholdValue(condition RuleCondition, env string) interface{} {
-	// 先尝试从环境特定阈值中获取
-	if condition.Thresholds != nil {
-		if threshold, exists := condition.Thresholds[env]; exists {
-			return threshold
-		}
-		
-		// 尝试获取默认环境的阈值
-		if threshold, exists := condition.Thresholds["default"]; exists {
-			return threshold
-		}
+// evaluateJSONPathRule 评估 jsonpath 指标规则，实际值为路径取到的全部值
+func (e *Engine) evaluateJSONPathRule(rule Rule, object interface{}) (*RuleResult, error) {
+	passed, values, err := e.evaluateJSONPath(rule.Condition, object)
+	if err != nil {
+		return nil, fmt.Errorf("验证失败: %w", err)
 	}
-	
-	// 返回通用阈值
-	return condition.Threshold
+
+	threshold := e.threshold(rule.Condition)
+	return &RuleResult{
+		RuleID:        rule.ID,
+		RuleName:      rule.Name,
+		Passed:        passed,
+		ActualValue:   formatJSONPathValues(values),
+		ExpectedValue: threshold,
+		Message:       e.formatResultMessage(rule, passed, formatJSONPathValues(values), fmt.Sprintf("%v", threshold)),
+		Remediation:   rule.Remediation,
+		Severity:      rule.Severity,
+		EvaluatedAt:   time.Now(),
+	}, nil
+}
+
+// threshold 返回条件在当前环境下使用的阈值，阈值环境按环境继承链确定
+func (e *Engine) threshold(condition RuleCondition) interface{} {
+	return condition.ThresholdFor(e.thresholdEnv(condition))
 }
 
 // formatResultMessage 格式化结果消息
 func (e *Engine) formatResultMessage(rule Rule, passed bool, formattedValue string, formattedThreshold string) string {
 	if passed {
-		return fmt.Sprintf("%s: 检查通过 (值: %s)", rule.Name, formattedValue)
+		return i18n.Sprintf("%s: 检查通过 (值: %s)", rule.Name, formattedValue)
 	}
 	
 	// 根据操作符生成不同的消息
 	var expectation string
 	switch rule.Condition.Operator {
 	case ">":
-		expectation = fmt.Sprintf("应大于 %s", formattedThreshold)
+		expectation = i18n.Sprintf("应大于 %s", formattedThreshold)
 	case ">=":
-		expectation = fmt.Sprintf("应大于等于 %s", formattedThreshold)
+		expectation = i18n.Sprintf("应大于等于 %s", formattedThreshold)
 	case "<":
-		expectation = fmt.Sprintf("应小于 %s", formattedThreshold)
+		expectation = i18n.Sprintf("应小于 %s", formattedThreshold)
 	case "<=":
-		expectation = fmt.Sprintf("应小于等于 %s", formattedThreshold)
+		expectation = i18n.Sprintf("应小于等于 %s", formattedThreshold)
 	case "==":
-		expectation = fmt.Sprintf("应等于 %s", formattedThreshold)
+		expectation = i18n.Sprintf("应等于 %s", formattedThreshold)
 	case "!=":
-		expectation = fmt.Sprintf("不应等于 %s", formattedThreshold)
+		expectation = i18n.Sprintf("不应等于 %s", formattedThreshold)
 	case "contains":
-		expectation = fmt.Sprintf("应包含 %s", formattedThreshold)
+		expectation = i18n.Sprintf("应包含 %s", formattedThreshold)
 	case "has_non_empty":
 		// 对于has_non_empty操作符，提供更具体的错误信息
 		if threshold, ok := rule.Condition.Threshold.(map[string]interface{}); ok {
@@ -173,20 +355,34 @@ func (e *Engine) formatResultMessage(rule Rule, passed bool, formattedValue stri
 				missingKeys = append(missingKeys, key)
 			}
 			if len(missingKeys) == 1 {
-				expectation = fmt.Sprintf("应包含标签 '%s' 且值不为空", missingKeys[0])
+				expectation = i18n.Sprintf("应包含标签 '%s' 且值不为空", missingKeys[0])
 			} else {
-				expectation = fmt.Sprintf("应包含标签 %v 且值不为空", missingKeys)
+				expectation = i18n.Sprintf("应包含标签 %v 且值不为空", missingKeys)
 			}
 		} else {
-			expectation = "应包含指定标签且值不为空"
+			expectation = i18n.T("应包含指定标签且值不为空")
 		}
 	case "matches":
-		expectation = fmt.Sprintf("应匹配正则表达式 %s", formattedThreshold)
+		expectation = i18n.Sprintf("应匹配正则表达式 %s", formattedThreshold)
+	case "in":
+		expectation = i18n.Sprintf("应为 %s 之一", formattedThreshold)
+	case "not_in":
+		expectation = i18n.Sprintf("不应为 %s 之一", formattedThreshold)
+	case "between":
+		expectation = i18n.Sprintf("应在区间 %s 内", formattedThreshold)
+	case "starts_with":
+		expectation = i18n.Sprintf("应以 %s 开头", formattedThreshold)
+	case "ends_with":
+		expectation = i18n.Sprintf("应以 %s 结尾", formattedThreshold)
+	case "version_lt":
+		expectation = i18n.Sprintf("版本应低于 %s", formattedThreshold)
+	case "version_gte":
+		expectation = i18n.Sprintf("版本应不低于 %s", formattedThreshold)
 	default:
-		expectation = fmt.Sprintf("不满足条件 %s %s", rule.Condition.Operator, formattedThreshold)
+		expectation = i18n.Sprintf("不满足条件 %s %s", rule.Condition.Operator, formattedThreshold)
 	}
 
-	return fmt.Sprintf("%s: 检查失败, 值 %s %s", rule.Name, formattedValue, expectation)
+	return i18n.Sprintf("%s: 检查失败, 值 %s %s", rule.Name, formattedValue, expectation)
 }
 
 // NumericValidator 数值验证器
@@ -201,21 +397,18 @@ func (v *NumericValidator) Validate(metric string, actualValue interface{}, cond
 	}
 
 	// 获取适用的阈值
-	var thresholdValue interface{}
-	if len(condition.Thresholds) > 0 {
-		// 尝试获取环境特定阈值
-		if val, exists := condition.Thresholds[env]; exists {
-			thresholdValue = val
-		} else if val, exists := condition.Thresholds["default"]; exists {
-			// 尝试获取默认环境阈值
-			thresholdValue = val
-		} else {
-			// 使用通用阈值
-			thresholdValue = condition.Threshold
-		}
-	} else {
-		// 使用通用阈值
-		thresholdValue = condition.Threshold
+	thresholdValue := condition.ThresholdFor(env)
+
+	// 集合与区间操作符的阈值为列表
+	switch condition.Operator {
+	case "in", "not_in", "between":
+		return validateOrderedList(condition.Operator, thresholdValue, func(item interface{}) (int, error) {
+			f, err := toFloat64(item)
+			if err != nil {
+				return 0, err
+			}
+			return compareFloat(actualFloat, f), nil
+		})
 	}
 
 	// 将阈值转换为float64
@@ -259,6 +452,17 @@ type StringValidator struct{}
 
 // Validate 验证字符串
 func (v *StringValidator) Validate(metric string, actualValue interface{}, condition RuleCondition, env string) (bool, error) {
+	// 多值指标（如所有容器的镜像仓库）要求每个值都满足条件
+	if values, ok := actualValue.([]string); ok {
+		for _, value := range values {
+			passed, err := v.Validate(metric, value, condition, env)
+			if err != nil || !passed {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
 	// 将实际值转换为字符串
 	actualStr, ok := toString(actualValue)
 	if !ok {
@@ -266,21 +470,12 @@ func (v *StringValidator) Validate(metric string, actualValue interface{}, condi
 	}
 
 	// 获取适用的阈值
-	var thresholdValue interface{}
-	if len(condition.Thresholds) > 0 {
-		// 尝试获取环境特定阈值
-		if val, exists := condition.Thresholds[env]; exists {
-			thresholdValue = val
-		} else if val, exists := condition.Thresholds["default"]; exists {
-			// 尝试获取默认环境阈值
-			thresholdValue = val
-		} else {
-			// 使用通用阈值
-			thresholdValue = condition.Threshold
-		}
-	} else {
-		// 使用通用阈值
-		thresholdValue = condition.Threshold
+	thresholdValue := condition.ThresholdFor(env)
+
+	// 集合操作符的阈值为列表
+	switch condition.Operator {
+	case "in", "not_in":
+		return validateStringList(actualStr, condition.Operator, thresholdValue)
 	}
 
 	// 将阈值转换为字符串
@@ -304,6 +499,19 @@ func (v *StringValidator) Validate(metric string, actualValue interface{}, condi
 			return false, fmt.Errorf("正则表达式匹配失败: %v", err)
 		}
 		return matched, nil
+	case "starts_with":
+		return strings.HasPrefix(actualStr, thresholdStr), nil
+	case "ends_with":
+		return strings.HasSuffix(actualStr, thresholdStr), nil
+	case "version_lt", "version_gte":
+		cmp, err := compareVersions(actualStr, thresholdStr)
+		if err != nil {
+			return false, err
+		}
+		if condition.Operator == "version_lt" {
+			return cmp < 0, nil
+		}
+		return cmp >= 0, nil
 	default:
 		return false, fmt.Errorf("字符串类型不支持的操作符: %s", condition.Operator)
 	}
@@ -329,22 +537,7 @@ func (v *BooleanValidator) Validate(metric string, actualValue interface{}, cond
 	}
 
 	// 获取适用的阈值
-	var thresholdValue interface{}
-	if len(condition.Thresholds) > 0 {
-		// 尝试获取环境特定阈值
-		if val, exists := condition.Thresholds[env]; exists {
-			thresholdValue = val
-		} else if val, exists := condition.Thresholds["default"]; exists {
-			// 尝试获取默认环境阈值
-			thresholdValue = val
-		} else {
-			// 使用通用阈值
-			thresholdValue = condition.Threshold
-		}
-	} else {
-		// 使用通用阈值
-		thresholdValue = condition.Threshold
-	}
+	thresholdValue := condition.ThresholdFor(env)
 
 	// 将阈值转换为布尔值
 	thresholdBool, ok := toBool(thresholdValue)
@@ -384,18 +577,13 @@ func (v *MapValidator) Validate(metric string, actualValue interface{}, conditio
 		return false, fmt.Errorf("actualValue类型断言失败，期望map[string]string，实际类型：%T", actualValue)
 	}
 
-	// 获取适用的阈值（与其他验证器保持一致）
-	var thresholdValue interface{}
-	if len(condition.Thresholds) > 0 {
-		if val, exists := condition.Thresholds[env]; exists {
-			thresholdValue = val
-		} else if val, exists := condition.Thresholds["default"]; exists {
-			thresholdValue = val
-		} else {
-			thresholdValue = condition.Threshold
-		}
-	} else {
-		thresholdValue = condition.Threshold
+	// 获取适用的阈值
+	thresholdValue := condition.ThresholdFor(env)
+
+	// in/not_in 的阈值为 键 -> 允许(或禁止)的值列表
+	switch condition.Operator {
+	case "in", "not_in":
+		return v.validateList(actual, condition.Operator, thresholdValue)
 	}
 
 	// 转换threshold为map[string]string
@@ -412,6 +600,10 @@ func (v *MapValidator) Validate(metric string, actualValue interface{}, conditio
 		return v.validateContains(actual, expected), nil
 	case "has_non_empty":
 		return v.validateHasNonEmpty(actual, expected), nil
+	case "starts_with":
+		return v.validateEach(actual, expected, strings.HasPrefix), nil
+	case "ends_with":
+		return v.validateEach(actual, expected, strings.HasSuffix), nil
 	default:
 		return false, fmt.Errorf("map类型不支持的操作符: %s", condition.Operator)
 	}
@@ -452,6 +644,69 @@ func (v *MapValidator) validateHasNonEmpty(actual, expected map[string]string) b
 	return true
 }
 
+// validateEach 验证map包含指定的键，且值与期望值满足match（如前缀、后缀匹配）
+func (v *MapValidator) validateEach(actual, expected map[string]string, match func(value, expected string) bool) bool {
+	for k, want := range expected {
+		actualVal, exists := actual[k]
+		if !exists || !match(actualVal, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateList 验证map中指定键的值是否在列表中；in 要求键存在且值在列表中，not_in 要求键不存在或值不在列表中
+func (v *MapValidator) validateList(actual map[string]string, operator string, thresholdValue interface{}) (bool, error) {
+	lists, err := v.convertToListMap(thresholdValue)
+	if err != nil {
+		return false, fmt.Errorf("threshold类型转换失败: %w", err)
+	}
+	for k, allowed := range lists {
+		actualVal, exists := actual[k]
+		inList := exists && containsString(allowed, actualVal)
+		if operator == "in" && !inList {
+			return false, nil
+		}
+		if operator == "not_in" && inList {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// convertToListMap 将 键 -> 值列表 的阈值转换为map[string][]string
+func (v *MapValidator) convertToListMap(value interface{}) (map[string][]string, error) {
+	result := make(map[string][]string)
+	add := func(key, item interface{}) error {
+		values, err := toStringList(item)
+		if err != nil {
+			return fmt.Errorf("键 %v: %w", key, err)
+		}
+		result[fmt.Sprintf("%v", key)] = values
+		return nil
+	}
+
+	switch m := value.(type) {
+	case map[string]interface{}:
+		for k, item := range m {
+			if err := add(k, item); err != nil {
+				return nil, err
+			}
+		}
+	case map[interface{}]interface{}:
+		for k, item := range m {
+			if err := add(k, item); err != nil {
+				return nil, err
+			}
+		}
+	case map[string][]string:
+		return m, nil
+	default:
+		return nil, fmt.Errorf("不支持的threshold类型: %T", value)
+	}
+	return result, nil
+}
+
 // convertToStringMap 将不同类型的map转换为map[string]string
 func (v *MapValidator) convertToStringMap(value interface{}) (map[string]string, error) {
 	switch v := value.(type) {
@@ -480,6 +735,91 @@ func (v *MapValidator) FormatValue(value interface{}) string {
 	return fmt.Sprintf("%v", value)
 }
 
+// QuantityValidator Kubernetes资源数量验证器，阈值和实际值都可以使用 512Mi、250m、4Gi 等写法
+type QuantityValidator struct{}
+
+// Validate 验证资源数量
+func (v *QuantityValidator) Validate(metric string, actualValue interface{}, condition RuleCondition, env string) (bool, error) {
+	// 将实际值转换为资源数量
+	actual, err := toQuantity(actualValue)
+	if err != nil {
+		return false, fmt.Errorf("无法将实际值转换为资源数量: %v", err)
+	}
+
+	// 获取适用的阈值
+	thresholdValue := condition.ThresholdFor(env)
+
+	// 集合与区间操作符的阈值为列表
+	switch condition.Operator {
+	case "in", "not_in", "between":
+		return validateOrderedList(condition.Operator, thresholdValue, func(item interface{}) (int, error) {
+			q, err := toQuantity(item)
+			if err != nil {
+				return 0, err
+			}
+			return actual.Cmp(q), nil
+		})
+	}
+
+	// 将阈值转换为资源数量
+	threshold, err := toQuantity(thresholdValue)
+	if err != nil {
+		return false, fmt.Errorf("无法将阈值转换为资源数量: %v", err)
+	}
+
+	// 根据操作符比较
+	cmp := actual.Cmp(threshold)
+	switch condition.Operator {
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	default:
+		return false, fmt.Errorf("资源数量类型不支持的操作符: %s", condition.Operator)
+	}
+}
+
+// FormatValue 按资源数量的规范写法格式化，如 4Gi、250m
+func (v *QuantityValidator) FormatValue(value interface{}) string {
+	if q, err := toQuantity(value); err == nil {
+		return q.String()
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// toQuantity 将值转换为资源数量，支持 resource.Quantity、数量字符串和数字
+func toQuantity(value interface{}) (resource.Quantity, error) {
+	switch v := value.(type) {
+	case resource.Quantity:
+		return v, nil
+	case *resource.Quantity:
+		if v == nil {
+			return resource.Quantity{}, fmt.Errorf("资源数量为空")
+		}
+		return *v, nil
+	case string:
+		return resource.ParseQuantity(strings.TrimSpace(v))
+	case int:
+		return *resource.NewQuantity(int64(v), resource.DecimalSI), nil
+	case int32:
+		return *resource.NewQuantity(int64(v), resource.DecimalSI), nil
+	case int64:
+		return *resource.NewQuantity(v, resource.DecimalSI), nil
+	case float64:
+		return resource.ParseQuantity(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return resource.Quantity{}, fmt.Errorf("不支持的类型: %T", value)
+	}
+}
+
 // toFloat64 将值转换为float64
 func toFloat64(value interface{}) (float64, error) {
 	switch v := value.(type) {
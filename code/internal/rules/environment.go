@@ -0,0 +1,145 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+)
+
+// DefaultEnvironment 阈值的兜底环境键，也是所有环境继承链的终点
+const DefaultEnvironment = "default"
+
+// KubeSystemNamespace 读取集群环境标签的命名空间
+const KubeSystemNamespace = "kube-system"
+
+// Matches 判断集群名称是否匹配该模式
+func (p ClusterEnvironmentPattern) Matches(clusterName string) bool {
+	if p.Regex != "" {
+		re, err := regexp.Compile("^(?:" + p.Regex + ")$")
+		return err == nil && re.MatchString(clusterName)
+	}
+	matched, err := path.Match(p.Pattern, clusterName)
+	return err == nil && matched
+}
+
+// validate 验证集群名称模式
+func (p ClusterEnvironmentPattern) validate() error {
+	if (p.Pattern == "") == (p.Regex == "") {
+		return fmt.Errorf("pattern 和 regex 需设置且只能设置一个")
+	}
+	if p.Environment == "" {
+		return fmt.Errorf("缺少 environment")
+	}
+	if p.Regex != "" {
+		if _, err := regexp.Compile(p.Regex); err != nil {
+			return fmt.Errorf("无效的正则表达式 %q: %v", p.Regex, err)
+		}
+		return nil
+	}
+	if _, err := path.Match(p.Pattern, ""); err != nil {
+		return fmt.Errorf("无效的通配符模式 %q: %v", p.Pattern, err)
+	}
+	return nil
+}
+
+// validateEnvironments 验证环境继承关系：default 不能继承其他环境，继承关系不能成环
+func validateEnvironments(environments map[string]EnvironmentConfig) error {
+	if environments[DefaultEnvironment].Inherits != "" {
+		return fmt.Errorf("环境 %s 不能继承其他环境", DefaultEnvironment)
+	}
+	for name := range environments {
+		visited := map[string]bool{name: true}
+		for parent := environments[name].Inherits; parent != "" && parent != DefaultEnvironment; parent = environments[parent].Inherits {
+			if visited[parent] {
+				return fmt.Errorf("环境 %s 的继承关系存在循环", name)
+			}
+			visited[parent] = true
+		}
+	}
+	return nil
+}
+
+// environmentChain 返回查找阈值时依次使用的环境：环境本身、继承的上级环境，最后为 default
+func environmentChain(environments map[string]EnvironmentConfig, env string) []string {
+	chain := make([]string, 0, 2)
+	seen := make(map[string]bool)
+	for current := env; current != "" && current != DefaultEnvironment && !seen[current]; current = environments[current].Inherits {
+		seen[current] = true
+		chain = append(chain, current)
+	}
+	return append(chain, DefaultEnvironment)
+}
+
+// ResolveEnvironment 确定集群对应的环境，依次使用 clusterEnvironments 中的集群名称、clusterEnvironmentPatterns 中第一个匹配的模式、
+// kube-system 命名空间上 config.environmentLabel 标签的值（clusterLabels 为该命名空间的标签，可以为nil）、
+// clusterEnvironments 中的 default、config.environment，都未设置时为 prod
+func (rl *RuleLoader) ResolveEnvironment(clusterName string, clusterLabels map[string]string) string {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	if rl.config == nil {
+		return "prod" // 默认使用生产环境
+	}
+
+	if env, exists := rl.config.ClusterEnvironments[clusterName]; exists {
+		return env
+	}
+	for _, pattern := range rl.config.ClusterEnvironmentPatterns {
+		if pattern.Matches(clusterName) {
+			return pattern.Environment
+		}
+	}
+	if label := rl.config.Config.EnvironmentLabel; label != "" && clusterLabels[label] != "" {
+		return clusterLabels[label]
+	}
+	if defaultEnv, exists := rl.config.ClusterEnvironments[DefaultEnvironment]; exists {
+		return defaultEnv
+	}
+	if rl.config.Config.Environment != "" {
+		return rl.config.Config.Environment
+	}
+	return "prod"
+}
+
+// EnvironmentLabel 返回表示集群环境的 kube-system 命名空间标签，未配置时为空
+func (rl *RuleLoader) EnvironmentLabel() string {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	if rl.config == nil {
+		return ""
+	}
+	return rl.config.Config.EnvironmentLabel
+}
+
+// EnvironmentChain 返回环境的阈值查找顺序，包括继承的上级环境和 default
+func (rl *RuleLoader) EnvironmentChain(env string) []string {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	if rl.config == nil {
+		return environmentChain(nil, env)
+	}
+	return environmentChain(rl.config.Environments, env)
+}
+
+// ResolveEnvironment 根据集群名称和 kube-system 命名空间的标签确定环境
+func (e *Engine) ResolveEnvironment(clusterName string, clusterLabels map[string]string) string {
+	return e.loader.ResolveEnvironment(clusterName, clusterLabels)
+}
+
+// EnvironmentLabel 返回规则配置中表示集群环境的 kube-system 命名空间标签
+func (e *Engine) EnvironmentLabel() string {
+	return e.loader.EnvironmentLabel()
+}
+
+// thresholdEnv 返回条件在当前环境下使用的阈值环境键：沿继承链找到第一个设置了阈值的环境，
+// 都没有设置时返回当前环境，由验证器回退到 default 或通用阈值
+func (e *Engine) thresholdEnv(condition RuleCondition) string {
+	if len(condition.Thresholds) == 0 {
+		return e.environment
+	}
+	for _, env := range e.loader.EnvironmentChain(e.environment) {
+		if _, exists := condition.Thresholds[env]; exists {
+			return env
+		}
+	}
+	return e.environment
+}
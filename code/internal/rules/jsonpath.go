@@ -0,0 +1,193 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+)
+
+// JSONPathMetric 通用指标名称，按条件中的 path 从原始Kubernetes对象取值
+const JSONPathMetric = "jsonpath"
+
+// ObjectMetricType 原始对象的值类型，分析器的 MetricSource 对 jsonpath 指标返回该类型
+const ObjectMetricType = "object"
+
+// 路径返回多个值时的判定方式
+const (
+	// FanOutAll 每个值都满足条件时成立（默认）
+	FanOutAll = "all"
+	// FanOutAny 至少一个值满足条件时成立
+	FanOutAny = "any"
+)
+
+// jsonPathValueTypes jsonpath 条件可以声明的值类型
+var jsonPathValueTypes = []string{"numeric", "quantity", "string", "boolean", "map"}
+
+// JSONPathMetricSpec 返回资源类型的 jsonpath 指标描述，由支持原始对象的分析器注册
+func JSONPathMetricSpec(kind string) MetricSpec {
+	var operators []string
+	for _, valueType := range jsonPathValueTypes {
+		for _, op := range typeOperators[valueType] {
+			if !containsString(operators, op) {
+				operators = append(operators, op)
+			}
+		}
+	}
+	return MetricSpec{
+		Name:        JSONPathMetric,
+		Kind:        kind,
+		Type:        JSONPathMetric,
+		Operators:   operators,
+		Description: "按 path 从原始对象取值，如 {.spec.template.spec.securityContext.runAsNonRoot}",
+	}
+}
+
+// parseJSONPath 解析JSONPath表达式，未使用 {} 包裹时自动补全
+func parseJSONPath(path string) (*jsonpath.JSONPath, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+	jp := jsonpath.New(JSONPathMetric).AllowMissingKeys(true)
+	if err := jp.Parse(path); err != nil {
+		return nil, fmt.Errorf("无效的JSONPath %q: %v", path, err)
+	}
+	return jp, nil
+}
+
+// JSONPathValues 在原始对象上执行JSONPath，返回全部结果；数组通配（如 [*]）会展开为多个值，缺失的字段不返回值
+func JSONPathValues(path string, object interface{}) ([]interface{}, error) {
+	jp, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	results, err := jp.FindResults(object)
+	if err != nil {
+		return nil, fmt.Errorf("执行JSONPath %q 失败: %v", path, err)
+	}
+
+	var values []interface{}
+	for _, group := range results {
+		for _, result := range group {
+			if !result.IsValid() || !result.CanInterface() {
+				continue
+			}
+			value := result.Interface()
+			if value == nil {
+				continue
+			}
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// validateJSONPathCondition 验证 jsonpath 条件的 path、valueType 和 fanOut
+func validateJSONPathCondition(condition RuleCondition) error {
+	if condition.Path == "" {
+		return fmt.Errorf("jsonpath 指标缺少 path")
+	}
+	if _, err := parseJSONPath(condition.Path); err != nil {
+		return err
+	}
+	if condition.ValueType != "" && !containsString(jsonPathValueTypes, condition.ValueType) {
+		return fmt.Errorf("不支持的 valueType %q (可用: %s)", condition.ValueType, strings.Join(jsonPathValueTypes, ", "))
+	}
+	if condition.FanOut != "" && condition.FanOut != FanOutAll && condition.FanOut != FanOutAny {
+		return fmt.Errorf("fanOut 应为 %s 或 %s", FanOutAll, FanOutAny)
+	}
+	return nil
+}
+
+// evaluateJSONPath 在原始对象上评估 jsonpath 条件，返回条件是否成立以及路径取到的值；
+// 路径没有值时使用条件的 default，未设置 default 时条件不成立
+func (e *Engine) evaluateJSONPath(condition RuleCondition, object interface{}) (bool, []interface{}, error) {
+	if _, ok := object.(map[string]interface{}); !ok {
+		return false, nil, fmt.Errorf("jsonpath 指标需要原始对象，实际类型: %T", object)
+	}
+	values, err := JSONPathValues(condition.Path, object)
+	if err != nil {
+		return false, nil, err
+	}
+	if len(values) == 0 {
+		if condition.Default == nil {
+			return false, nil, nil
+		}
+		values = []interface{}{condition.Default}
+	}
+
+	anyMatch := condition.FanOut == FanOutAny
+	for _, value := range values {
+		valueType := condition.ValueType
+		if valueType == "" {
+			valueType = inferValueType(value)
+		}
+		value = normalizeJSONPathValue(value, valueType)
+		validator, err := e.GetValidator(valueType)
+		if err != nil {
+			return false, values, err
+		}
+		matched, err := validator.Validate(condition.Path, value, condition, e.thresholdEnv(condition))
+		if err != nil {
+			return false, values, fmt.Errorf("路径 %s 的值 %v: %w", condition.Path, value, err)
+		}
+		if anyMatch && matched {
+			return true, values, nil
+		}
+		if !anyMatch && !matched {
+			return false, values, nil
+		}
+	}
+	return !anyMatch, values, nil
+}
+
+// inferValueType 按值的类型推断验证器类型
+func inferValueType(value interface{}) string {
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case map[string]interface{}, map[string]string:
+		return "map"
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "numeric"
+	}
+	return "string"
+}
+
+// normalizeJSONPathValue 将对象中的值转换为验证器可以处理的形式
+func normalizeJSONPathValue(value interface{}, valueType string) interface{} {
+	if valueType != "map" {
+		return value
+	}
+	if m, ok := value.(map[string]interface{}); ok {
+		result := make(map[string]string, len(m))
+		for k, v := range m {
+			result[k] = fmt.Sprintf("%v", v)
+		}
+		return result
+	}
+	return value
+}
+
+// formatJSONPathValues 格式化路径取到的值，单个值直接显示
+func formatJSONPathValues(values []interface{}) string {
+	switch len(values) {
+	case 0:
+		return i18n.T("<无值>")
+	case 1:
+		return fmt.Sprintf("%v", values[0])
+	}
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, fmt.Sprintf("%v", value))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
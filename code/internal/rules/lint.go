@@ -0,0 +1,398 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+)
+
+// 规则检查问题级别
+const (
+	// LintError 规则无法按预期生效
+	LintError = "error"
+	// LintWarning 规则可以评估，但部分配置不会生效
+	LintWarning = "warning"
+)
+
+// LintIssue 规则检查发现的问题
+type LintIssue struct {
+	// 规则ID
+	RuleID string
+	// 条件路径，组合条件中的子条件如 all[0]，顶层条件为空
+	Path string
+	// 问题级别：error 或 warning
+	Level string
+	// 问题描述
+	Message string
+}
+
+// String 返回问题的单行描述
+func (i LintIssue) String() string {
+	location := i.RuleID
+	if i.Path != "" {
+		location = fmt.Sprintf("%s (%s)", i.RuleID, i.Path)
+	}
+	return fmt.Sprintf("[%s] %s: %s", i.Level, location, i.Message)
+}
+
+// HasLintErrors 判断问题列表中是否包含错误级别的问题
+func HasLintErrors(issues []LintIssue) bool {
+	for _, issue := range issues {
+		if issue.Level == LintError {
+			return true
+		}
+	}
+	return false
+}
+
+// LintConfig 按指标目录检查规则配置：指标是否存在、操作符是否适用、阈值类型是否匹配，
+// 以及环境阈值的键是否能对应到 clusterEnvironments、clusterEnvironmentPatterns 或 environments 中的环境
+func LintConfig(config *RulesConfig) []LintIssue {
+	issues := make([]LintIssue, 0)
+	if config == nil {
+		return issues
+	}
+
+	// 可被选中的环境名称
+	environments := map[string]bool{"default": true}
+	if config.Config.Environment != "" {
+		environments[config.Config.Environment] = true
+	}
+	for _, env := range config.ClusterEnvironments {
+		environments[env] = true
+	}
+	for _, pattern := range config.ClusterEnvironmentPatterns {
+		environments[pattern.Environment] = true
+	}
+	for name, env := range config.Environments {
+		environments[name] = true
+		if env.Inherits != "" {
+			environments[env.Inherits] = true
+		}
+	}
+
+	for _, rule := range config.Rules {
+		linter := &ruleLinter{rule: rule, environments: environments}
+		linter.lint()
+		issues = append(issues, linter.issues...)
+	}
+	issues = append(issues, lintScoring(config)...)
+	return issues
+}
+
+// lintScoring 检查评分模型引用的规则是否存在
+func lintScoring(config *RulesConfig) []LintIssue {
+	var issues []LintIssue
+	if config.Scoring == nil {
+		return issues
+	}
+	ruleIDs := make(map[string]bool, len(config.Rules))
+	for _, rule := range config.Rules {
+		ruleIDs[rule.ID] = true
+	}
+	unknown := func(ruleID, path string) {
+		issues = append(issues, LintIssue{
+			RuleID:  ruleID,
+			Path:    path,
+			Level:   LintWarning,
+			Message: "评分模型引用了不存在的规则，该设置不会生效",
+		})
+	}
+
+	weighted := make([]string, 0, len(config.Scoring.RuleWeights))
+	for ruleID := range config.Scoring.RuleWeights {
+		weighted = append(weighted, ruleID)
+	}
+	sort.Strings(weighted)
+	for _, ruleID := range weighted {
+		if !ruleIDs[ruleID] {
+			unknown(ruleID, "scoring.ruleWeights")
+		}
+	}
+
+	names := make([]string, 0, len(config.Scoring.Categories))
+	for name := range config.Scoring.Categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, ruleID := range config.Scoring.Categories[name].Rules {
+			if !ruleIDs[ruleID] {
+				unknown(ruleID, "scoring.categories."+name)
+			}
+		}
+	}
+	return issues
+}
+
+// ruleLinter 检查单条规则
+type ruleLinter struct {
+	rule         Rule
+	kind         string
+	environments map[string]bool
+	issues       []LintIssue
+}
+
+// report 记录问题
+func (l *ruleLinter) report(level, path, format string, args ...interface{}) {
+	l.issues = append(l.issues, LintIssue{
+		RuleID:  l.rule.ID,
+		Path:    path,
+		Level:   level,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// lint 检查规则的资源类型和条件
+func (l *ruleLinter) lint() {
+	l.lintLocales()
+	kind := l.rule.Category
+	if len(CatalogMetrics(kind)) == 0 {
+		// 没有专用分析器的资源类型由 inspect resource 使用通用指标评估
+		generic := CatalogMetrics(GenericKind)
+		if len(generic) == 0 || !usesOnlyMetrics(l.rule.Condition, GenericKind) {
+			names := make([]string, 0, len(generic))
+			for _, spec := range generic {
+				names = append(names, spec.Name)
+			}
+			l.report(LintError, "", "未知的资源类型 %q，没有分析器会评估该规则 (可用: %s；其他资源类型只能使用通用指标: %s)",
+				kind, strings.Join(CatalogKinds(), ", "), strings.Join(names, ", "))
+			return
+		}
+		kind = GenericKind
+	}
+	l.kind = kind
+	// 节点是集群级资源，命名空间条件不会匹配任何节点
+	if kind == "node" {
+		for name, selector := range map[string]*ResourceSelector{"match": l.rule.Match, "exclude": l.rule.Exclude} {
+			if selector != nil && (len(selector.Namespaces) > 0 || selector.NamespaceSelector != "") {
+				l.report(LintWarning, "", "节点没有命名空间，%s 中的命名空间条件不会匹配任何节点", name)
+			}
+		}
+	}
+	if l.rule.Condition.IsCompound() && !SupportsCompound(kind) {
+		l.report(LintError, "", "%s 规则不支持 all/any/not 组合条件", kind)
+		return
+	}
+	l.lintCondition(l.rule.Condition, "")
+}
+
+// lintLocales 检查多语言文本的语言是否受支持
+func (l *ruleLinter) lintLocales() {
+	langs := make([]string, 0, len(l.rule.Locales))
+	for lang := range l.rule.Locales {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		if parsed, err := i18n.ParseLanguage(lang); err != nil || string(parsed) != lang {
+			l.report(LintWarning, "", "locales 中的语言 %q 不受支持，该文本不会被使用 (可用: %s)", lang, supportedLanguages())
+		}
+	}
+}
+
+// supportedLanguages 返回支持的语言列表
+func supportedLanguages() string {
+	names := make([]string, 0, len(i18n.Languages))
+	for _, lang := range i18n.Languages {
+		names = append(names, string(lang))
+	}
+	return strings.Join(names, ", ")
+}
+
+// lintCondition 递归检查条件
+func (l *ruleLinter) lintCondition(condition RuleCondition, path string) {
+	for i, child := range condition.All {
+		l.lintCondition(child, joinConditionPath(path, fmt.Sprintf("all[%d]", i)))
+	}
+	for i, child := range condition.Any {
+		l.lintCondition(child, joinConditionPath(path, fmt.Sprintf("any[%d]", i)))
+	}
+	if condition.Not != nil {
+		l.lintCondition(*condition.Not, joinConditionPath(path, "not"))
+	}
+	if condition.IsCompound() {
+		return
+	}
+
+	kind := l.kind
+	spec, ok := LookupMetric(kind, condition.Metric)
+	if !ok {
+		message := fmt.Sprintf("未知指标 %q，该条件永远不会被评估", condition.Metric)
+		if suggestion := suggestMetric(kind, condition.Metric); suggestion != "" {
+			message += fmt.Sprintf("，是否为 %q?", suggestion)
+		}
+		l.report(LintError, path, "%s", message)
+		return
+	}
+
+	// jsonpath 指标的值类型由条件声明，未声明时运行时按值推断，不检查阈值类型
+	if spec.Type == JSONPathMetric {
+		if err := validateJSONPathCondition(condition); err != nil {
+			l.report(LintError, path, "%v", err)
+			return
+		}
+		if condition.ValueType != "" {
+			spec = MetricSpec{Name: condition.Path, Kind: kind, Type: condition.ValueType, Operators: TypeOperators(condition.ValueType)}
+		}
+	}
+
+	if !spec.SupportsOperator(condition.Operator) {
+		l.report(LintError, path, "指标 %s (%s) 不支持操作符 %q (可用: %s)",
+			spec.Name, spec.Type, condition.Operator, strings.Join(spec.Operators, " "))
+		return
+	}
+
+	if condition.Threshold != nil && spec.Type != JSONPathMetric {
+		if err := checkThresholdType(spec.Type, condition.Operator, condition.Threshold); err != nil {
+			l.report(LintError, path, "阈值 %v 与指标 %s 的类型 %s 不匹配: %v", condition.Threshold, spec.Name, spec.Type, err)
+		}
+	}
+
+	envs := make([]string, 0, len(condition.Thresholds))
+	for env := range condition.Thresholds {
+		envs = append(envs, env)
+	}
+	sort.Strings(envs)
+	for _, env := range envs {
+		value := condition.Thresholds[env]
+		if spec.Type != JSONPathMetric {
+			if err := checkThresholdType(spec.Type, condition.Operator, value); err != nil {
+				l.report(LintError, path, "环境 %s 的阈值 %v 与指标 %s 的类型 %s 不匹配: %v", env, value, spec.Name, spec.Type, err)
+			}
+		}
+		if !l.environments[env] {
+			l.report(LintWarning, path, "环境阈值 %q 未对应 clusterEnvironments、clusterEnvironmentPatterns、environments 或 config.environment 中的任何环境，不会生效", env)
+		}
+	}
+}
+
+// usesOnlyMetrics 判断条件中的全部指标是否都已在指定资源类型下注册
+func usesOnlyMetrics(condition RuleCondition, kind string) bool {
+	for _, child := range condition.All {
+		if !usesOnlyMetrics(child, kind) {
+			return false
+		}
+	}
+	for _, child := range condition.Any {
+		if !usesOnlyMetrics(child, kind) {
+			return false
+		}
+	}
+	if condition.Not != nil && !usesOnlyMetrics(*condition.Not, kind) {
+		return false
+	}
+	if condition.IsCompound() {
+		return true
+	}
+	_, ok := LookupMetric(kind, condition.Metric)
+	return ok
+}
+
+// checkThresholdType 检查阈值能否被指定类型的验证器使用
+func checkThresholdType(valueType, operator string, threshold interface{}) error {
+	if err := validateThresholdShape(operator, threshold); err != nil {
+		return err
+	}
+
+	// 列表阈值逐个检查元素
+	switch operator {
+	case "in", "not_in", "between":
+		if valueType == "map" {
+			_, err := (&MapValidator{}).convertToListMap(threshold)
+			return err
+		}
+		items, err := toList(threshold)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := checkScalarThreshold(valueType, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if valueType == "map" {
+		_, err := (&MapValidator{}).convertToStringMap(threshold)
+		return err
+	}
+	return checkScalarThreshold(valueType, threshold)
+}
+
+// checkScalarThreshold 检查单个阈值的类型
+func checkScalarThreshold(valueType string, value interface{}) error {
+	switch valueType {
+	case "numeric":
+		// 字符串会被宽松解析（如 "4Gi" 被当作 4），因此要求使用数字
+		if _, ok := value.(string); ok {
+			return fmt.Errorf("应为数字，实际为字符串 %q", value)
+		}
+		_, err := toFloat64(value)
+		return err
+	case "quantity":
+		_, err := toQuantity(value)
+		return err
+	case "boolean":
+		if _, ok := toBool(value); !ok {
+			return fmt.Errorf("应为 true 或 false")
+		}
+	case "string":
+		switch value.(type) {
+		case []interface{}, map[interface{}]interface{}, map[string]interface{}:
+			return fmt.Errorf("应为字符串，实际类型: %T", value)
+		}
+	}
+	return nil
+}
+
+// suggestMetric 为未知指标查找拼写最接近的已注册指标
+func suggestMetric(kind, metric string) string {
+	best, bestDistance := "", 0
+	for _, spec := range CatalogMetrics(kind) {
+		distance := editDistance(metric, spec.Name)
+		if best == "" || distance < bestDistance {
+			best, bestDistance = spec.Name, distance
+		}
+	}
+	// 差异过大时不给出建议
+	if best == "" || bestDistance > len(metric)/3+1 {
+		return ""
+	}
+	return best
+}
+
+// editDistance 计算两个字符串的编辑距离
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
+
+// minInt 返回最小值
+func minInt(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
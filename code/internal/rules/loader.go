@@ -1,79 +1,384 @@
 package rules
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
-
-	"time"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	"gopkg.in/yaml.v2"
 )
 
 // RuleLoader 规则加载器
 type RuleLoader struct {
-	// 规则文件路径
-	rulesFile string
-	// 上次修改时间
-	lastModified time.Time
+	// 规则文件或目录路径，按顺序合并，后加载的规则按ID覆盖先加载的规则
+	paths []string
+	// 读取规则文件的文件系统，为nil时使用本地文件系统
+	fsys fs.FS
+	// 上次成功加载时的文件指纹（路径、大小与修改时间）
+	fingerprint string
+	// 最近一次验证失败的文件指纹，用于避免重复报告同一个无效版本
+	lastAttempt string
 	// 已加载的规则配置
 	config *RulesConfig
+	// 保护配置的并发读写，重载时整体替换
+	mu sync.RWMutex
+	// 串行化重载，避免并发重载时旧版本覆盖新版本
+	reloadMu sync.Mutex
+}
+
+// NewRuleLoader 创建规则加载器，paths 可以是规则文件或包含规则文件的目录
+func NewRuleLoader(paths ...string) *RuleLoader {
+	return &RuleLoader{
+		paths: paths,
+	}
 }
 
-// NewRuleLoader 创建规则加载器
-func NewRuleLoader(rulesFile string) *RuleLoader {
+// NewRuleLoaderFromFS 创建从指定文件系统（如内置规则包）读取规则的加载器
+func NewRuleLoaderFromFS(fsys fs.FS, paths ...string) *RuleLoader {
 	return &RuleLoader{
-		rulesFile: rulesFile,
+		paths: paths,
+		fsys:  fsys,
 	}
 }
 
+// Sources 返回规则路径的描述，用于日志输出
+func (rl *RuleLoader) Sources() string {
+	return strings.Join(rl.paths, ", ")
+}
+
 // LoadRules 加载规则
 func (rl *RuleLoader) LoadRules() error {
-	// 检查文件是否存在
-	info, err := os.Stat(rl.rulesFile)
-	if os.IsNotExist(err) {
-		return fmt.Errorf("规则文件不存在: %s", rl.rulesFile)
-	}
+	_, err := rl.reload()
+	return err
+}
+
+// renamedRules 内置规则包中已改名的规则ID及其新ID，启用或禁用旧ID时在错误中提示新ID
+var renamedRules = map[string]string{
+	"require_owner_label": "deployment_require_owner_label 和 service_require_owner_label",
+}
+
+// reload 在规则文件变更时重新加载并验证，验证通过后整体替换当前配置；
+// 返回配置是否被替换，验证失败时保留原有配置
+func (rl *RuleLoader) reload() (bool, error) {
+	rl.reloadMu.Lock()
+	defer rl.reloadMu.Unlock()
+
+	files, fingerprint, err := rl.resolveFiles()
 	if err != nil {
-		return fmt.Errorf("检查规则文件失败: %w", err)
+		return false, err
 	}
 
 	// 检查文件是否已修改
-	if rl.config != nil && info.ModTime().Equal(rl.lastModified) {
+	rl.mu.RLock()
+	unchanged := rl.config != nil && (fingerprint == rl.fingerprint || fingerprint == rl.lastAttempt)
+	rl.mu.RUnlock()
+	if unchanged {
 		// 文件未修改，使用已加载的配置
-		return nil
+		return false, nil
 	}
 
-	// 读取文件内容
-	data, err := os.ReadFile(rl.rulesFile)
+	config, err := rl.parse(files)
 	if err != nil {
-		return fmt.Errorf("读取规则文件失败: %w", err)
+		// 记录无效版本，文件再次变更前不重复报告
+		rl.mu.Lock()
+		rl.lastAttempt = fingerprint
+		rl.mu.Unlock()
+		return false, err
+	}
+
+	// 更新配置和文件指纹
+	rl.mu.Lock()
+	rl.config = config
+	rl.fingerprint = fingerprint
+	rl.mu.Unlock()
+
+	return true, nil
+}
+
+// parse 依次解析所有规则文件中的规则文档，合并后验证
+func (rl *RuleLoader) parse(files []string) (*RulesConfig, error) {
+	var documents []ruleDocument
+	for _, file := range files {
+		// 读取文件内容
+		data, err := rl.readFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("读取规则文件失败: %w", err)
+		}
+		docs, err := parseRuleDocuments(file, data)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, docs...)
+	}
+	if len(documents) == 0 {
+		return nil, fmt.Errorf("未找到规则配置: %s", rl.Sources())
+	}
+
+	config, err := mergeRuleDocuments(documents)
+	if err != nil {
+		return nil, fmt.Errorf("验证规则配置失败: %w", err)
+	}
+	return config, nil
+}
+
+// resolveFiles 展开规则路径，目录按文件名顺序包含其中的 .yaml/.yml 文件（不递归），
+// 同时返回所有文件的指纹用于判断是否变更
+func (rl *RuleLoader) resolveFiles() ([]string, string, error) {
+	var files []string
+	var fingerprint strings.Builder
+	for _, path := range rl.paths {
+		// 检查文件是否存在
+		info, err := rl.stat(path)
+		if os.IsNotExist(err) {
+			return nil, "", fmt.Errorf("规则文件不存在: %s", path)
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("检查规则文件失败: %w", err)
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			fmt.Fprintf(&fingerprint, "%s|%d|%d;", path, info.Size(), info.ModTime().UnixNano())
+			continue
+		}
+
+		entries, err := rl.readDir(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("读取规则目录失败: %w", err)
+		}
+		// ReadDir 按文件名排序返回
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+				continue
+			}
+			entryInfo, err := entry.Info()
+			if err != nil {
+				return nil, "", fmt.Errorf("检查规则文件失败: %w", err)
+			}
+			file := rl.join(path, entry.Name())
+			files = append(files, file)
+			fmt.Fprintf(&fingerprint, "%s|%d|%d;", file, entryInfo.Size(), entryInfo.ModTime().UnixNano())
+		}
+	}
+	return files, fingerprint.String(), nil
+}
+
+// ruleDocument 规则文件中的单个 RulesConfig 文档
+type ruleDocument struct {
+	// 来源描述：文件路径，多文档文件附带文档序号
+	source string
+	config RulesConfig
+}
+
+// stat 获取文件信息
+func (rl *RuleLoader) stat(path string) (fs.FileInfo, error) {
+	if rl.fsys != nil {
+		return fs.Stat(rl.fsys, path)
+	}
+	return os.Stat(path)
+}
+
+// readDir 读取目录，结果按文件名排序
+func (rl *RuleLoader) readDir(path string) ([]fs.DirEntry, error) {
+	if rl.fsys != nil {
+		return fs.ReadDir(rl.fsys, path)
 	}
+	return os.ReadDir(path)
+}
+
+// readFile 读取文件内容
+func (rl *RuleLoader) readFile(path string) ([]byte, error) {
+	if rl.fsys != nil {
+		return fs.ReadFile(rl.fsys, path)
+	}
+	return os.ReadFile(path)
+}
+
+// join 拼接路径，fs.FS 中始终使用 / 分隔
+func (rl *RuleLoader) join(dir, name string) string {
+	if rl.fsys != nil {
+		return path.Join(dir, name)
+	}
+	return filepath.Join(dir, name)
+}
 
+// parseRuleDocuments 解析规则文件，支持以 --- 分隔的多文档YAML
+func parseRuleDocuments(file string, data []byte) ([]ruleDocument, error) {
 	// 解析YAML
-	var config RulesConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return fmt.Errorf("解析规则文件YAML失败: %w", err)
+	var documents []ruleDocument
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for index := 1; ; index++ {
+		var config RulesConfig
+		if err := decoder.Decode(&config); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("解析规则文件YAML失败 (%s): %w", file, err)
+		}
+		// 跳过空文档
+		if config.APIVersion == "" && config.Kind == "" && len(config.Rules) == 0 {
+			continue
+		}
+		source := file
+		if index > 1 {
+			source = fmt.Sprintf("%s#%d", file, index)
+		}
+		documents = append(documents, ruleDocument{source: source, config: config})
 	}
 
-	// 验证配置
-	if err := validateConfig(&config); err != nil {
-		return fmt.Errorf("验证规则配置失败: %w", err)
+	// 多文档文件中第一个文档的序号也需要区分
+	if len(documents) > 1 && documents[0].source == file {
+		documents[0].source = file + "#1"
 	}
+	return documents, nil
+}
 
-	// 更新配置和修改时间
-	rl.config = &config
-	rl.lastModified = info.ModTime()
+// mergeRuleDocuments 按顺序合并规则文档
+// 同一文档内规则ID不能重复；后续文档中相同ID的完整规则会替换之前的规则，替换时类别必须相同，
+// 避免不同资源类型的规则因ID相同而被静默覆盖；只包含 id 和 enabled 的条目用于启用或禁用之前定义的规则
+func mergeRuleDocuments(documents []ruleDocument) (*RulesConfig, error) {
+	merged := &RulesConfig{
+		ClusterEnvironments: make(map[string]string),
+	}
+	index := make(map[string]int)
+	// 每条规则最后一次定义所在的文档
+	sources := make(map[string]string)
 
-	return nil
+	for _, doc := range documents {
+		config := doc.config
+		if err := validateConfig(&config); err != nil {
+			return nil, fmt.Errorf("%s: %w", doc.source, err)
+		}
+
+		// 合并通用配置，后面的文档覆盖前面的非空设置
+		if merged.APIVersion == "" {
+			merged.APIVersion = config.APIVersion
+			merged.Kind = config.Kind
+		}
+		if config.Config.AutoReload {
+			merged.Config.AutoReload = true
+		}
+		if config.Config.ReloadInterval != "" {
+			merged.Config.ReloadInterval = config.Config.ReloadInterval
+		}
+		if config.Config.Environment != "" {
+			merged.Config.Environment = config.Config.Environment
+		}
+		if config.Config.EnvironmentLabel != "" {
+			merged.Config.EnvironmentLabel = config.Config.EnvironmentLabel
+		}
+		for cluster, env := range config.ClusterEnvironments {
+			merged.ClusterEnvironments[cluster] = env
+		}
+		// 后面文档中的模式优先匹配
+		merged.ClusterEnvironmentPatterns = append(append([]ClusterEnvironmentPattern{}, config.ClusterEnvironmentPatterns...), merged.ClusterEnvironmentPatterns...)
+		for name, environment := range config.Environments {
+			if merged.Environments == nil {
+				merged.Environments = make(map[string]EnvironmentConfig)
+			}
+			merged.Environments[name] = environment
+		}
+		merged.Scoring = mergeScoring(merged.Scoring, config.Scoring)
+
+		// 按ID合并规则
+		for _, rule := range config.Rules {
+			pos, exists := index[rule.ID]
+			if isRuleToggle(rule) {
+				if !exists {
+					if renamed, ok := renamedRules[rule.ID]; ok {
+						return nil, fmt.Errorf("%s: 规则 '%s' 已改名为 %s，无法启用或禁用", doc.source, rule.ID, renamed)
+					}
+					return nil, fmt.Errorf("%s: 规则 '%s' 不存在，无法启用或禁用", doc.source, rule.ID)
+				}
+				merged.Rules[pos].Enabled = rule.Enabled
+				continue
+			}
+			if exists {
+				if previous := merged.Rules[pos].Category; previous != rule.Category {
+					return nil, fmt.Errorf("%s: 规则 '%s' 已在 %s 中定义为 %s 类别，覆盖时不能改为 %s 类别，不同的规则请使用不同的ID",
+						doc.source, rule.ID, sources[rule.ID], previous, rule.Category)
+				}
+				merged.Rules[pos] = rule
+				sources[rule.ID] = doc.source
+				continue
+			}
+			index[rule.ID] = len(merged.Rules)
+			merged.Rules = append(merged.Rules, rule)
+			sources[rule.ID] = doc.source
+		}
+	}
+
+	// 继承关系可能跨文档，合并后再检查
+	if err := validateEnvironments(merged.Environments); err != nil {
+		return nil, err
+	}
+
+	return merged, nil
+}
+
+// mergeScoring 合并评分模型，后面文档中的权重和类别按键覆盖前面的设置，非零的下限覆盖前面的下限
+func mergeScoring(base, override *ScoringConfig) *ScoringConfig {
+	if override == nil {
+		return base
+	}
+	if base == nil {
+		base = &ScoringConfig{}
+	}
+	merged := &ScoringConfig{
+		SeverityWeights: make(map[string]int),
+		RuleWeights:     make(map[string]int),
+		Categories:      make(map[string]ScoreCategory),
+		Floor:           base.Floor,
+	}
+	for _, c := range []*ScoringConfig{base, override} {
+		for severity, weight := range c.SeverityWeights {
+			merged.SeverityWeights[severity] = weight
+		}
+		for ruleID, weight := range c.RuleWeights {
+			merged.RuleWeights[ruleID] = weight
+		}
+		for name, category := range c.Categories {
+			merged.Categories[name] = category
+		}
+	}
+	if override.Floor != 0 {
+		merged.Floor = override.Floor
+	}
+	return merged
+}
+
+// isRuleToggle 判断规则条目是否只用于启用或禁用已有规则（只包含 id 和 enabled）
+func isRuleToggle(rule Rule) bool {
+	return rule.Name == "" && rule.Category == "" && rule.Severity == "" &&
+		rule.Description == "" && rule.Remediation == "" && rule.Message == "" && isEmptyCondition(rule.Condition) &&
+		rule.Match == nil && rule.Exclude == nil && len(rule.Tags) == 0 && len(rule.Controls) == 0 &&
+		len(rule.Locales) == 0
+}
+
+// isEmptyCondition 判断条件是否未设置
+func isEmptyCondition(condition RuleCondition) bool {
+	return condition.Metric == "" && condition.Operator == "" && condition.Threshold == nil && condition.Path == "" &&
+		len(condition.Thresholds) == 0 && !condition.IsCompound()
 }
 
 // GetRulesConfig 获取规则配置
 func (rl *RuleLoader) GetRulesConfig() *RulesConfig {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
 	return rl.config
 }
 
 // GetRules 获取规则列表，可以根据过滤条件筛选
 func (rl *RuleLoader) GetRules(filter RuleFilter) []Rule {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
 	if rl.config == nil {
 		return nil
 	}
@@ -91,29 +396,9 @@ func (rl *RuleLoader) GetRules(filter RuleFilter) []Rule {
 	return result
 }
 
-// GetEnvironment 获取环境设置
+// GetEnvironment 获取集群对应的环境，不使用集群标签
 func (rl *RuleLoader) GetEnvironment(clusterName string) string {
-	if rl.config == nil {
-		return "prod" // 默认使用生产环境
-	}
-
-	// 从集群环境映射中查找
-	if env, exists := rl.config.ClusterEnvironments[clusterName]; exists {
-		return env
-	}
-
-	// 返回默认环境
-	if defaultEnv, exists := rl.config.ClusterEnvironments["default"]; exists {
-		return defaultEnv
-	}
-
-	// 如果没有默认环境，使用配置中的环境
-	if rl.config.Config.Environment != "" {
-		return rl.config.Config.Environment
-	}
-
-	// 最终默认值
-	return "prod"
+	return rl.ResolveEnvironment(clusterName, nil)
 }
 
 // matchesFilter 检查规则是否符合过滤条件
@@ -146,11 +431,24 @@ func matchesFilter(rule Rule, filter RuleFilter) bool {
 		}
 	}
 
+	// 检查标签和控制项
+	if len(filter.Tags) > 0 && !rule.HasAnyTag(filter.Tags) {
+		return false
+	}
+	if len(filter.Controls) > 0 && !rule.MatchesAnyControl(filter.Controls) {
+		return false
+	}
+
 	// 检查是否启用
 	if filter.Enabled != nil && rule.Enabled != *filter.Enabled {
 		return false
 	}
 
+	// 检查作用范围
+	if filter.Resource != nil && !rule.AppliesTo(*filter.Resource) {
+		return false
+	}
+
 	return true
 }
 
@@ -167,11 +465,42 @@ func validateConfig(config *RulesConfig) error {
 		return fmt.Errorf("不支持的kind: %s", config.Kind)
 	}
 
+	// 检查重载间隔
+	if config.Config.ReloadInterval != "" {
+		if _, err := parseReloadInterval(config.Config.ReloadInterval); err != nil {
+			return err
+		}
+	}
+
+	// 检查评分模型
+	if err := config.Scoring.validate(); err != nil {
+		return err
+	}
+
+	// 检查集群环境模式
+	for i, pattern := range config.ClusterEnvironmentPatterns {
+		if err := pattern.validate(); err != nil {
+			return fmt.Errorf("clusterEnvironmentPatterns 第 %d 项 %v", i+1, err)
+		}
+	}
+	if err := validateEnvironments(config.Environments); err != nil {
+		return err
+	}
+
 	// 检查每条规则
+	seen := make(map[string]bool)
 	for i, rule := range config.Rules {
 		if rule.ID == "" {
 			return fmt.Errorf("第 %d 条规则缺少ID", i+1)
 		}
+		if seen[rule.ID] {
+			return fmt.Errorf("规则ID重复: %s", rule.ID)
+		}
+		seen[rule.ID] = true
+		// 只用于启用或禁用已有规则的条目在合并时处理
+		if isRuleToggle(rule) {
+			continue
+		}
 		if rule.Name == "" {
 			return fmt.Errorf("规则 '%s' 缺少名称", rule.ID)
 		}
@@ -181,25 +510,136 @@ func validateConfig(config *RulesConfig) error {
 		if rule.Severity == "" {
 			return fmt.Errorf("规则 '%s' 缺少严重程度", rule.ID)
 		}
-		if rule.Condition.Metric == "" {
-			return fmt.Errorf("规则 '%s' 缺少指标", rule.ID)
+		if err := validateCondition(rule.ID, rule.Condition, ""); err != nil {
+			return err
+		}
+		if err := rule.Match.validate(); err != nil {
+			return fmt.Errorf("规则 '%s' 的 match %v", rule.ID, err)
 		}
-		if rule.Condition.Operator == "" {
-			return fmt.Errorf("规则 '%s' 缺少操作符", rule.ID)
+		if err := rule.Exclude.validate(); err != nil {
+			return fmt.Errorf("规则 '%s' 的 exclude %v", rule.ID, err)
 		}
-		if rule.Condition.Threshold == nil && len(rule.Condition.Thresholds) == 0 {
-			return fmt.Errorf("规则 '%s' 缺少阈值", rule.ID)
+		if err := validateControls(rule.Controls); err != nil {
+			return fmt.Errorf("规则 '%s' 的 controls %v", rule.ID, err)
 		}
-		
-		// 验证操作符是否支持
-		if !isValidOperator(rule.Condition.Operator) {
-			return fmt.Errorf("规则 '%s' 包含不支持的操作符: %s", rule.ID, rule.Condition.Operator)
+		if err := validateMessage(rule); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// validateCondition 递归验证条件树，path 为子条件在树中的位置，根条件为空
+func validateCondition(ruleID string, condition RuleCondition, path string) error {
+	where := fmt.Sprintf("规则 '%s'", ruleID)
+	if path != "" {
+		where = fmt.Sprintf("规则 '%s' 的条件 %s", ruleID, path)
+	}
+
+	// 同一层级只能是叶子条件或一种组合条件
+	kinds := 0
+	if condition.Metric != "" || condition.Operator != "" {
+		kinds++
+	}
+	if len(condition.All) > 0 {
+		kinds++
+	}
+	if len(condition.Any) > 0 {
+		kinds++
+	}
+	if condition.Not != nil {
+		kinds++
+	}
+	if kinds > 1 {
+		return fmt.Errorf("%s 不能同时使用 metric、all、any、not 中的多种写法", where)
+	}
+
+	switch {
+	case len(condition.All) > 0:
+		for i, sub := range condition.All {
+			if err := validateCondition(ruleID, sub, joinConditionPath(path, fmt.Sprintf("all[%d]", i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	case len(condition.Any) > 0:
+		for i, sub := range condition.Any {
+			if err := validateCondition(ruleID, sub, joinConditionPath(path, fmt.Sprintf("any[%d]", i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	case condition.Not != nil:
+		return validateCondition(ruleID, *condition.Not, joinConditionPath(path, "not"))
+	}
+
+	if condition.Metric == "" {
+		return fmt.Errorf("%s 缺少指标", where)
+	}
+	if condition.Operator == "" {
+		return fmt.Errorf("%s 缺少操作符", where)
+	}
+	if condition.Threshold == nil && len(condition.Thresholds) == 0 {
+		return fmt.Errorf("%s 缺少阈值", where)
+	}
+
+	// 验证操作符是否支持
+	if !isValidOperator(condition.Operator) {
+		return fmt.Errorf("%s 包含不支持的操作符: %s", where, condition.Operator)
+	}
+
+	if condition.Metric == JSONPathMetric {
+		if err := validateJSONPathCondition(condition); err != nil {
+			return fmt.Errorf("%s %v", where, err)
+		}
+	}
+
+	// 验证阈值形式是否与操作符匹配
+	thresholds := []interface{}{condition.Threshold}
+	for _, threshold := range condition.Thresholds {
+		thresholds = append(thresholds, threshold)
+	}
+	for _, threshold := range thresholds {
+		if threshold == nil {
+			continue
+		}
+		if err := validateThresholdShape(condition.Operator, threshold); err != nil {
+			return fmt.Errorf("%s 的阈值无效: %v", where, err)
+		}
+	}
+
+	return nil
+}
+
+// validateThresholdShape 检查阈值形式：in/not_in 为列表（map类型指标为 键 -> 列表），
+// between 为 [最小值, 最大值]，版本比较为合法的版本号
+func validateThresholdShape(operator string, threshold interface{}) error {
+	switch operator {
+	case "in", "not_in":
+		if _, err := toList(threshold); err == nil {
+			return nil
+		}
+		if _, err := (&MapValidator{}).convertToListMap(threshold); err != nil {
+			return fmt.Errorf("%s 的阈值应为列表", operator)
+		}
+	case "between":
+		items, err := toList(threshold)
+		if err != nil || len(items) != 2 {
+			return fmt.Errorf("between 的阈值应为 [最小值, 最大值]")
+		}
+	case "version_lt", "version_gte":
+		version, ok := toString(threshold)
+		if !ok {
+			return fmt.Errorf("%s 的阈值应为版本号", operator)
+		}
+		if _, err := parseVersion(version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // isValidOperator 检查操作符是否有效
 func isValidOperator(op string) bool {
 	validOps := map[string]bool{
@@ -212,6 +652,13 @@ func isValidOperator(op string) bool {
 		"contains":     true,
 		"matches":      true,
 		"has_non_empty": true,
+		"in":           true,
+		"not_in":       true,
+		"between":      true,
+		"starts_with":  true,
+		"ends_with":    true,
+		"version_lt":   true,
+		"version_gte":  true,
 	}
 	return validOps[op]
 }
\ No newline at end of file
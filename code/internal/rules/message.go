@@ -0,0 +1,153 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// MessageData 规则消息模板可以使用的数据
+type MessageData struct {
+	// 规则ID
+	RuleID string
+	// 规则名称（当前语言）
+	RuleName string
+	// 严重程度
+	Severity string
+	// 资源类型，如 Deployment
+	Kind string
+	// 资源名称
+	Name string
+	// 命名空间，集群级资源为空
+	Namespace string
+	// 资源标签
+	Labels map[string]string
+	// 检查的指标，jsonpath 指标为路径
+	Metric string
+	// 格式化后的实际值
+	Actual string
+	// 格式化后的期望值（当前环境的阈值）
+	Expected string
+	// 当前环境
+	Environment string
+	// 决定组合条件结果的叶子条件（仅组合条件）
+	Conditions []ConditionResult
+}
+
+// messageFuncs 消息模板中可用的函数
+var messageFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	// default 在值为空时使用默认值，如 {{default "default" .Namespace}}
+	"default": func(fallback string, value interface{}) string {
+		if value == nil || fmt.Sprintf("%v", value) == "" {
+			return fallback
+		}
+		return fmt.Sprintf("%v", value)
+	},
+}
+
+// messageTemplates 已解析的消息模板，键为模板文本
+var messageTemplates sync.Map
+
+// parseMessageTemplate 解析消息模板，并用空数据试渲染以提前发现引用了不存在字段的模板
+func parseMessageTemplate(ruleID, text string) (*template.Template, error) {
+	tmpl, err := template.New(ruleID).Funcs(messageFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(&strings.Builder{}, MessageData{}); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// validateMessage 验证规则默认语言和各语言的消息模板
+func validateMessage(rule Rule) error {
+	if rule.Message != "" {
+		if _, err := parseMessageTemplate(rule.ID, rule.Message); err != nil {
+			return fmt.Errorf("规则 '%s' 的 message 模板无效: %v", rule.ID, err)
+		}
+	}
+	for lang, text := range rule.Locales {
+		if text.Message == "" {
+			continue
+		}
+		if _, err := parseMessageTemplate(rule.ID, text.Message); err != nil {
+			return fmt.Errorf("规则 '%s' 的 locales.%s.message 模板无效: %v", rule.ID, lang, err)
+		}
+	}
+	return nil
+}
+
+// RenderMessage 按规则的消息模板生成发现项消息，resource 为被检查的资源，可以为nil；
+// 规则未设置模板或渲染失败时返回false，调用方继续使用默认消息
+func (e *Engine) RenderMessage(rule Rule, resource *ResourceMeta, result *RuleResult) (string, bool) {
+	if rule.Message == "" || result == nil {
+		return "", false
+	}
+	var tmpl *template.Template
+	if cached, ok := messageTemplates.Load(rule.Message); ok {
+		tmpl = cached.(*template.Template)
+	} else {
+		parsed, err := parseMessageTemplate(rule.ID, rule.Message)
+		if err != nil {
+			return "", false
+		}
+		messageTemplates.Store(rule.Message, parsed)
+		tmpl = parsed
+	}
+
+	data := MessageData{
+		RuleID:      rule.ID,
+		RuleName:    rule.Name,
+		Severity:    rule.Severity,
+		Metric:      rule.Condition.Metric,
+		Actual:      formatMessageValue(result.ActualValue),
+		Expected:    formatMessageValue(result.ExpectedValue),
+		Environment: e.environment,
+		Conditions:  result.Conditions,
+	}
+	if rule.Condition.Metric == JSONPathMetric {
+		data.Metric = rule.Condition.Path
+	} else if rule.Condition.IsCompound() {
+		data.Metric = DescribeCondition(rule.Condition)
+	}
+	if resource != nil {
+		data.Kind = resource.Kind
+		data.Name = resource.Name
+		data.Namespace = resource.Namespace
+		data.Labels = resource.Labels
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(sb.String()), true
+}
+
+// EvaluateRuleFor 评估规则对指定资源的检查结果，检查未通过且规则设置了消息模板时使用模板生成消息；
+// 适用于规则描述期望状态的资源类型（Deployment、Service 等），描述问题状态的规则由分析器调用 RenderMessage
+func (e *Engine) EvaluateRuleFor(rule Rule, resource *ResourceMeta, metricType string, actualValue interface{}) (*RuleResult, error) {
+	result, err := e.EvaluateRule(rule, metricType, actualValue)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Passed {
+		if message, ok := e.RenderMessage(rule, resource, result); ok {
+			result.Message = message
+		}
+	}
+	return result, nil
+}
+
+// formatMessageValue 格式化模板中的值，nil 显示为空
+func formatMessageValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
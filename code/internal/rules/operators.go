@@ -0,0 +1,178 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// validateOrderedList 处理可比较类型（数值、资源数量）的 in、not_in 和 between 操作符
+// compare 返回实际值与列表元素的比较结果：负数表示小于，0表示相等，正数表示大于
+func validateOrderedList(operator string, thresholdValue interface{}, compare func(item interface{}) (int, error)) (bool, error) {
+	items, err := toList(thresholdValue)
+	if err != nil {
+		return false, err
+	}
+
+	if operator == "between" {
+		if len(items) != 2 {
+			return false, fmt.Errorf("between 的阈值应为 [最小值, 最大值]，实际: %v", thresholdValue)
+		}
+		lower, err := compare(items[0])
+		if err != nil {
+			return false, fmt.Errorf("无法转换区间下限: %v", err)
+		}
+		upper, err := compare(items[1])
+		if err != nil {
+			return false, fmt.Errorf("无法转换区间上限: %v", err)
+		}
+		// 闭区间
+		return lower >= 0 && upper <= 0, nil
+	}
+
+	found := false
+	for _, item := range items {
+		cmp, err := compare(item)
+		if err != nil {
+			return false, fmt.Errorf("无法转换列表元素 %v: %v", item, err)
+		}
+		if cmp == 0 {
+			found = true
+			break
+		}
+	}
+	if operator == "not_in" {
+		return !found, nil
+	}
+	return found, nil
+}
+
+// validateStringList 处理字符串的 in 和 not_in 操作符
+func validateStringList(actual, operator string, thresholdValue interface{}) (bool, error) {
+	values, err := toStringList(thresholdValue)
+	if err != nil {
+		return false, err
+	}
+	found := containsString(values, actual)
+	if operator == "not_in" {
+		return !found, nil
+	}
+	return found, nil
+}
+
+// compareVersions 比较两个版本号，支持 v1.28.3、1.28、v1.27.4-eks-abc 等写法，
+// 只比较数字部分，忽略预发布和构建信息
+func compareVersions(a, b string) (int, error) {
+	va, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(va) || i < len(vb); i++ {
+		var x, y int
+		if i < len(va) {
+			x = va[i]
+		}
+		if i < len(vb) {
+			y = vb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// parseVersion 解析版本号的数字部分
+func parseVersion(value string) ([]int, error) {
+	version := strings.TrimPrefix(strings.TrimSpace(value), "v")
+	// 去掉预发布和构建信息
+	if idx := strings.IndexAny(version, "-+"); idx >= 0 {
+		version = version[:idx]
+	}
+
+	parts := strings.Split(version, ".")
+	result := make([]int, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("无效的版本号: %s", value)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+// compareFloat 比较两个浮点数
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// toList 将阈值转换为列表
+func toList(value interface{}) ([]interface{}, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, nil
+	case []string:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = item
+		}
+		return result, nil
+	case []int:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = item
+		}
+		return result, nil
+	case []float64:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = item
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("阈值应为列表，实际类型: %T", value)
+	}
+}
+
+// toStringList 将阈值转换为字符串列表
+func toStringList(value interface{}) ([]string, error) {
+	items, err := toList(value)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		str, ok := toString(item)
+		if !ok {
+			return nil, fmt.Errorf("无法将列表元素转换为字符串: %v", item)
+		}
+		result = append(result, str)
+	}
+	return result, nil
+}
+
+// containsString 判断列表中是否包含指定字符串
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultReloadInterval 未配置 reloadInterval 时的默认重载间隔
+const defaultReloadInterval = 5 * time.Minute
+
+// ReloadEvent 表示一次规则重载事件
+type ReloadEvent struct {
+	// 规则文件或目录路径，多个路径以逗号分隔
+	File string
+	// 事件时间
+	Time time.Time
+	// 是否已替换为新规则
+	Reloaded bool
+	// 重载后的规则数量
+	RuleCount int
+	// 新配置无效时的错误，此时继续使用原有规则
+	Err error
+}
+
+// Pass 执行一次分析，期间持有引擎的读锁，后台重载会等待分析结束后再替换规则，
+// 保证同一次分析中的所有资源按同一版本的规则检查；fn 中不能调用引擎的 Set 系列方法
+func (e *Engine) Pass(fn func() error) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return fn()
+}
+
+// Reload 检查规则文件是否变更，变更时验证并替换当前规则，正在进行的 Pass 结束前不会替换；
+// 文件未变更时返回nil，新配置无效时返回带有 Err 的事件并保留原有规则
+func (e *Engine) Reload() *ReloadEvent {
+	e.mu.Lock()
+	reloaded, err := e.loader.reload()
+	e.mu.Unlock()
+	if !reloaded && err == nil {
+		return nil
+	}
+
+	event := &ReloadEvent{
+		File:     e.loader.Sources(),
+		Time:     time.Now(),
+		Reloaded: reloaded,
+		Err:      err,
+	}
+	if config := e.loader.GetRulesConfig(); config != nil {
+		event.RuleCount = len(config.Rules)
+	}
+	return event
+}
+
+// StartAutoReload 根据规则配置中的 autoReload 和 reloadInterval 启动后台重载；
+// 未启用自动重载时不启动，返回的 stop 函数用于停止后台重载
+func (e *Engine) StartAutoReload(onEvent func(ReloadEvent)) (func(), error) {
+	config := e.loader.GetRulesConfig()
+	if config == nil || !config.Config.AutoReload {
+		return func() {}, nil
+	}
+
+	interval := defaultReloadInterval
+	if config.Config.ReloadInterval != "" {
+		var err error
+		if interval, err = parseReloadInterval(config.Config.ReloadInterval); err != nil {
+			return nil, err
+		}
+	}
+
+	return e.StartReloader(interval, onEvent), nil
+}
+
+// StartReloader 以指定间隔在后台检查规则文件并重载，每次重载或重载失败都会调用 onEvent；
+// 后台重载期间应通过 Pass 执行分析
+func (e *Engine) StartReloader(interval time.Duration, onEvent func(ReloadEvent)) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if event := e.Reload(); event != nil && onEvent != nil {
+					onEvent(*event)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// parseReloadInterval 解析重载间隔
+func parseReloadInterval(value string) (time.Duration, error) {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("无效的重载间隔: %s", value)
+	}
+	if interval <= 0 {
+		return 0, fmt.Errorf("重载间隔必须大于0: %s", value)
+	}
+	return interval, nil
+}
@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ResourceSelector 规则的作用范围选择器，设置的各项需同时满足
+type ResourceSelector struct {
+	// 命名空间名称，满足其一即可
+	Namespaces []string `yaml:"namespaces,omitempty" json:"namespaces,omitempty"`
+	// 命名空间标签选择器，语法与 kubectl -l 相同，如 "team=batch,env!=dev"
+	NamespaceSelector string `yaml:"namespaceSelector,omitempty" json:"namespaceSelector,omitempty"`
+	// 资源标签选择器，如 "tier=frontend"
+	LabelSelector string `yaml:"labelSelector,omitempty" json:"labelSelector,omitempty"`
+	// 资源名称正则表达式（需完整匹配），满足其一即可
+	Names []string `yaml:"names,omitempty" json:"names,omitempty"`
+}
+
+// ResourceMeta 描述被检查的资源，用于判断规则的作用范围
+type ResourceMeta struct {
+	// 资源类型，如 Deployment，用于规则消息模板
+	Kind string
+	// 资源名称
+	Name string
+	// 命名空间，集群级资源（如节点）为空
+	Namespace string
+	// 资源标签
+	Labels map[string]string
+	// 所在命名空间的标签，为nil时由规则引擎补充
+	NamespaceLabels map[string]string
+}
+
+// IsEmpty 判断选择器是否未设置任何条件
+func (s *ResourceSelector) IsEmpty() bool {
+	return s == nil || (len(s.Namespaces) == 0 && s.NamespaceSelector == "" && s.LabelSelector == "" && len(s.Names) == 0)
+}
+
+// Matches 判断资源是否满足选择器
+func (s *ResourceSelector) Matches(res ResourceMeta) bool {
+	if s == nil {
+		return true
+	}
+
+	if len(s.Namespaces) > 0 && !containsString(s.Namespaces, res.Namespace) {
+		return false
+	}
+
+	if s.NamespaceSelector != "" {
+		// 集群级资源没有命名空间，不满足命名空间条件
+		if res.Namespace == "" {
+			return false
+		}
+		selector, err := labels.Parse(s.NamespaceSelector)
+		if err != nil || !selector.Matches(labels.Set(res.NamespaceLabels)) {
+			return false
+		}
+	}
+
+	if s.LabelSelector != "" {
+		selector, err := labels.Parse(s.LabelSelector)
+		if err != nil || !selector.Matches(labels.Set(res.Labels)) {
+			return false
+		}
+	}
+
+	if len(s.Names) > 0 {
+		matched := false
+		for _, pattern := range s.Names {
+			if ok, err := regexp.MatchString("^(?:"+pattern+")$", res.Name); err == nil && ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validate 验证选择器中的标签选择器和正则表达式
+func (s *ResourceSelector) validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.NamespaceSelector != "" {
+		if _, err := labels.Parse(s.NamespaceSelector); err != nil {
+			return fmt.Errorf("无效的命名空间标签选择器 %q: %v", s.NamespaceSelector, err)
+		}
+	}
+	if s.LabelSelector != "" {
+		if _, err := labels.Parse(s.LabelSelector); err != nil {
+			return fmt.Errorf("无效的标签选择器 %q: %v", s.LabelSelector, err)
+		}
+	}
+	for _, pattern := range s.Names {
+		if _, err := regexp.Compile("^(?:" + pattern + ")$"); err != nil {
+			return fmt.Errorf("无效的名称正则表达式 %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// AppliesTo 判断规则是否作用于指定资源：满足 match（未设置时匹配全部）且不满足 exclude
+func (r Rule) AppliesTo(res ResourceMeta) bool {
+	if !r.Match.Matches(res) {
+		return false
+	}
+	if !r.Exclude.IsEmpty() && r.Exclude.Matches(res) {
+		return false
+	}
+	return true
+}
+
+// usesNamespaceSelector 判断规则的作用范围是否依赖命名空间标签
+func (r Rule) usesNamespaceSelector() bool {
+	return (r.Match != nil && r.Match.NamespaceSelector != "") ||
+		(r.Exclude != nil && r.Exclude.NamespaceSelector != "")
+}
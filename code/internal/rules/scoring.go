@@ -0,0 +1,178 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BaseScore 健康评分的满分
+const BaseScore = 100
+
+// defaultSeverityWeights 未配置 scoring.severityWeights 时各严重程度的扣分
+var defaultSeverityWeights = map[string]int{
+	"critical": 20,
+	"warning":  10,
+	"info":     5,
+}
+
+// scoringSeverities 可以配置扣分的严重程度
+var scoringSeverities = []string{"critical", "error", "warning", "info"}
+
+// ScoringConfig 健康评分模型：未通过的检查项按权重扣分，同一类别的扣分不超过上限，最终分数不低于下限
+type ScoringConfig struct {
+	// 各严重程度的扣分，未配置的严重程度使用默认值（critical 20、warning 10、info 5）
+	SeverityWeights map[string]int `yaml:"severityWeights,omitempty" json:"severityWeights,omitempty"`
+	// 单条规则的扣分，优先于严重程度的扣分
+	RuleWeights map[string]int `yaml:"ruleWeights,omitempty" json:"ruleWeights,omitempty"`
+	// 评分类别：名称 -> 包含的规则和扣分上限
+	Categories map[string]ScoreCategory `yaml:"categories,omitempty" json:"categories,omitempty"`
+	// 分数下限
+	Floor int `yaml:"floor,omitempty" json:"floor,omitempty"`
+}
+
+// ScoreCategory 评分类别，类别中规则的扣分合计不超过 Cap
+type ScoreCategory struct {
+	// 属于该类别的规则ID
+	Rules []string `yaml:"rules" json:"rules"`
+	// 扣分上限
+	Cap int `yaml:"cap" json:"cap"`
+}
+
+// ScoreItem 参与评分的未通过检查项
+type ScoreItem struct {
+	// 规则ID
+	RuleID string
+	// 严重程度
+	Severity string
+}
+
+// ScoreDeduction 一次扣分，也用于报告中的评分解释，JSON字段与报告一致使用驼峰命名
+type ScoreDeduction struct {
+	// 规则ID
+	RuleID string `json:"ruleID"`
+	// 严重程度
+	Severity string `json:"severity"`
+	// 扣分来源：rule（规则权重）或 severity（严重程度权重）
+	Source string `json:"source"`
+	// 配置的扣分
+	Weight int `json:"weight"`
+	// 实际扣分，类别达到上限时小于 Weight
+	Points int `json:"points"`
+	// 所属评分类别
+	Category string `json:"category,omitempty"`
+	// 所属类别的扣分上限是否限制了本次扣分
+	Capped bool `json:"capped,omitempty"`
+}
+
+// ScoreBreakdown 健康评分及其扣分明细
+type ScoreBreakdown struct {
+	// 最终分数
+	Score int `json:"score"`
+	// 扣分明细，按检查项顺序排列
+	Deductions []ScoreDeduction `json:"deductions,omitempty"`
+	// 分数下限
+	Floor int `json:"floor,omitempty"`
+	// 是否因下限提高了分数
+	FloorApplied bool `json:"floor_applied,omitempty"`
+}
+
+// Score 按评分模型计算健康评分，config 为nil时使用默认扣分
+func (c *ScoringConfig) Score(items []ScoreItem) ScoreBreakdown {
+	if c == nil {
+		c = &ScoringConfig{}
+	}
+	ruleCategories := c.ruleCategories()
+
+	breakdown := ScoreBreakdown{Floor: c.Floor}
+	used := make(map[string]int)
+	total := 0
+	for _, item := range items {
+		deduction := ScoreDeduction{RuleID: item.RuleID, Severity: item.Severity}
+		if weight, ok := c.RuleWeights[item.RuleID]; ok {
+			deduction.Source, deduction.Weight = "rule", weight
+		} else {
+			deduction.Source, deduction.Weight = "severity", c.severityWeight(item.Severity)
+		}
+		if deduction.Weight == 0 {
+			continue
+		}
+
+		deduction.Points = deduction.Weight
+		if name, ok := ruleCategories[item.RuleID]; ok {
+			deduction.Category = name
+			if remaining := c.Categories[name].Cap - used[name]; deduction.Points > remaining {
+				deduction.Points = remaining
+				deduction.Capped = true
+			}
+			used[name] += deduction.Points
+		}
+		total += deduction.Points
+		breakdown.Deductions = append(breakdown.Deductions, deduction)
+	}
+
+	breakdown.Score = BaseScore - total
+	if breakdown.Score < c.Floor {
+		breakdown.Score = c.Floor
+		breakdown.FloorApplied = true
+	}
+	return breakdown
+}
+
+// severityWeight 返回严重程度的扣分
+func (c *ScoringConfig) severityWeight(severity string) int {
+	if weight, ok := c.SeverityWeights[severity]; ok {
+		return weight
+	}
+	return defaultSeverityWeights[severity]
+}
+
+// ruleCategories 返回规则ID到评分类别的映射，规则属于多个类别时按类别名称取第一个
+func (c *ScoringConfig) ruleCategories() map[string]string {
+	names := make([]string, 0, len(c.Categories))
+	for name := range c.Categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make(map[string]string)
+	for _, name := range names {
+		for _, ruleID := range c.Categories[name].Rules {
+			if _, exists := result[ruleID]; !exists {
+				result[ruleID] = name
+			}
+		}
+	}
+	return result
+}
+
+// validate 验证评分模型配置
+func (c *ScoringConfig) validate() error {
+	if c == nil {
+		return nil
+	}
+	for severity, weight := range c.SeverityWeights {
+		if !containsString(scoringSeverities, severity) {
+			return fmt.Errorf("scoring.severityWeights 中不支持的严重程度 %q", severity)
+		}
+		if weight < 0 {
+			return fmt.Errorf("scoring.severityWeights.%s 不能为负数", severity)
+		}
+	}
+	for ruleID, weight := range c.RuleWeights {
+		if weight < 0 {
+			return fmt.Errorf("scoring.ruleWeights.%s 不能为负数", ruleID)
+		}
+	}
+	for name, category := range c.Categories {
+		if category.Cap < 0 {
+			return fmt.Errorf("scoring.categories.%s 的 cap 不能为负数", name)
+		}
+		if len(category.Rules) == 0 {
+			return fmt.Errorf("scoring.categories.%s 没有包含任何规则", name)
+		}
+	}
+	if c.Floor < 0 || c.Floor > BaseScore {
+		return fmt.Errorf("scoring.floor 应在 0 到 %d 之间", BaseScore)
+	}
+	return nil
+}
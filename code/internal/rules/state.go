@@ -0,0 +1,153 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/homedir"
+)
+
+// stateFileVersion 状态文件格式版本
+const stateFileVersion = 1
+
+// ConditionState 表示配置了持续时间的规则在某个资源上的状态
+type ConditionState struct {
+	// 条件首次成立的时间
+	Since time.Time
+	// 条件成立时间是否仍未达到规则要求的持续时间
+	Pending bool
+}
+
+// StateStore 记录规则条件首次成立的时间，并在多次巡检之间持久化到本地文件
+type StateStore struct {
+	// 状态文件路径，为空时仅保存在内存中
+	path string
+	mu   sync.Mutex
+	// 键为 规则ID|资源标识，值为条件首次成立时间
+	conditions map[string]time.Time
+	// 自上次保存以来检查过的规则ID和状态键，用于保存时删除已不存在的资源的状态
+	trackedRules map[string]bool
+	trackedKeys  map[string]bool
+}
+
+// stateFile 状态文件内容
+type stateFile struct {
+	Version    int                  `json:"version"`
+	Conditions map[string]time.Time `json:"conditions"`
+}
+
+// NewMemoryStateStore 创建仅保存在内存中的状态存储
+func NewMemoryStateStore() *StateStore {
+	return &StateStore{
+		conditions:   make(map[string]time.Time),
+		trackedRules: make(map[string]bool),
+		trackedKeys:  make(map[string]bool),
+	}
+}
+
+// LoadStateStore 从状态文件加载条件状态，文件不存在时返回空状态
+func LoadStateStore(path string) (*StateStore, error) {
+	store := NewMemoryStateStore()
+	store.path = path
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("读取状态文件失败: %w", err)
+	}
+
+	var content stateFile
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("解析状态文件失败: %w", err)
+	}
+	if content.Version != stateFileVersion {
+		return nil, fmt.Errorf("不支持的状态文件版本: %d", content.Version)
+	}
+	for key, since := range content.Conditions {
+		store.conditions[key] = since
+	}
+
+	return store, nil
+}
+
+// DefaultStateFile 返回默认的状态文件路径 $HOME/.k8s-inspector/state.json
+func DefaultStateFile() string {
+	if home := homedir.HomeDir(); home != "" {
+		return filepath.Join(home, ".k8s-inspector", "state.json")
+	}
+	return filepath.Join(".k8s-inspector", "state.json")
+}
+
+// Save 删除自上次保存以来检查过的规则在未检查到的资源（如已删除的资源）上的状态，并将条件状态写入状态文件；
+// 未检查过的规则（如其他资源类型的规则）的状态保持不变
+func (s *StateStore) Save() error {
+	s.mu.Lock()
+	s.prune()
+	if s.path == "" {
+		s.mu.Unlock()
+		return nil
+	}
+	data, err := json.MarshalIndent(stateFile{Version: stateFileVersion, Conditions: s.conditions}, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("序列化状态失败: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("创建状态目录失败: %w", err)
+	}
+	// 先写临时文件再重命名，避免中断时留下损坏的状态文件
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("写入状态文件失败: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("写入状态文件失败: %w", err)
+	}
+	return nil
+}
+
+// track 记录本次巡检检查了规则在某个资源上的状态
+func (s *StateStore) track(ruleID, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trackedRules[ruleID] = true
+	s.trackedKeys[key] = true
+}
+
+// prune 删除检查过的规则在未检查到的资源上的状态，并开始新一轮记录，调用方需持有锁
+func (s *StateStore) prune() {
+	for key := range s.conditions {
+		ruleID, _, _ := strings.Cut(key, "|")
+		if s.trackedRules[ruleID] && !s.trackedKeys[key] {
+			delete(s.conditions, key)
+		}
+	}
+	s.trackedRules = make(map[string]bool)
+	s.trackedKeys = make(map[string]bool)
+}
+
+// observe 记录条件成立，返回首次成立时间
+func (s *StateStore) observe(key string, now time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if since, exists := s.conditions[key]; exists {
+		return since
+	}
+	s.conditions[key] = now
+	return now
+}
+
+// clear 清除条件状态
+func (s *StateStore) clear(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conditions, key)
+}
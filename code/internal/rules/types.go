@@ -20,15 +20,77 @@ type Rule struct {
 	Condition RuleCondition `yaml:"condition" json:"condition"`
 	// 修复建议
 	Remediation string `yaml:"remediation" json:"remediation"`
+	// 检查未通过时的消息模板（Go text/template），如 "{{.Kind}} {{.Name}} 只有 {{.Actual}} 个副本"；未设置时使用默认消息
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
 	// 是否启用
 	Enabled bool `yaml:"enabled" json:"enabled"`
+	// 其他语言的名称、描述和修复建议，键为语言（如 en），未设置的字段使用默认文本
+	Locales map[string]RuleText `yaml:"locales,omitempty" json:"locales,omitempty"`
+	// 标签，用于按主题筛选规则，如 security、reliability
+	Tags []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	// 规则对应的合规框架控制项，如 CIS Kubernetes Benchmark 5.2.2
+	Controls []Control `yaml:"controls,omitempty" json:"controls,omitempty"`
+	// 作用范围，只检查满足条件的资源；未设置时作用于该类别的全部资源
+	Match *ResourceSelector `yaml:"match,omitempty" json:"match,omitempty"`
+	// 排除范围，满足条件的资源不检查
+	Exclude *ResourceSelector `yaml:"exclude,omitempty" json:"exclude,omitempty"`
 	// 创建时间
 	CreatedAt time.Time `yaml:"created_at,omitempty" json:"created_at,omitempty"`
 	// 更新时间
 	UpdatedAt time.Time `yaml:"updated_at,omitempty" json:"updated_at,omitempty"`
 }
 
+// RuleText 规则在某种语言下的文本
+type RuleText struct {
+	// 规则名称
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// 规则描述
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// 修复建议
+	Remediation string `yaml:"remediation,omitempty" json:"remediation,omitempty"`
+	// 消息模板
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// Localize 返回使用指定语言文本的规则副本，该语言未设置的字段保留默认文本
+func (r Rule) Localize(lang string) Rule {
+	text, ok := r.Locales[lang]
+	if !ok {
+		return r
+	}
+	if text.Name != "" {
+		r.Name = text.Name
+	}
+	if text.Description != "" {
+		r.Description = text.Description
+	}
+	if text.Remediation != "" {
+		r.Remediation = text.Remediation
+	}
+	if text.Message != "" {
+		r.Message = text.Message
+	}
+	return r
+}
+
+// Control 表示合规框架中的一个控制项
+type Control struct {
+	// 框架标识，如 cis-kubernetes、nsa-cisa 或内部规范名称
+	Framework string `yaml:"framework" json:"framework"`
+	// 控制项编号，如 5.2.2
+	ID string `yaml:"id" json:"id"`
+	// 控制项标题
+	Title string `yaml:"title,omitempty" json:"title,omitempty"`
+}
+
+// String 返回 framework:id 形式的控制项标识
+func (c Control) String() string {
+	return c.Framework + ":" + c.ID
+}
+
 // RuleCondition 表示规则的触发条件
+// 叶子条件使用 metric/operator/threshold 描述；组合条件使用 all/any/not 嵌套子条件，
+// 同一层级只能选择其中一种写法
 type RuleCondition struct {
 	// 要检查的指标
 	Metric string `yaml:"metric" json:"metric"`
@@ -38,10 +100,59 @@ type RuleCondition struct {
 	Threshold interface{} `yaml:"threshold" json:"threshold"`
 	// 环境特定阈值
 	Thresholds map[string]interface{} `yaml:"thresholds,omitempty" json:"thresholds,omitempty"`
+	// JSONPath 表达式，metric 为 jsonpath 时从原始对象取值，如 {.spec.template.spec.hostNetwork}
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+	// 路径取值的类型（numeric、quantity、string、boolean、map），未设置时按值推断
+	ValueType string `yaml:"valueType,omitempty" json:"valueType,omitempty"`
+	// 路径返回多个值时的判定方式：all（默认）每个值都满足，any 至少一个值满足
+	FanOut string `yaml:"fanOut,omitempty" json:"fanOut,omitempty"`
+	// 路径没有值时使用的值，未设置时条件不成立
+	Default interface{} `yaml:"default,omitempty" json:"default,omitempty"`
 	// 持续时间（可选，用于某些需要持续一段时间的条件）
 	Duration *time.Duration `yaml:"duration,omitempty" json:"duration,omitempty"`
+	// 所有子条件均满足时成立
+	All []RuleCondition `yaml:"all,omitempty" json:"all,omitempty"`
+	// 任一子条件满足时成立
+	Any []RuleCondition `yaml:"any,omitempty" json:"any,omitempty"`
+	// 子条件不满足时成立
+	Not *RuleCondition `yaml:"not,omitempty" json:"not,omitempty"`
+}
+
+// IsCompound 判断是否为组合条件
+func (c RuleCondition) IsCompound() bool {
+	return len(c.All) > 0 || len(c.Any) > 0 || c.Not != nil
 }
 
+// ThresholdFor 返回条件在阈值环境 env 下使用的阈值：依次使用该环境的阈值、default 环境的阈值和通用阈值
+func (c RuleCondition) ThresholdFor(env string) interface{} {
+	if threshold, exists := c.Thresholds[env]; exists {
+		return threshold
+	}
+	if threshold, exists := c.Thresholds["default"]; exists {
+		return threshold
+	}
+	return c.Threshold
+}
+
+// ConditionResult 表示组合条件中单个叶子条件的评估结果
+type ConditionResult struct {
+	// 子条件在条件树中的路径，如 all[1].not
+	Path string `json:"path"`
+	// 检查的指标
+	Metric string `json:"metric"`
+	// 比较操作符
+	Operator string `json:"operator"`
+	// 实际值
+	ActualValue interface{} `json:"actual_value"`
+	// 期望值
+	ExpectedValue interface{} `json:"expected_value"`
+	// 叶子条件是否成立
+	Matched bool `json:"matched"`
+}
+
+// MetricSource 按指标名称返回实际值及其验证器类型，指标不存在时返回false
+type MetricSource func(metric string) (interface{}, string, bool)
+
 // RuleResult 表示规则评估结果
 type RuleResult struct {
 	// 规则ID
@@ -58,6 +169,8 @@ type RuleResult struct {
 	ExpectedValue interface{} `json:"expected_value"`
 	// 评估消息
 	Message string `json:"message"`
+	// 决定组合条件结果的叶子条件（仅组合条件）
+	Conditions []ConditionResult `json:"conditions,omitempty"`
 	// 修复建议
 	Remediation string `json:"remediation"`
 	// 严重程度
@@ -88,18 +201,48 @@ type RulesConfig struct {
 		ReloadInterval string `yaml:"reloadInterval" json:"reloadInterval"`
 		// 当前环境
 		Environment string `yaml:"environment" json:"environment"`
+		// kube-system 命名空间上表示集群环境的标签，如 inspector.k8s/environment
+		EnvironmentLabel string `yaml:"environmentLabel,omitempty" json:"environmentLabel,omitempty"`
 	} `yaml:"config" json:"config"`
 	// 集群环境映射
 	ClusterEnvironments map[string]string `yaml:"clusterEnvironments" json:"clusterEnvironments"`
+	// 按集群名称模式映射环境，按顺序使用第一个匹配的模式
+	ClusterEnvironmentPatterns []ClusterEnvironmentPattern `yaml:"clusterEnvironmentPatterns,omitempty" json:"clusterEnvironmentPatterns,omitempty"`
+	// 环境定义，用于声明环境之间的继承关系
+	Environments map[string]EnvironmentConfig `yaml:"environments,omitempty" json:"environments,omitempty"`
+	// 健康评分模型，未设置时使用默认扣分
+	Scoring *ScoringConfig `yaml:"scoring,omitempty" json:"scoring,omitempty"`
 	// 规则列表
 	Rules []Rule `yaml:"rules" json:"rules"`
 }
 
+// ClusterEnvironmentPattern 按集群名称模式映射环境，pattern 和 regex 只能设置一个
+type ClusterEnvironmentPattern struct {
+	// 通配符模式，语法同 path.Match，如 prod-*
+	Pattern string `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	// 正则表达式，需完整匹配集群名称
+	Regex string `yaml:"regex,omitempty" json:"regex,omitempty"`
+	// 匹配时使用的环境
+	Environment string `yaml:"environment" json:"environment"`
+}
+
+// EnvironmentConfig 环境定义
+type EnvironmentConfig struct {
+	// 继承的上级环境，本环境没有设置的阈值依次从上级环境查找，最后使用 default
+	Inherits string `yaml:"inherits,omitempty" json:"inherits,omitempty"`
+}
+
 // RuleFilter 用于过滤规则
 type RuleFilter struct {
 	Categories []string
 	Severities []string
 	Enabled    *bool
+	// 标签，规则包含任一标签时匹配
+	Tags []string
+	// 控制项，格式为 framework 或 framework:id，规则对应任一控制项时匹配
+	Controls []string
+	// 被检查的资源，设置后只返回 match/exclude 作用范围包含该资源的规则
+	Resource *ResourceMeta
 }
 
 // Validator 指标验证接口
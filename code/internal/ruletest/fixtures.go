@@ -0,0 +1,97 @@
+package ruletest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	metricsscheme "k8s.io/metrics/pkg/client/clientset/versioned/scheme"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/snapshot"
+)
+
+// fixtureDecoder 可解析Kubernetes内置对象和 metrics.k8s.io 指标对象的解码器
+var fixtureDecoder = newFixtureDecoder()
+
+// newFixtureDecoder 创建测试夹具解码器
+func newFixtureDecoder() runtime.Decoder {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	if err := metricsscheme.AddToScheme(scheme); err != nil {
+		panic(err)
+	}
+	return serializer.NewCodecFactory(scheme).UniversalDeserializer()
+}
+
+// loadFixtures 读取测试夹具中的对象，构造由夹具对象支撑的离线集群客户端
+// NodeMetrics、PodMetrics 对象作为指标数据，其余对象作为集群中的资源
+func loadFixtures(files []string) (*cluster.Client, error) {
+	objects := make([]runtime.Object, 0)
+	for _, file := range files {
+		decoded, err := decodeFixtureFile(file)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+
+	client, err := snapshot.NewClient("rules-test", objects)
+	if err != nil {
+		return nil, fmt.Errorf("构造测试夹具客户端失败: %w", err)
+	}
+	return client, nil
+}
+
+// decodeFixtureFile 解析测试夹具文件，支持以 --- 分隔的多文档YAML和JSON
+func decodeFixtureFile(file string) ([]runtime.Object, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("读取测试夹具失败: %w", err)
+	}
+
+	objects := make([]runtime.Object, 0)
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for index := 1; ; index++ {
+		doc, err := reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("读取测试夹具失败 (%s): %w", file, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, _, err := fixtureDecoder.Decode(doc, nil, nil)
+		if err != nil {
+			// 跳过只有注释的文档
+			if runtime.IsMissingKind(err) && isCommentOnly(doc) {
+				continue
+			}
+			return nil, fmt.Errorf("解析测试夹具失败 (%s#%d): %w", file, index, err)
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// isCommentOnly 判断YAML文档是否只包含注释
+func isCommentOnly(doc []byte) bool {
+	for _, line := range bytes.Split(doc, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 && line[0] != '#' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,294 @@
+package ruletest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/configs"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/node"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/pod"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/service"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// Diff 期望结果与实际结果的差异
+type Diff struct {
+	// 资源名称
+	Resource string
+	// 规则ID
+	RuleID string
+	// 期望结果
+	Expected string
+	// 实际结果
+	Actual string
+	// 实际检查项的描述
+	Detail string
+}
+
+// CaseResult 测试用例的执行结果
+type CaseResult struct {
+	// 套件名称
+	Suite string
+	// 用例名称
+	Name string
+	// 被检查的资源
+	Resources []string
+	// 与期望不一致的规则结果
+	Diffs []Diff
+	// 用例无法执行时的错误
+	Err error
+}
+
+// Passed 判断用例是否通过
+func (r CaseResult) Passed() bool {
+	return r.Err == nil && len(r.Diffs) == 0
+}
+
+// outcome 规则在单个资源上的实际结果
+type outcome struct {
+	status string
+	detail string
+}
+
+// resourceOutcomes 单个资源上各规则的实际结果
+type resourceOutcomes struct {
+	name  string
+	rules map[string]outcome
+}
+
+// record 记录检查项结果，同一规则产生多个检查项时（如每个容器一项）取最严重的结果
+func (r *resourceOutcomes) record(ruleID string, passed bool, pending bool, detail string) {
+	status := OutcomePass
+	if !passed {
+		status = OutcomeFail
+	} else if pending {
+		status = OutcomePending
+	}
+	if existing, ok := r.rules[ruleID]; ok && outcomeRank(existing.status) >= outcomeRank(status) {
+		return
+	}
+	r.rules[ruleID] = outcome{status: status, detail: detail}
+}
+
+// outcomeRank 结果的严重程度
+func outcomeRank(status string) int {
+	switch status {
+	case OutcomeFail:
+		return 2
+	case OutcomePending:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Run 执行测试套件中的所有用例
+func Run(suite *Suite) []CaseResult {
+	results := make([]CaseResult, 0, len(suite.Tests))
+	for i, tc := range suite.Tests {
+		name := tc.Name
+		if name == "" {
+			name = fmt.Sprintf("tests[%d]", i)
+		}
+		result := CaseResult{Suite: suite.Name, Name: name}
+		result.Resources, result.Diffs, result.Err = runCase(suite, tc)
+		results = append(results, result)
+	}
+	return results
+}
+
+// runCase 执行单个测试用例
+func runCase(suite *Suite, tc TestCase) ([]string, []Diff, error) {
+	engine, err := newCaseEngine(suite, tc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 期望中的规则必须存在，避免规则ID拼写错误导致用例永远通过
+	known := make(map[string]bool)
+	for _, rule := range engine.GetRules(rules.RuleFilter{}) {
+		known[rule.ID] = true
+	}
+	ruleIDs := make([]string, 0, len(tc.Expect))
+	for ruleID := range tc.Expect {
+		if !known[ruleID] {
+			return nil, nil, fmt.Errorf("规则 %s 不存在", ruleID)
+		}
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	sort.Strings(ruleIDs)
+
+	fixtures := make([]string, 0, len(tc.Fixtures))
+	for _, fixture := range tc.Fixtures {
+		fixtures = append(fixtures, suite.resolve(fixture))
+	}
+	client, err := loadFixtures(fixtures)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 命名空间标签选择器使用夹具中的 Namespace 对象
+	if engine.UsesNamespaceSelectors() {
+		namespaceLabels, err := client.ListNamespaceLabels(context.Background())
+		if err != nil {
+			return nil, nil, fmt.Errorf("获取命名空间标签失败: %w", err)
+		}
+		engine.SetNamespaceLabels(namespaceLabels)
+	}
+
+	all, err := analyze(tc.Kind, client, engine)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 筛选要检查的资源
+	targets := make([]resourceOutcomes, 0, len(all))
+	for _, res := range all {
+		if tc.Resource == "" || res.name == tc.Resource {
+			targets = append(targets, res)
+		}
+	}
+	if len(targets) == 0 {
+		if tc.Resource != "" {
+			return nil, nil, fmt.Errorf("测试夹具中没有 %s %s", tc.Kind, tc.Resource)
+		}
+		return nil, nil, fmt.Errorf("测试夹具中没有 %s 资源", tc.Kind)
+	}
+
+	resources := make([]string, 0, len(targets))
+	diffs := make([]Diff, 0)
+	for _, res := range targets {
+		resources = append(resources, res.name)
+		for _, ruleID := range ruleIDs {
+			expected := tc.Expect[ruleID]
+			actual, ok := res.rules[ruleID]
+			if !ok {
+				actual = outcome{status: OutcomeSkip}
+			}
+			if actual.status != expected {
+				diffs = append(diffs, Diff{
+					Resource: res.name,
+					RuleID:   ruleID,
+					Expected: expected,
+					Actual:   actual.status,
+					Detail:   actual.detail,
+				})
+			}
+		}
+	}
+	return resources, diffs, nil
+}
+
+// newCaseEngine 为用例创建规则引擎，每个用例使用独立的持续条件状态
+func newCaseEngine(suite *Suite, tc TestCase) (*rules.Engine, error) {
+	var engine *rules.Engine
+	var err error
+	if len(suite.Rules) > 0 {
+		files := make([]string, 0, len(suite.Rules))
+		for _, file := range suite.Rules {
+			files = append(files, suite.resolve(file))
+		}
+		engine, err = rules.NewEngine(files...)
+	} else {
+		engine, err = rules.NewEngineFromFS(configs.RulesFS, configs.RulePackPath(tc.Kind))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("加载规则引擎失败: %w", err)
+	}
+
+	engine.SetStateStore(rules.NewMemoryStateStore())
+	env := suite.Environment
+	if tc.Environment != "" {
+		env = tc.Environment
+	}
+	if env != "" {
+		engine.SetEnvironment(env)
+	}
+	return engine, nil
+}
+
+// analyze 通过真实的采集器和分析器检查测试夹具中的资源
+func analyze(kind string, client *cluster.Client, engine *rules.Engine) ([]resourceOutcomes, error) {
+	ctx := context.Background()
+	results := make([]resourceOutcomes, 0)
+
+	switch kind {
+	case "node":
+		nodeCollector, err := collector.NewNodeCollector(client)
+		if err != nil {
+			return nil, err
+		}
+		nodes, err := nodeCollector.GetNodes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		analyzer := node.NewNodeAnalyzer(engine, nodeCollector)
+		for i := range nodes.Items {
+			result, err := analyzer.AnalyzeNode(&nodes.Items[i])
+			if err != nil {
+				return nil, err
+			}
+			res := resourceOutcomes{name: result.NodeName, rules: make(map[string]outcome)}
+			for _, item := range result.Items {
+				res.record(item.RuleID, item.Passed, item.PendingSince != nil, item.Description)
+			}
+			results = append(results, res)
+		}
+	case "pod":
+		podCollector, err := collector.NewPodCollector(client)
+		if err != nil {
+			return nil, err
+		}
+		pods, err := podCollector.GetPods(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		analyzer := pod.NewPodAnalyzer(engine)
+		for i := range pods.Items {
+			result, err := analyzer.AnalyzePod(&pods.Items[i])
+			if err != nil {
+				return nil, err
+			}
+			res := resourceOutcomes{name: result.Namespace + "/" + result.PodName, rules: make(map[string]outcome)}
+			for _, item := range result.Items {
+				res.record(item.RuleID, item.Passed, item.PendingSince != nil, item.Description)
+			}
+			results = append(results, res)
+		}
+	case "deployment":
+		deployments, err := collector.NewDeploymentCollector(client).GetDeployments(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		analyzer := deployment.NewDeploymentAnalyzer(engine, nil)
+		for _, dep := range deployments {
+			result := analyzer.AnalyzeDeployment(dep)
+			res := resourceOutcomes{name: result.Namespace + "/" + result.DeploymentName, rules: make(map[string]outcome)}
+			for _, item := range result.Items {
+				res.record(item.RuleID, item.Passed, item.PendingSince != nil, item.Description)
+			}
+			results = append(results, res)
+		}
+	case "service":
+		services, err := collector.NewServiceCollector(client).GetServices(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		analyzer := service.NewServiceAnalyzer(engine, nil)
+		for i := range services {
+			result := analyzer.AnalyzeService(&services[i])
+			res := resourceOutcomes{name: result.Namespace + "/" + result.ServiceName, rules: make(map[string]outcome)}
+			for _, item := range result.Items {
+				res.record(item.RuleID, item.Passed, item.PendingSince != nil, item.Description)
+			}
+			results = append(results, res)
+		}
+	default:
+		return nil, fmt.Errorf("不支持的资源类型: %s", kind)
+	}
+
+	return results, nil
+}
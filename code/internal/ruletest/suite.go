@@ -0,0 +1,166 @@
+// Package ruletest 实现规则单元测试：将测试夹具中的Kubernetes对象交给真实的采集器、分析器和规则引擎，
+// 并与测试套件中期望的规则结果比较
+package ruletest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// 期望的规则结果
+const (
+	// OutcomePass 规则检查通过
+	OutcomePass = "pass"
+	// OutcomeFail 规则检查未通过
+	OutcomeFail = "fail"
+	// OutcomePending 条件成立但未达到规则要求的持续时间
+	OutcomePending = "pending"
+	// OutcomeSkip 规则没有对该资源产生检查项
+	OutcomeSkip = "skip"
+)
+
+// Suite 规则测试套件
+type Suite struct {
+	// 套件名称
+	Name string `yaml:"name"`
+	// 规则文件或目录，相对于套件文件；为空时使用各资源类型的内置规则包
+	Rules []string `yaml:"rules,omitempty"`
+	// 默认环境，用于选择环境阈值
+	Environment string `yaml:"environment,omitempty"`
+	// 测试用例
+	Tests []TestCase `yaml:"tests"`
+
+	// 套件文件路径
+	path string
+}
+
+// TestCase 单个测试用例
+type TestCase struct {
+	// 用例名称
+	Name string `yaml:"name"`
+	// 资源类型：node、pod、deployment、service
+	Kind string `yaml:"kind"`
+	// 测试夹具，包含Kubernetes对象的YAML或JSON清单，相对于套件文件
+	Fixtures []string `yaml:"fixtures"`
+	// 要检查的资源，节点为名称，其他资源为 命名空间/名称；为空时检查夹具中该类型的全部资源
+	Resource string `yaml:"resource,omitempty"`
+	// 环境，覆盖套件的默认环境
+	Environment string `yaml:"environment,omitempty"`
+	// 规则ID -> 期望结果（pass、fail、pending、skip）
+	Expect map[string]string `yaml:"expect"`
+}
+
+// Path 返回套件文件路径
+func (s *Suite) Path() string {
+	return s.path
+}
+
+// resolve 将相对于套件文件的路径转换为可访问的路径
+func (s *Suite) resolve(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(s.path), path)
+}
+
+// LoadSuite 加载测试套件文件
+func LoadSuite(path string) (*Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取测试套件失败: %w", err)
+	}
+
+	suite := &Suite{path: path}
+	if err := yaml.UnmarshalStrict(data, suite); err != nil {
+		return nil, fmt.Errorf("解析测试套件失败 (%s): %w", path, err)
+	}
+	if suite.Name == "" {
+		suite.Name = filepath.Base(path)
+	}
+	if err := suite.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return suite, nil
+}
+
+// LoadSuites 加载测试套件，路径可以是文件或目录（加载目录中的 .yaml/.yml 文件，不递归）
+func LoadSuites(paths ...string) ([]*Suite, error) {
+	suites := make([]*Suite, 0)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("读取测试套件失败: %w", err)
+		}
+
+		files := []string{path}
+		if info.IsDir() {
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				return nil, fmt.Errorf("读取测试套件目录失败: %w", err)
+			}
+			files = files[:0]
+			for _, entry := range entries {
+				ext := strings.ToLower(filepath.Ext(entry.Name()))
+				if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+					continue
+				}
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+			sort.Strings(files)
+		}
+
+		for _, file := range files {
+			suite, err := LoadSuite(file)
+			if err != nil {
+				return nil, err
+			}
+			suites = append(suites, suite)
+		}
+	}
+	return suites, nil
+}
+
+// validate 验证套件内容
+func (s *Suite) validate() error {
+	if len(s.Tests) == 0 {
+		return fmt.Errorf("测试套件没有测试用例")
+	}
+	for i, tc := range s.Tests {
+		name := tc.Name
+		if name == "" {
+			name = fmt.Sprintf("tests[%d]", i)
+		}
+		if !isSupportedKind(tc.Kind) {
+			return fmt.Errorf("用例 %s: 不支持的资源类型 %q (可用: node, pod, deployment, service)", name, tc.Kind)
+		}
+		if len(tc.Fixtures) == 0 {
+			return fmt.Errorf("用例 %s: 缺少测试夹具", name)
+		}
+		if len(tc.Expect) == 0 {
+			return fmt.Errorf("用例 %s: 缺少期望结果", name)
+		}
+		for ruleID, outcome := range tc.Expect {
+			switch outcome {
+			case OutcomePass, OutcomeFail, OutcomePending, OutcomeSkip:
+			default:
+				return fmt.Errorf("用例 %s: 规则 %s 的期望结果 %q 无效 (可用: pass, fail, pending, skip)", name, ruleID, outcome)
+			}
+		}
+	}
+	return nil
+}
+
+// isSupportedKind 判断资源类型是否支持
+func isSupportedKind(kind string) bool {
+	switch kind {
+	case "node", "pod", "deployment", "service":
+		return true
+	default:
+		return false
+	}
+}
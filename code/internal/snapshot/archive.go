@@ -0,0 +1,330 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+)
+
+// APIVersion 快照清单的版本，快照格式发生不兼容变更时升级
+const APIVersion = "inspector.k8s/v1"
+
+// ManifestKind 快照清单的类型
+const ManifestKind = "ClusterSnapshot"
+
+// ManifestFile 快照归档或目录中清单文件的名称
+const ManifestFile = "manifest.json"
+
+// Manifest 快照清单，描述快照来自哪个集群以及采集的内容
+type Manifest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// 集群名称，离线分析时作为报告中的集群名称并用于确定环境
+	ClusterName string `json:"clusterName"`
+	// 集群版本
+	ServerVersion string `json:"serverVersion,omitempty"`
+	// 采集时间
+	CapturedAt time.Time `json:"capturedAt"`
+	// 采集的命名空间，为空表示所有命名空间
+	Namespace string `json:"namespace,omitempty"`
+	// 是否已对敏感信息脱敏
+	Redacted bool `json:"redacted"`
+	// 各文件中的对象数量
+	Resources map[string]int `json:"resources"`
+	// 采集过程中的警告，如未安装 metrics-server 时无法采集指标
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// CaptureOptions 采集快照的选项
+type CaptureOptions struct {
+	// 写入清单的集群名称
+	ClusterName string
+	// 只采集该命名空间的资源，为空表示所有命名空间；节点和节点指标总是全部采集
+	Namespace string
+	// 是否对敏感信息脱敏，见 Redact
+	Redact bool
+}
+
+// snapshotResource 快照中的一类资源
+type snapshotResource struct {
+	// 归档中的文件名（不含扩展名）
+	name string
+	// 获取资源
+	list func(ctx context.Context, client *cluster.Client, namespace string) ([]runtime.Object, error)
+	// 获取失败时只记录警告，用于依赖 metrics-server 的指标
+	optional bool
+}
+
+// snapshotResources 快照采集的资源，按写入归档的顺序排列
+var snapshotResources = []snapshotResource{
+	{name: "namespaces", list: func(ctx context.Context, client *cluster.Client, namespace string) ([]runtime.Object, error) {
+		items, err := client.ListRawNamespaces(ctx)
+		if namespace != "" {
+			for i := range items {
+				if items[i].Name == namespace {
+					return toObjects(items[i : i+1]), err
+				}
+			}
+			return nil, err
+		}
+		return toObjects(items), err
+	}},
+	{name: "nodes", list: func(ctx context.Context, client *cluster.Client, _ string) ([]runtime.Object, error) {
+		items, err := client.ListRawNodes(ctx)
+		return toObjects(items), err
+	}},
+	{name: "pods", list: func(ctx context.Context, client *cluster.Client, namespace string) ([]runtime.Object, error) {
+		items, err := client.ListRawPods(ctx, namespace)
+		return toObjects(items), err
+	}},
+	{name: "deployments", list: func(ctx context.Context, client *cluster.Client, namespace string) ([]runtime.Object, error) {
+		items, err := client.ListRawDeployments(ctx, namespace)
+		return toObjects(items), err
+	}},
+	{name: "services", list: func(ctx context.Context, client *cluster.Client, namespace string) ([]runtime.Object, error) {
+		items, err := client.ListRawServices(ctx, namespace)
+		return toObjects(items), err
+	}},
+	{name: "endpoints", list: func(ctx context.Context, client *cluster.Client, namespace string) ([]runtime.Object, error) {
+		items, err := client.ListRawEndpoints(ctx, namespace)
+		return toObjects(items), err
+	}},
+	{name: "events", list: func(ctx context.Context, client *cluster.Client, namespace string) ([]runtime.Object, error) {
+		items, err := client.ListRawEvents(ctx, namespace)
+		return toObjects(items), err
+	}},
+	{name: "node-metrics", optional: true, list: func(ctx context.Context, client *cluster.Client, _ string) ([]runtime.Object, error) {
+		items, err := client.ListRawNodeMetrics(ctx)
+		return toObjects(items), err
+	}},
+	{name: "pod-metrics", optional: true, list: func(ctx context.Context, client *cluster.Client, namespace string) ([]runtime.Object, error) {
+		items, err := client.ListRawPodMetrics(ctx, namespace)
+		return toObjects(items), err
+	}},
+}
+
+// toObjects 将 ListRaw* 返回的对象切片转换为 runtime.Object 切片
+func toObjects[T any, PT interface {
+	*T
+	runtime.Object
+}](items []T) []runtime.Object {
+	objects := make([]runtime.Object, 0, len(items))
+	for i := range items {
+		objects = append(objects, PT(&items[i]))
+	}
+	return objects
+}
+
+// Capture 通过集群客户端采集命名空间、节点、Pod、Deployment、Service、Endpoints、事件以及节点和Pod指标，
+// 以 gzip 压缩的 tar 归档写入 w：manifest.json 为快照清单，每类资源一个 kubectl get -o json 格式的 List 文件
+func Capture(ctx context.Context, client *cluster.Client, w io.Writer, options CaptureOptions) (*Manifest, error) {
+	manifest := &Manifest{
+		APIVersion:  APIVersion,
+		Kind:        ManifestKind,
+		ClusterName: options.ClusterName,
+		CapturedAt:  time.Now().UTC().Truncate(time.Second),
+		Namespace:   options.Namespace,
+		Redacted:    options.Redact,
+		Resources:   make(map[string]int, len(snapshotResources)),
+	}
+	if version, err := client.GetServerVersion(); err == nil {
+		manifest.ServerVersion = version
+	} else {
+		manifest.Warnings = append(manifest.Warnings, err.Error())
+	}
+
+	files := make([]archiveFile, 0, len(snapshotResources)+1)
+	for _, resource := range snapshotResources {
+		objects, err := resource.list(ctx, client, options.Namespace)
+		if err != nil {
+			if resource.optional {
+				manifest.Warnings = append(manifest.Warnings, fmt.Sprintf("采集 %s 失败: %v", resource.name, err))
+				continue
+			}
+			return nil, fmt.Errorf("采集 %s 失败: %w", resource.name, err)
+		}
+		data, err := encodeList(objects, options.Redact)
+		if err != nil {
+			return nil, fmt.Errorf("编码 %s 失败: %w", resource.name, err)
+		}
+		files = append(files, archiveFile{name: resource.name + ".json", data: data})
+		manifest.Resources[resource.name] = len(objects)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("编码快照清单失败: %w", err)
+	}
+	files = append([]archiveFile{{name: ManifestFile, data: data}}, files...)
+	if err := writeArchive(w, files, manifest.CapturedAt); err != nil {
+		return nil, fmt.Errorf("写入快照归档失败: %w", err)
+	}
+	return manifest, nil
+}
+
+// archiveFile 归档中的文件
+type archiveFile struct {
+	name string
+	data []byte
+}
+
+// encodeList 将对象编码为 kubectl get -o json 格式的 List，对象的 apiVersion、kind 按类型补全
+func encodeList(objects []runtime.Object, redact bool) ([]byte, error) {
+	items := make([]runtime.Object, 0, len(objects))
+	for _, obj := range objects {
+		gvks, _, err := scheme.ObjectKinds(obj)
+		if err != nil {
+			return nil, err
+		}
+		obj = obj.DeepCopyObject()
+		obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+		if redact {
+			Redact(obj)
+		}
+		items = append(items, obj)
+	}
+	return json.MarshalIndent(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	}, "", "  ")
+}
+
+// writeArchive 将文件写入 gzip 压缩的 tar 归档
+func writeArchive(w io.Writer, files []archiveFile, modTime time.Time) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	for _, file := range files {
+		header := &tar.Header{
+			Name:    file.name,
+			Mode:    0644,
+			Size:    int64(len(file.data)),
+			ModTime: modTime,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// isArchive 判断文件是否为 gzip 压缩的快照归档
+func isArchive(file string) bool {
+	f, err := os.Open(file)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	magic := make([]byte, 2)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return false
+	}
+	return magic[0] == 0x1f && magic[1] == 0x8b
+}
+
+// readArchive 读取快照归档中的清单和对象，归档中的 .json、.yaml、.yml 文件按名称顺序解析
+func readArchive(file string) ([]runtime.Object, *Manifest, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取快照归档失败: %w", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取快照归档失败: %w", err)
+	}
+	defer gz.Close()
+
+	contents := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("读取快照归档失败: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg || !isSnapshotFile(header.Name) {
+			continue
+		}
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, tr); err != nil {
+			return nil, nil, fmt.Errorf("读取快照归档失败 (%s): %w", header.Name, err)
+		}
+		contents[header.Name] = buf.Bytes()
+	}
+
+	var manifest *Manifest
+	names := make([]string, 0, len(contents))
+	for name := range contents {
+		if path.Base(name) == ManifestFile {
+			manifest, err = parseManifest(contents[name])
+			if err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	objects := make([]runtime.Object, 0)
+	for _, name := range names {
+		decoded, err := decodeObjects(file+"/"+name, contents[name])
+		if err != nil {
+			return nil, nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, manifest, nil
+}
+
+// readManifestFile 读取快照目录中的清单文件
+func readManifestFile(file string) (*Manifest, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("读取快照清单失败: %w", err)
+	}
+	return parseManifest(data)
+}
+
+// parseManifest 解析快照清单并检查版本
+func parseManifest(data []byte) (*Manifest, error) {
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("解析快照清单失败: %w", err)
+	}
+	if manifest.Kind != ManifestKind || manifest.APIVersion != APIVersion {
+		return nil, fmt.Errorf("不支持的快照格式 %s/%s，当前支持 %s/%s", manifest.APIVersion, manifest.Kind, APIVersion, ManifestKind)
+	}
+	return &manifest, nil
+}
+
+// isSnapshotFile 判断文件是否为快照中的对象或清单文件
+func isSnapshotFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package snapshot
+
+import (
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RedactedValue 脱敏后替换敏感内容的值
+const RedactedValue = "<redacted>"
+
+// sensitiveAnnotationKeywords 注解键包含这些关键字（不区分大小写）时视为敏感注解
+var sensitiveAnnotationKeywords = []string{"password", "passwd", "secret", "token", "credential", "apikey", "api-key", "private-key"}
+
+// Redact 对快照对象中的敏感信息脱敏：删除包含完整清单的 last-applied-configuration 注解，
+// 替换敏感注解的值以及Pod和Pod模板中容器环境变量的明文值（valueFrom 引用保留）
+func Redact(obj runtime.Object) {
+	if accessor, err := meta.Accessor(obj); err == nil {
+		redactAnnotations(accessor)
+	}
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		redactPodSpec(&o.Spec)
+	case *appsv1.Deployment:
+		redactAnnotations(&o.Spec.Template.ObjectMeta)
+		redactPodSpec(&o.Spec.Template.Spec)
+	}
+}
+
+// redactAnnotations 脱敏对象的注解
+func redactAnnotations(accessor metav1.Object) {
+	annotations := accessor.GetAnnotations()
+	if len(annotations) == 0 {
+		return
+	}
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+	for key := range annotations {
+		if isSensitiveAnnotation(key) {
+			annotations[key] = RedactedValue
+		}
+	}
+	accessor.SetAnnotations(annotations)
+}
+
+// isSensitiveAnnotation 判断注解是否可能包含敏感信息
+func isSensitiveAnnotation(key string) bool {
+	key = strings.ToLower(key)
+	for _, keyword := range sensitiveAnnotationKeywords {
+		if strings.Contains(key, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactPodSpec 替换所有容器环境变量的明文值
+func redactPodSpec(spec *corev1.PodSpec) {
+	redactEnv := func(env []corev1.EnvVar) {
+		for i := range env {
+			if env[i].Value != "" {
+				env[i].Value = RedactedValue
+			}
+		}
+	}
+	for i := range spec.InitContainers {
+		redactEnv(spec.InitContainers[i].Env)
+	}
+	for i := range spec.Containers {
+		redactEnv(spec.Containers[i].Env)
+	}
+	for i := range spec.EphemeralContainers {
+		redactEnv(spec.EphemeralContainers[i].Env)
+	}
+}
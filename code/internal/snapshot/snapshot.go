@@ -0,0 +1,295 @@
+// Package snapshot 从集群快照文件构造离线的集群客户端，使采集器和分析器无需访问 API Server 即可分析集群状态。
+// 快照可以是 inspector snapshot 生成的归档、kubectl get -o json/yaml 导出的对象或列表文件，也可以是包含这些文件的目录。
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	metricsscheme "k8s.io/metrics/pkg/client/clientset/versioned/scheme"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+)
+
+// scheme 快照中可识别为内置类型的对象，包括Kubernetes内置对象和 metrics.k8s.io 指标对象；其余对象按非结构化对象处理
+var scheme = newScheme()
+
+// newScheme 创建快照使用的类型注册表
+func newScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		panic(err)
+	}
+	if err := metricsscheme.AddToScheme(s); err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Load 读取快照归档、文件或目录，构造由快照对象支撑的集群客户端。目录和归档中的 .json、.yaml、.yml 文件按路径顺序读取，
+// 同一对象出现多次时以后读取的为准；manifest.json 为 inspector snapshot 生成的快照清单。
+// 客户端的 ContextName 为清单中的集群名称，没有清单时为快照名称（目录名或不含扩展名的文件名）
+func Load(path string) (*cluster.Client, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取快照失败: %w", err)
+	}
+
+	var objects []runtime.Object
+	var manifest *Manifest
+	switch {
+	case info.IsDir():
+		objects, manifest, err = readDir(path)
+	case isArchive(path):
+		objects, manifest, err = readArchive(path)
+	default:
+		objects, err = DecodeFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("快照 %s 中没有Kubernetes对象", path)
+	}
+
+	name := filepath.Base(filepath.Clean(path))
+	if !info.IsDir() {
+		name = strings.TrimSuffix(name, ".gz")
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	if manifest != nil && manifest.ClusterName != "" {
+		name = manifest.ClusterName
+	}
+	client, err := NewClient(name, objects)
+	if err != nil {
+		return nil, err
+	}
+	if manifest != nil {
+		client.Store.SetServerVersion(manifest.ServerVersion)
+	}
+	return client, nil
+}
+
+// readDir 读取快照目录中的对象和清单
+func readDir(dir string) ([]runtime.Object, *Manifest, error) {
+	var manifest *Manifest
+	files := make([]string, 0)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !isSnapshotFile(path) {
+			return nil
+		}
+		if entry.Name() == ManifestFile {
+			manifest, err = readManifestFile(path)
+			return err
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("读取快照目录失败: %w", err)
+	}
+
+	objects := make([]runtime.Object, 0)
+	for _, file := range files {
+		decoded, err := DecodeFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, manifest, nil
+}
+
+// DecodeFile 解析快照文件中的对象，支持以 --- 分隔的多文档YAML和JSON；List 对象展开为其中的各个对象，
+// 内置类型解析为对应的结构体，其他类型（如CRD）保留为非结构化对象
+func DecodeFile(file string) ([]runtime.Object, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("读取快照文件失败: %w", err)
+	}
+	return decodeObjects(file, data)
+}
+
+// decodeObjects 解析快照文件内容，file 用于错误信息
+func decodeObjects(file string, data []byte) ([]runtime.Object, error) {
+	objects := make([]runtime.Object, 0)
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for index := 1; ; index++ {
+		doc, err := reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("读取快照文件失败 (%s): %w", file, err)
+		}
+		decoded, err := DecodeDocument(doc)
+		if err != nil {
+			return nil, fmt.Errorf("解析快照文件失败 (%s#%d): %w", file, index, err)
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, nil
+}
+
+// DecodeDocument 解析单个YAML或JSON文档，空文档和只有注释的文档返回空结果；List 对象展开为其中的各个对象
+func DecodeDocument(doc []byte) ([]runtime.Object, error) {
+	data, err := utilyaml.ToJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+	var content map[string]interface{}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, nil
+	}
+
+	obj := &unstructured.Unstructured{Object: content}
+	if !obj.IsList() {
+		typed, err := convertObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		return []runtime.Object{typed}, nil
+	}
+
+	list, err := obj.ToList()
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]runtime.Object, 0, len(list.Items))
+	for i := range list.Items {
+		typed, err := convertObject(&list.Items[i])
+		if err != nil {
+			return nil, fmt.Errorf("items[%d]: %w", i, err)
+		}
+		objects = append(objects, typed)
+	}
+	return objects, nil
+}
+
+// convertObject 将非结构化对象转换为已注册的内置类型，未注册的类型原样返回
+func convertObject(obj *unstructured.Unstructured) (runtime.Object, error) {
+	gvk := obj.GroupVersionKind()
+	if gvk.Kind == "" || gvk.Version == "" {
+		return nil, fmt.Errorf("对象缺少 apiVersion 或 kind")
+	}
+	if obj.GetName() == "" {
+		return nil, fmt.Errorf("%s 对象缺少 metadata.name", gvk.Kind)
+	}
+	typed, err := scheme.New(gvk)
+	if err != nil {
+		if runtime.IsNotRegisteredError(err) {
+			return obj, nil
+		}
+		return nil, err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, typed); err != nil {
+		return nil, fmt.Errorf("转换 %s %s 失败: %w", gvk.Kind, obj.GetName(), err)
+	}
+	typed.GetObjectKind().SetGroupVersionKind(gvk)
+	return typed, nil
+}
+
+// NewClient 用给定对象构造离线的集群客户端：各读取方法从对象集合中按类型、命名空间和名称查找对象，
+// NodeMetrics、PodMetrics 作为指标数据，非内置类型（如CRD）的对象通过 inspect resource 读取，资源类型由对象推断。
+// 未设置 apiVersion/kind 的内置类型对象按类型补全；同一对象出现多次时以最后一个为准
+func NewClient(name string, objects []runtime.Object) (*cluster.Client, error) {
+	for _, obj := range objects {
+		if !obj.GetObjectKind().GroupVersionKind().Empty() {
+			continue
+		}
+		kinds, _, err := scheme.ObjectKinds(obj)
+		if err != nil {
+			return nil, fmt.Errorf("识别对象类型失败: %w", err)
+		}
+		obj.GetObjectKind().SetGroupVersionKind(kinds[0])
+	}
+	objects, err := dedupe(objects)
+	if err != nil {
+		return nil, err
+	}
+	return cluster.NewOfflineClient(name, cluster.NewObjectStore(objects, apiResources(objects))), nil
+}
+
+// dedupe 按 GroupVersionKind、命名空间和名称去重，保留最后出现的对象并保持首次出现的顺序
+func dedupe(objects []runtime.Object) ([]runtime.Object, error) {
+	type objectKey struct {
+		gvk       schema.GroupVersionKind
+		namespace string
+		name      string
+	}
+	index := make(map[objectKey]int, len(objects))
+	result := make([]runtime.Object, 0, len(objects))
+	for _, obj := range objects {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, fmt.Errorf("读取对象元数据失败: %w", err)
+		}
+		key := objectKey{gvk: obj.GetObjectKind().GroupVersionKind(), namespace: accessor.GetNamespace(), name: accessor.GetName()}
+		if i, exists := index[key]; exists {
+			result[i] = obj
+			continue
+		}
+		index[key] = len(result)
+		result = append(result, obj)
+	}
+	return result, nil
+}
+
+// apiResources 按对象的类型生成 discovery 资源列表，资源名按 Kind 推断，带命名空间的对象所属类型视为命名空间级资源
+func apiResources(objects []runtime.Object) []*metav1.APIResourceList {
+	lists := make([]*metav1.APIResourceList, 0)
+	byGroupVersion := make(map[string]*metav1.APIResourceList)
+	for _, obj := range objects {
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			continue
+		}
+		list, exists := byGroupVersion[gvk.GroupVersion().String()]
+		if !exists {
+			list = &metav1.APIResourceList{GroupVersion: gvk.GroupVersion().String()}
+			byGroupVersion[list.GroupVersion] = list
+			lists = append(lists, list)
+		}
+
+		plural, singular := meta.UnsafeGuessKindToResource(gvk)
+		found := false
+		for i := range list.APIResources {
+			if list.APIResources[i].Kind == gvk.Kind {
+				list.APIResources[i].Namespaced = list.APIResources[i].Namespaced || accessor.GetNamespace() != ""
+				found = true
+				break
+			}
+		}
+		if !found {
+			list.APIResources = append(list.APIResources, metav1.APIResource{
+				Name:         plural.Resource,
+				SingularName: singular.Resource,
+				Kind:         gvk.Kind,
+				Namespaced:   accessor.GetNamespace() != "",
+				Verbs:        metav1.Verbs{"get", "list"},
+			})
+		}
+	}
+	return lists
+}
@@ -0,0 +1,254 @@
+package waiver
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// AnnotationPrefix 资源上豁免注解的前缀，完整键为 inspector.io/waive-<规则ID>
+const AnnotationPrefix = "inspector.io/waive-"
+
+// dateLayout 豁免到期日期的格式
+const dateLayout = "2006-01-02"
+
+// 豁免来源
+const (
+	SourceFile       = "file"
+	SourceAnnotation = "annotation"
+)
+
+// Waiver 表示对某条规则在某些资源上的豁免
+type Waiver struct {
+	// 被豁免的规则ID
+	RuleID string `yaml:"rule" json:"rule"`
+	// 资源类型（Node、Pod、Deployment、Service），为空时匹配全部类型
+	Kind string `yaml:"kind,omitempty" json:"kind,omitempty"`
+	// 资源匹配模式，命名空间资源为 namespace/name，集群级资源为 name，语法见 matchResource
+	Resource string `yaml:"resource" json:"resource"`
+	// 豁免原因
+	Reason string `yaml:"reason" json:"reason"`
+	// 负责人
+	Owner string `yaml:"owner" json:"owner"`
+	// 到期日期，格式为 YYYY-MM-DD，当天结束前有效
+	Expires string `yaml:"expires" json:"expires"`
+	// 豁免来源，file 或 annotation
+	Source string `yaml:"-" json:"source"`
+
+	// 解析后的到期时间（到期日期次日零点）
+	expiresAt time.Time
+}
+
+// Config 豁免文件内容
+type Config struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Waivers    []Waiver `yaml:"waivers"`
+}
+
+// Invalid 表示无法解析的豁免注解
+type Invalid struct {
+	// 资源类型
+	Kind string
+	// 资源标识
+	Resource string
+	// 注解键
+	Annotation string
+	// 错误原因
+	Err error
+}
+
+// Set 豁免集合，包含豁免文件和资源注解中的豁免
+type Set struct {
+	waivers []Waiver
+	invalid []Invalid
+}
+
+// NewSet 创建空的豁免集合
+func NewSet() *Set {
+	return &Set{}
+}
+
+// LoadFile 从豁免文件加载豁免，每条豁免都必须包含规则、资源、原因、负责人和到期日期
+func LoadFile(filePath string) (*Set, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("读取豁免文件失败: %w", err)
+	}
+
+	var config Config
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.SetStrict(true)
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("解析豁免文件失败: %w", err)
+	}
+	if config.Kind != "" && config.Kind != "WaiverConfig" {
+		return nil, fmt.Errorf("豁免文件类型应为 WaiverConfig，实际为 %s", config.Kind)
+	}
+
+	set := NewSet()
+	for i, w := range config.Waivers {
+		w.Source = SourceFile
+		if err := w.init(); err != nil {
+			return nil, fmt.Errorf("豁免文件第 %d 条豁免无效: %w", i+1, err)
+		}
+		set.waivers = append(set.waivers, w)
+	}
+	return set, nil
+}
+
+// init 验证必填字段并解析到期日期
+func (w *Waiver) init() error {
+	var missing []string
+	if w.RuleID == "" {
+		missing = append(missing, "rule")
+	}
+	if w.Resource == "" {
+		missing = append(missing, "resource")
+	}
+	if w.Reason == "" {
+		missing = append(missing, "reason")
+	}
+	if w.Owner == "" {
+		missing = append(missing, "owner")
+	}
+	if w.Expires == "" {
+		missing = append(missing, "expires")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("缺少必填字段 %s", strings.Join(missing, ", "))
+	}
+
+	if err := validatePattern(w.Resource); err != nil {
+		return fmt.Errorf("无效的资源匹配模式 %q: %v", w.Resource, err)
+	}
+	expires, err := time.ParseInLocation(dateLayout, w.Expires, time.Local)
+	if err != nil {
+		return fmt.Errorf("无效的到期日期 %q，格式应为 YYYY-MM-DD", w.Expires)
+	}
+	w.expiresAt = expires.AddDate(0, 0, 1)
+	return nil
+}
+
+// AddAnnotations 从资源注解中读取豁免，注解值需包含 reason、owner 和 expires，
+// 如 {"reason": "批处理任务无需探针", "owner": "team-data", "expires": "2026-12-31"}；
+// 无法解析的注解记录为无效豁免
+func (s *Set) AddAnnotations(kind, resource string, annotations map[string]string) {
+	for key, value := range annotations {
+		if !strings.HasPrefix(key, AnnotationPrefix) {
+			continue
+		}
+
+		w := Waiver{
+			RuleID:   strings.TrimPrefix(key, AnnotationPrefix),
+			Kind:     kind,
+			Resource: resource,
+			Source:   SourceAnnotation,
+		}
+		var fields struct {
+			Reason  string `yaml:"reason"`
+			Owner   string `yaml:"owner"`
+			Expires string `yaml:"expires"`
+		}
+		err := yaml.Unmarshal([]byte(value), &fields)
+		if err == nil {
+			w.Reason, w.Owner, w.Expires = fields.Reason, fields.Owner, fields.Expires
+			err = w.init()
+		}
+		if err != nil {
+			s.invalid = append(s.invalid, Invalid{Kind: kind, Resource: resource, Annotation: key, Err: err})
+			continue
+		}
+		s.waivers = append(s.waivers, w)
+	}
+}
+
+// Invalid 返回无法解析的豁免注解
+func (s *Set) Invalid() []Invalid {
+	if s == nil {
+		return nil
+	}
+	return s.invalid
+}
+
+// Lookup 查找匹配规则和资源的豁免，优先返回仍然有效的豁免；
+// 只有已过期的豁免匹配时 active 为 false
+func (s *Set) Lookup(ruleID, kind, resource string, now time.Time) (w *Waiver, active bool) {
+	if s == nil {
+		return nil, false
+	}
+	var expired *Waiver
+	for i := range s.waivers {
+		candidate := &s.waivers[i]
+		if !candidate.Matches(ruleID, kind, resource) {
+			continue
+		}
+		if !candidate.Expired(now) {
+			return candidate, true
+		}
+		if expired == nil {
+			expired = candidate
+		}
+	}
+	return expired, false
+}
+
+// Matches 判断豁免是否作用于指定规则和资源
+func (w *Waiver) Matches(ruleID, kind, resource string) bool {
+	if w.RuleID != ruleID {
+		return false
+	}
+	if w.Kind != "" && !strings.EqualFold(w.Kind, kind) {
+		return false
+	}
+	return matchResource(w.Resource, resource)
+}
+
+// matchResource 按命名空间和名称分别匹配资源标识：模式为 namespace/name 时分别匹配命名空间和名称，
+// 只匹配命名空间资源；模式不含 / 时只匹配名称，作用于任意命名空间中的资源和集群级资源，如 * 匹配所有资源。
+// 命名空间和名称中的 *、? 和 [...] 语法同 path.Match
+func matchResource(pattern, resource string) bool {
+	namespace, name, namespaced := strings.Cut(resource, "/")
+	if !namespaced {
+		namespace, name = "", resource
+	}
+
+	namespacePattern, namePattern, hasNamespace := strings.Cut(pattern, "/")
+	if !hasNamespace {
+		matched, err := path.Match(pattern, name)
+		return err == nil && matched
+	}
+	if !namespaced {
+		return false
+	}
+	namespaceMatched, err := path.Match(namespacePattern, namespace)
+	if err != nil || !namespaceMatched {
+		return false
+	}
+	nameMatched, err := path.Match(namePattern, name)
+	return err == nil && nameMatched
+}
+
+// validatePattern 验证资源匹配模式：最多包含一个 /，命名空间和名称均为有效的通配符模式
+func validatePattern(pattern string) error {
+	parts := strings.Split(pattern, "/")
+	if len(parts) > 2 {
+		return fmt.Errorf("最多包含一个 /，格式为 namespace/name 或 name")
+	}
+	for _, part := range parts {
+		if _, err := path.Match(part, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Expired 判断豁免在指定时间是否已过期
+func (w *Waiver) Expired(now time.Time) bool {
+	return !now.Before(w.expiresAt)
+}
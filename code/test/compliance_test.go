@@ -0,0 +1,250 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/configs"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/waiver"
+)
+
+// ruleIDs 返回规则ID列表
+func ruleIDs(rulesList []rules.Rule) []string {
+	ids := make([]string, 0, len(rulesList))
+	for _, rule := range rulesList {
+		ids = append(ids, rule.ID)
+	}
+	return ids
+}
+
+// TestRuleFilterTagsAndControls 测试按标签和控制项筛选规则
+func TestRuleFilterTagsAndControls(t *testing.T) {
+	engine, err := rules.NewEngine(filepath.Join("testdata", "compliance_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		filter   rules.RuleFilter
+		expected string
+	}{
+		{name: "标签", filter: rules.RuleFilter{Tags: []string{"security"}}, expected: "host-network,host-pid"},
+		{name: "多个标签", filter: rules.RuleFilter{Tags: []string{"governance", "Reliability"}}, expected: "owner-label,min-replicas"},
+		{name: "框架", filter: rules.RuleFilter{Controls: []string{"internal"}}, expected: "owner-label,host-network"},
+		{name: "单个控制项", filter: rules.RuleFilter{Controls: []string{"CIS-Kubernetes:5.2.3"}}, expected: "host-pid"},
+		{name: "标签与控制项同时满足", filter: rules.RuleFilter{Tags: []string{"security"}, Controls: []string{"internal"}}, expected: "host-network"},
+		{name: "没有匹配", filter: rules.RuleFilter{Controls: []string{"nsa-cisa"}}, expected: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := strings.Join(ruleIDs(engine.GetRules(tc.filter)), ",")
+			if got != tc.expected {
+				t.Errorf("期望规则 %q，实际 %q", tc.expected, got)
+			}
+		})
+	}
+
+	// 引擎级别的筛选作用于未设置标签和控制项的过滤条件
+	engine.SelectRules(nil, []string{"cis-kubernetes"})
+	if got := strings.Join(ruleIDs(engine.GetRules(rules.RuleFilter{Categories: []string{"deployment"}})), ","); got != "host-network,host-pid" {
+		t.Errorf("期望只使用 cis-kubernetes 规则，实际 %q", got)
+	}
+	if got := strings.Join(ruleIDs(engine.GetRules(rules.RuleFilter{Tags: []string{"governance"}})), ","); got != "owner-label" {
+		t.Errorf("过滤条件的标签应优先，实际 %q", got)
+	}
+}
+
+// TestRuleControlsValidation 测试控制项缺少框架或编号时加载失败
+func TestRuleControlsValidation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.yaml")
+	content := `apiVersion: inspector.k8s/v1
+kind: RulesConfig
+rules:
+  - id: "host-network"
+    name: "禁止使用宿主机网络"
+    category: "deployment"
+    severity: "critical"
+    controls:
+      - framework: "cis-kubernetes"
+    condition:
+      metric: "replicas"
+      operator: ">="
+      threshold: 1
+    enabled: true
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("写入规则文件失败: %v", err)
+	}
+	if _, err := rules.NewEngine(path); err == nil || !strings.Contains(err.Error(), "缺少 framework 或 id") {
+		t.Errorf("期望控制项验证错误，实际: %v", err)
+	}
+}
+
+// TestComplianceSummary 测试报告按控制项汇总检查结果，被豁免的失败计为 WAIVED
+func TestComplianceSummary(t *testing.T) {
+	engine, err := rules.NewEngine(filepath.Join("testdata", "compliance_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+
+	newDeployment := func(name string, labels map[string]string, hostNetwork bool) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: int32Ptr(2),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						HostNetwork: hostNetwork,
+						Containers:  []corev1.Container{{Name: "app", Image: "nginx:1.25"}},
+					},
+				},
+			},
+		}
+	}
+	fakeClientset := fake.NewSimpleClientset(
+		newDeployment("api", map[string]string{"owner": "team-a"}, false),
+		newDeployment("proxy", map[string]string{"owner": "team-net"}, true),
+	)
+	deployments, err := collector.NewDeploymentCollector(&cluster.Client{Clientset: fakeClientset}).GetDeployments(context.TODO(), "default")
+	if err != nil {
+		t.Fatalf("采集失败: %v", err)
+	}
+
+	analyzer := deployment.NewDeploymentAnalyzer(engine, nil)
+	var results []*deployment.AnalysisResult
+	for _, dep := range deployments {
+		results = append(results, analyzer.AnalyzeDeployment(dep))
+	}
+	r := report.NewGenerator("test-cluster", "default").GenerateDeploymentReport(results, engine.GetRules(rules.RuleFilter{}))
+
+	controls := func() map[string]report.ControlResult {
+		byKey := make(map[string]report.ControlResult)
+		for _, control := range r.Compliance {
+			byKey[control.Framework+":"+control.ControlID] = control
+		}
+		return byKey
+	}
+
+	got := controls()
+	if len(got) != 3 {
+		t.Fatalf("期望 3 个控制项（不含已禁用规则的控制项），实际 %v", r.Compliance)
+	}
+	if c := got["cis-kubernetes:5.2.5"]; c.Status != report.ControlFail || c.Passed != 1 || c.Failed != 1 {
+		t.Errorf("cis-kubernetes:5.2.5 期望 FAIL (1 通过, 1 未通过)，实际 %+v", c)
+	}
+	if c := got["internal:OPS-01"]; c.Status != report.ControlPass || c.Passed != 2 || c.Title != "工作负载必须登记负责人" {
+		t.Errorf("internal:OPS-01 期望 PASS (2 通过)，实际 %+v", c)
+	}
+	if r.Compliance[0].Framework != "cis-kubernetes" || r.Compliance[1].ControlID != "OPS-01" {
+		t.Errorf("控制项应按框架和编号排序，实际 %v", r.Compliance)
+	}
+
+	// 豁免宿主机网络的失败后，对应控制项变为 WAIVED
+	waivers := waiver.NewSet()
+	waivers.AddAnnotations("Deployment", "default/proxy", map[string]string{
+		waiver.AnnotationPrefix + "host-network": `{"reason": "入口代理需要宿主机网络", "owner": "team-net", "expires": "2099-01-01"}`,
+	})
+	report.ApplyWaivers(r, waivers, time.Now())
+
+	got = controls()
+	for _, key := range []string{"cis-kubernetes:5.2.5", "internal:SEC-02"} {
+		if c := got[key]; c.Status != report.ControlWaived || c.Failed != 0 || c.Waived != 1 {
+			t.Errorf("%s 期望 WAIVED (1 豁免)，实际 %+v", key, c)
+		}
+	}
+
+	text := report.NewTextFormatter(false).Format(r)
+	if !strings.Contains(text, "COMPLIANCE SUMMARY") || !strings.Contains(text, "[WAIVED] 5.2.5") {
+		t.Errorf("文本报告应包含合规汇总，实际:\n%s", text)
+	}
+}
+
+// TestSecurityRulePack 测试内置安全规则包标注了 CIS 控制项并能检查出特权配置
+func TestSecurityRulePack(t *testing.T) {
+	engine, err := rules.NewEngineFromFS(configs.RulesFS, configs.RulePackPath("deployment"), configs.RulePackPath("security"))
+	if err != nil {
+		t.Fatalf("加载规则包失败: %v", err)
+	}
+	engine.SelectRules(nil, []string{"cis-kubernetes"})
+	selected := engine.GetRules(rules.RuleFilter{})
+	if len(selected) == 0 {
+		t.Fatalf("安全规则包中没有 cis-kubernetes 规则")
+	}
+	for _, rule := range selected {
+		if !rule.HasAnyTag([]string{"security"}) {
+			t.Errorf("规则 %s 应带有 security 标签", rule.ID)
+		}
+	}
+
+	privileged := true
+	fakeClientset := fake.NewSimpleClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(1),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					HostPID: true,
+					Containers: []corev1.Container{{
+						Name:            "agent",
+						Image:           "agent:1.0",
+						SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+					}},
+					Volumes: []corev1.Volume{{
+						Name:         "proc",
+						VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/proc"}},
+					}},
+				},
+			},
+		},
+	})
+	deployments, err := collector.NewDeploymentCollector(&cluster.Client{Clientset: fakeClientset}).GetDeployments(context.TODO(), "default")
+	if err != nil {
+		t.Fatalf("采集失败: %v", err)
+	}
+	result := deployment.NewDeploymentAnalyzer(engine, nil).AnalyzeDeployment(deployments[0])
+	r := report.NewGenerator("test-cluster", "default").GenerateDeploymentReport([]*deployment.AnalysisResult{result}, selected)
+
+	// 只有 cis-kubernetes 规则被评估，NSA/CISA 控制项随对应规则一起汇总
+	status := make(map[string]report.ControlStatus)
+	for _, control := range r.Compliance {
+		status[control.Framework+":"+control.ControlID] = control.Status
+	}
+	expected := map[string]report.ControlStatus{
+		"cis-kubernetes:5.2.2":  report.ControlFail,
+		"cis-kubernetes:5.2.3":  report.ControlFail,
+		"cis-kubernetes:5.2.4":  report.ControlPass,
+		"cis-kubernetes:5.2.5":  report.ControlPass,
+		"cis-kubernetes:5.2.12": report.ControlFail,
+	}
+	for key, want := range expected {
+		if status[key] != want {
+			t.Errorf("%s 期望 %s，实际 %s", key, want, status[key])
+		}
+	}
+	if _, ok := status["nsa-cisa:immutable-filesystem"]; ok {
+		t.Errorf("未被选中的规则不应出现在合规汇总中")
+	}
+	order := make(map[string]int)
+	for i, control := range r.Compliance {
+		order[control.ControlID] = i
+	}
+	if order["5.2.12"] < order["5.2.7"] {
+		t.Errorf("5.2.12 应排在 5.2.7 之后，实际 %v", r.Compliance)
+	}
+}
@@ -0,0 +1,237 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/node"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/pod"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestCompoundConditionEvaluation 测试all/any/not组合条件的评估及结果说明
+func TestCompoundConditionEvaluation(t *testing.T) {
+	engine, err := rules.NewEngine(filepath.Join("testdata", "compound_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+
+	findRule := func(id string) rules.Rule {
+		for _, rule := range engine.GetRules(rules.RuleFilter{}) {
+			if rule.ID == id {
+				return rule
+			}
+		}
+		t.Fatalf("未找到规则: %s", id)
+		return rules.Rule{}
+	}
+	sourceFor := func(dep models.Deployment) rules.MetricSource {
+		return func(metric string) (interface{}, string, bool) {
+			return deployment.GetMetricValue(dep, metric)
+		}
+	}
+
+	replicasRule := findRule("prod-min-replicas")
+	testCases := []struct {
+		name      string
+		dep       models.Deployment
+		expectOK  bool
+		tripPaths []string
+	}{
+		{
+			name:      "生产环境单副本",
+			dep:       models.Deployment{Name: "api", Namespace: "prod", Replicas: 1},
+			expectOK:  false,
+			tripPaths: []string{"not.all[0]", "not.all[1]"},
+		},
+		{
+			name:      "测试环境单副本",
+			dep:       models.Deployment{Name: "api", Namespace: "dev", Replicas: 1},
+			expectOK:  true,
+			tripPaths: []string{"not.all[1]"},
+		},
+		{
+			name:      "生产环境多副本",
+			dep:       models.Deployment{Name: "api", Namespace: "prod", Replicas: 3},
+			expectOK:  true,
+			tripPaths: []string{"not.all[0]"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := engine.EvaluateRule(replicasRule, "", sourceFor(tc.dep))
+			if err != nil {
+				t.Fatalf("评估规则失败: %v", err)
+			}
+			if result.Passed != tc.expectOK {
+				t.Errorf("期望 Passed=%v，实际 %v (%s)", tc.expectOK, result.Passed, result.Message)
+			}
+			var paths []string
+			for _, c := range result.Conditions {
+				paths = append(paths, c.Path)
+			}
+			if strings.Join(paths, ",") != strings.Join(tc.tripPaths, ",") {
+				t.Errorf("期望决定结果的子条件 %v，实际 %v", tc.tripPaths, paths)
+			}
+			for _, path := range tc.tripPaths {
+				if !strings.Contains(result.Message, path) {
+					t.Errorf("结果消息应说明子条件 %s: %s", path, result.Message)
+				}
+			}
+		})
+	}
+
+	// any: 任一子条件成立即通过
+	anyRule := findRule("pull-policy-or-limits")
+	dep := models.Deployment{
+		Namespace:  "prod",
+		Containers: []models.DeploymentContainer{{Name: "c1", ImagePullPolicy: "Always"}},
+	}
+	result, err := engine.EvaluateRule(anyRule, "", sourceFor(dep))
+	if err != nil {
+		t.Fatalf("评估规则失败: %v", err)
+	}
+	if result.Passed || len(result.Conditions) != 2 {
+		t.Errorf("期望两个子条件均不成立导致失败，实际 Passed=%v，子条件 %d 个", result.Passed, len(result.Conditions))
+	}
+
+	// 组合条件必须通过 MetricSource 提供指标值
+	if _, err := engine.EvaluateRule(anyRule, "string", "Always"); err == nil {
+		t.Error("组合条件传入单个指标值时应返回错误")
+	}
+}
+
+// TestCompoundConditionNodeAndPod 测试节点和Pod分析器评估组合条件规则，条件成立表示存在问题
+func TestCompoundConditionNodeAndPod(t *testing.T) {
+	engine, err := rules.NewEngine(filepath.Join("testdata", "compound_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+
+	nodeAnalyzer := node.NewNodeAnalyzer(engine, nil)
+	nodeCases := map[string]struct {
+		node       *models.Node
+		expectPass bool
+	}{
+		"节点就绪且无压力": {node: &models.Node{Name: "node-1", Ready: true}, expectPass: true},
+		"节点存在内存压力": {node: &models.Node{Name: "node-2", Ready: true, PressureStatus: models.NodePressureStatus{MemoryPressure: true}}, expectPass: false},
+	}
+	for name, tc := range nodeCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := nodeAnalyzer.AnalyzeNode(tc.node)
+			if err != nil {
+				t.Fatalf("分析节点失败: %v", err)
+			}
+			var item *node.AnalysisItem
+			for i := range result.Items {
+				if result.Items[i].RuleID == "node-unhealthy" {
+					item = &result.Items[i]
+				}
+			}
+			if item == nil {
+				t.Fatalf("组合条件规则未被评估: %+v", result.Items)
+			}
+			if item.Passed != tc.expectPass {
+				t.Errorf("期望 Passed=%v，实际 %v (%s)", tc.expectPass, item.Passed, item.Description)
+			}
+		})
+	}
+
+	podAnalyzer := pod.NewPodAnalyzer(engine)
+	podCases := map[string]struct {
+		pod        *models.Pod
+		expectPass bool
+	}{
+		"频繁重启且缺少探针":  {pod: &models.Pod{Name: "web-1", Namespace: "shop", TotalRestarts: 5}, expectPass: false},
+		"频繁重启但配置了探针": {pod: &models.Pod{Name: "web-2", Namespace: "shop", TotalRestarts: 5, HasLivenessProbe: true}, expectPass: true},
+	}
+	for name, tc := range podCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := podAnalyzer.AnalyzePod(tc.pod)
+			if err != nil {
+				t.Fatalf("分析Pod失败: %v", err)
+			}
+			var item *pod.AnalysisItem
+			for i := range result.Items {
+				if result.Items[i].RuleID == "pod-restarts-without-probes" {
+					item = &result.Items[i]
+				}
+			}
+			if item == nil {
+				t.Fatalf("组合条件规则未被评估: %+v", result.Items)
+			}
+			if item.Passed != tc.expectPass {
+				t.Errorf("期望 Passed=%v，实际 %v (%s)", tc.expectPass, item.Passed, item.Description)
+			}
+		})
+	}
+}
+
+// TestCompoundConditionValidation 测试规则加载时对整棵条件树的校验
+func TestCompoundConditionValidation(t *testing.T) {
+	testCases := []struct {
+		name      string
+		condition string
+		expectErr string
+	}{
+		{
+			name: "嵌套条件缺少阈值",
+			condition: `
+      all:
+        - metric: "replicas"
+          operator: "<"
+          threshold: 2
+        - not:
+            metric: "namespace"
+            operator: "=="`,
+			expectErr: "all[1].not 缺少阈值",
+		},
+		{
+			name: "嵌套条件操作符不支持",
+			condition: `
+      any:
+        - metric: "replicas"
+          operator: "~"
+          threshold: 2`,
+			expectErr: "any[0] 包含不支持的操作符",
+		},
+		{
+			name: "同一层级混用叶子和组合条件",
+			condition: `
+      metric: "replicas"
+      operator: "<"
+      threshold: 2
+      any:
+        - metric: "namespace"
+          operator: "=="
+          threshold: "prod"`,
+			expectErr: "不能同时使用",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := `apiVersion: inspector.k8s/v1
+kind: RulesConfig
+rules:
+  - id: "broken"
+    name: "broken"
+    category: "deployment"
+    severity: "warning"
+    enabled: true
+    condition:` + tc.condition + "\n"
+			file := filepath.Join(t.TempDir(), "rules.yaml")
+			if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+				t.Fatalf("写入规则文件失败: %v", err)
+			}
+			_, err := rules.NewEngine(file)
+			if err == nil || !strings.Contains(err.Error(), tc.expectErr) {
+				t.Errorf("期望错误包含 %q，实际: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
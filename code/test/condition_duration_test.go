@@ -0,0 +1,124 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestConditionDuration 测试配置了duration的规则只有在条件持续足够时间后才报告失败
+func TestConditionDuration(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	dep := models.Deployment{Name: "web", Namespace: "default", Replicas: 1}
+
+	analyze := func() deployment.AnalysisItem {
+		t.Helper()
+		engine, err := rules.NewEngine(filepath.Join("testdata", "duration_rules_test.yaml"))
+		if err != nil {
+			t.Fatalf("加载规则引擎失败: %v", err)
+		}
+		store, err := rules.LoadStateStore(stateFile)
+		if err != nil {
+			t.Fatalf("加载状态文件失败: %v", err)
+		}
+		engine.SetStateStore(store)
+
+		result := deployment.NewDeploymentAnalyzer(engine, nil).AnalyzeDeployment(dep)
+		if err := engine.SaveState(); err != nil {
+			t.Fatalf("保存状态失败: %v", err)
+		}
+		if len(result.Items) != 1 {
+			t.Fatalf("期望1个分析项，实际 %d", len(result.Items))
+		}
+		return result.Items[0]
+	}
+
+	// 第一次发现条件成立，尚未持续足够时间，不应报告失败
+	item := analyze()
+	if !item.Passed {
+		t.Errorf("条件首次成立时不应报告失败: %s", item.Description)
+	}
+	if item.PendingSince == nil {
+		t.Fatal("等待中的分析项应包含条件首次成立时间")
+	}
+	firstSeen := *item.PendingSince
+
+	// 再次巡检时首次成立时间应从状态文件恢复
+	item = analyze()
+	if item.PendingSince == nil || !item.PendingSince.Equal(firstSeen) {
+		t.Errorf("首次成立时间应保持为 %v，实际 %v", firstSeen, item.PendingSince)
+	}
+
+	// 模拟条件已持续超过10分钟
+	since := time.Now().Add(-15 * time.Minute).UTC().Truncate(time.Second)
+	content := fmt.Sprintf(`{"version": 1, "conditions": {"min-replicas-sustained|Deployment/default/web": %q}}`, since.Format(time.RFC3339))
+	if err := os.WriteFile(stateFile, []byte(content), 0600); err != nil {
+		t.Fatalf("写入状态文件失败: %v", err)
+	}
+	item = analyze()
+	if item.Passed {
+		t.Error("条件持续超过规则要求的时间后应报告失败")
+	}
+	if item.PendingSince == nil || !item.PendingSince.Equal(since) {
+		t.Errorf("失败项应包含条件首次成立时间 %v，实际 %v", since, item.PendingSince)
+	}
+
+	// 条件恢复后应清除状态，再次出现时重新计时
+	dep.Replicas = 3
+	if item = analyze(); !item.Passed || item.PendingSince != nil {
+		t.Errorf("条件恢复后应通过且不包含等待时间，实际 Passed=%v PendingSince=%v", item.Passed, item.PendingSince)
+	}
+	dep.Replicas = 1
+	if item = analyze(); !item.Passed || item.PendingSince == nil || !item.PendingSince.After(since) {
+		t.Errorf("条件再次成立时应重新计时，实际 Passed=%v PendingSince=%v", item.Passed, item.PendingSince)
+	}
+}
+
+// TestConditionStatePruned 测试保存状态时删除已不存在的资源的状态，保留本次未检查的规则的状态
+func TestConditionStatePruned(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	since := time.Now().Add(-time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+	content := fmt.Sprintf(`{"version": 1, "conditions": {
+  "min-replicas-sustained|Deployment/default/deleted": %q,
+  "node-not-ready|Node/worker-1": %q
+}}`, since, since)
+	if err := os.WriteFile(stateFile, []byte(content), 0600); err != nil {
+		t.Fatalf("写入状态文件失败: %v", err)
+	}
+
+	engine, err := rules.NewEngine(filepath.Join("testdata", "duration_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+	store, err := rules.LoadStateStore(stateFile)
+	if err != nil {
+		t.Fatalf("加载状态文件失败: %v", err)
+	}
+	engine.SetStateStore(store)
+	deployment.NewDeploymentAnalyzer(engine, nil).AnalyzeDeployment(models.Deployment{Name: "web", Namespace: "default", Replicas: 1})
+	if err := engine.SaveState(); err != nil {
+		t.Fatalf("保存状态失败: %v", err)
+	}
+
+	data, err := os.ReadFile(stateFile)
+	if err != nil {
+		t.Fatalf("读取状态文件失败: %v", err)
+	}
+	saved := string(data)
+	if strings.Contains(saved, "Deployment/default/deleted") {
+		t.Errorf("未检查到的资源的状态应被删除: %s", saved)
+	}
+	if !strings.Contains(saved, "min-replicas-sustained|Deployment/default/web") {
+		t.Errorf("本次检查的资源的状态应被保存: %s", saved)
+	}
+	if !strings.Contains(saved, "node-not-ready|Node/worker-1") {
+		t.Errorf("本次未检查的规则的状态应保留: %s", saved)
+	}
+}
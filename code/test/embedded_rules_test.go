@@ -0,0 +1,97 @@
+package test
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/configs"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestEmbeddedRulePacks 测试内置规则包与仓库中的规则文件一致且可以直接加载
+func TestEmbeddedRulePacks(t *testing.T) {
+	packs, err := configs.RulePacks()
+	if err != nil {
+		t.Fatalf("读取内置规则包失败: %v", err)
+	}
+	expected := []string{"deployment", "node", "pod", "security", "service"}
+	// 专题规则包中的规则属于其他资源类别
+	topical := map[string]string{"security": "deployment"}
+	if len(packs) != len(expected) {
+		t.Fatalf("期望内置规则包 %v，实际 %v", expected, packs)
+	}
+
+	for i, pack := range packs {
+		if pack != expected[i] {
+			t.Errorf("期望规则包 %s，实际 %s", expected[i], pack)
+		}
+
+		embedded, err := fs.ReadFile(configs.RulesFS, configs.RulePackPath(pack))
+		if err != nil {
+			t.Fatalf("读取内置规则包 %s 失败: %v", pack, err)
+		}
+		onDisk, err := os.ReadFile(filepath.Join("..", "configs", "rules", pack+".yaml"))
+		if err != nil {
+			t.Fatalf("读取规则文件 %s 失败: %v", pack, err)
+		}
+		if !bytes.Equal(embedded, onDisk) {
+			t.Errorf("内置规则包 %s 与仓库中的文件不一致", pack)
+		}
+
+		engine, err := rules.NewEngineFromFS(configs.RulesFS, configs.RulePackPath(pack))
+		if err != nil {
+			t.Fatalf("加载内置规则包 %s 失败: %v", pack, err)
+		}
+		category := pack
+		if c, ok := topical[pack]; ok {
+			category = c
+		}
+		if len(engine.GetRules(rules.RuleFilter{Categories: []string{category}})) == 0 {
+			t.Errorf("内置规则包 %s 中没有 %s 类别的规则", pack, category)
+		}
+	}
+
+	// 整个内置规则目录也可以作为一个规则集加载
+	if _, err := rules.NewEngineFromFS(configs.RulesFS, configs.RulesDir); err != nil {
+		t.Errorf("加载内置规则目录失败: %v", err)
+	}
+}
+
+// TestEmbeddedRulePacksKeepAllRules 测试整个内置规则目录一起加载时，每个规则包中的规则都被保留，没有因规则ID相同而被覆盖
+func TestEmbeddedRulePacksKeepAllRules(t *testing.T) {
+	packs, err := configs.RulePacks()
+	if err != nil {
+		t.Fatalf("读取内置规则包失败: %v", err)
+	}
+	all, err := rules.NewEngineFromFS(configs.RulesFS, configs.RulesDir)
+	if err != nil {
+		t.Fatalf("加载内置规则目录失败: %v", err)
+	}
+	loaded := make(map[string]string)
+	for _, rule := range all.GetRules(rules.RuleFilter{}) {
+		loaded[rule.ID] = rule.Category
+	}
+
+	total := 0
+	for _, pack := range packs {
+		engine, err := rules.NewEngineFromFS(configs.RulesFS, configs.RulePackPath(pack))
+		if err != nil {
+			t.Fatalf("加载内置规则包 %s 失败: %v", pack, err)
+		}
+		for _, rule := range engine.GetRules(rules.RuleFilter{}) {
+			total++
+			category, ok := loaded[rule.ID]
+			if !ok {
+				t.Errorf("规则包 %s 中的规则 %s 在合并加载后丢失", pack, rule.ID)
+			} else if category != rule.Category {
+				t.Errorf("规则包 %s 中的 %s 规则 %s 被 %s 类别的同名规则覆盖", pack, rule.Category, rule.ID, category)
+			}
+		}
+	}
+	if total != len(loaded) {
+		t.Errorf("各规则包共有 %d 条规则，合并加载后为 %d 条", total, len(loaded))
+	}
+}
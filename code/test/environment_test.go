@@ -0,0 +1,217 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestResolveEnvironment 测试按集群名称、名称模式和 kube-system 标签确定环境的优先顺序
+func TestResolveEnvironment(t *testing.T) {
+	engine, err := rules.NewEngine(filepath.Join("testdata", "environment_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+	if label := engine.EnvironmentLabel(); label != "inspector.k8s/environment" {
+		t.Errorf("环境标签期望 inspector.k8s/environment，实际 %q", label)
+	}
+
+	envLabel := map[string]string{"inspector.k8s/environment": "qa"}
+	tests := []struct {
+		name     string
+		cluster  string
+		labels   map[string]string
+		expected string
+	}{
+		{name: "精确映射优先于模式", cluster: "prod-legacy", labels: envLabel, expected: "staging"},
+		{name: "通配符模式", cluster: "prod-eu-1", labels: envLabel, expected: "prod"},
+		{name: "正则模式需完整匹配", cluster: "stg-eu-12", expected: "staging"},
+		{name: "正则模式不匹配时使用默认映射", cluster: "stg-eu-12-old", expected: "test"},
+		{name: "模式优先于标签", cluster: "prod-us-2", labels: envLabel, expected: "prod"},
+		{name: "按kube-system标签", cluster: "team-a", labels: envLabel, expected: "qa"},
+		{name: "标签为空时使用默认映射", cluster: "team-a", labels: map[string]string{"inspector.k8s/environment": ""}, expected: "test"},
+		{name: "没有标签时使用默认映射", cluster: "team-a", expected: "test"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if env := engine.ResolveEnvironment(tt.cluster, tt.labels); env != tt.expected {
+				t.Errorf("集群 %s 期望环境 %s，实际 %s", tt.cluster, tt.expected, env)
+			}
+		})
+	}
+}
+
+// TestEnvironmentThresholdInheritance 测试环境沿继承链查找阈值，最后使用 default
+func TestEnvironmentThresholdInheritance(t *testing.T) {
+	engine, err := rules.NewEngine(filepath.Join("testdata", "environment_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+	ruleByID := make(map[string]rules.Rule)
+	for _, rule := range engine.GetRules(rules.RuleFilter{}) {
+		ruleByID[rule.ID] = rule
+	}
+
+	tests := []struct {
+		env        string
+		ruleID     string
+		metricType string
+		actual     interface{}
+		expected   interface{}
+		passed     bool
+	}{
+		{env: "prod", ruleID: "min-replicas", metricType: "numeric", actual: 2, expected: 3, passed: false},
+		// staging 没有副本数阈值，继承 prod
+		{env: "staging", ruleID: "min-replicas", metricType: "numeric", actual: 2, expected: 3, passed: false},
+		// qa 继承 staging，再继承 prod
+		{env: "qa", ruleID: "min-replicas", metricType: "numeric", actual: 3, expected: 3, passed: true},
+		{env: "test", ruleID: "min-replicas", metricType: "numeric", actual: 2, expected: 2, passed: true},
+		{env: "dev", ruleID: "min-replicas", metricType: "numeric", actual: 1, expected: 1, passed: true},
+		// qa 使用继承链上最近的 staging 阈值
+		{env: "qa", ruleID: "max-memory-limit", metricType: "quantity", actual: "3Gi", expected: "2Gi", passed: false},
+		{env: "prod", ruleID: "max-memory-limit", metricType: "quantity", actual: "3Gi", expected: "4Gi", passed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.env+"/"+tt.ruleID, func(t *testing.T) {
+			engine.SetEnvironment(tt.env)
+			result, err := engine.EvaluateRule(ruleByID[tt.ruleID], tt.metricType, tt.actual)
+			if err != nil {
+				t.Fatalf("评估规则失败: %v", err)
+			}
+			if result.Passed != tt.passed || result.ExpectedValue != tt.expected {
+				t.Errorf("期望 Passed=%v 阈值 %v，实际 Passed=%v 阈值 %v (%s)",
+					tt.passed, tt.expected, result.Passed, result.ExpectedValue, result.Message)
+			}
+		})
+	}
+}
+
+// TestConditionThresholdFor 测试条件按阈值环境、default 环境和通用阈值的顺序取阈值
+func TestConditionThresholdFor(t *testing.T) {
+	condition := rules.RuleCondition{
+		Threshold:  1,
+		Thresholds: map[string]interface{}{"prod": 3, "default": 2},
+	}
+	expected := map[string]interface{}{"prod": 3, "staging": 2, "": 2}
+	for env, threshold := range expected {
+		if actual := condition.ThresholdFor(env); actual != threshold {
+			t.Errorf("环境 %q: 期望阈值 %v，实际 %v", env, threshold, actual)
+		}
+	}
+	if actual := (rules.RuleCondition{Threshold: 1}).ThresholdFor("prod"); actual != 1 {
+		t.Errorf("未设置环境阈值时期望使用通用阈值 1，实际 %v", actual)
+	}
+}
+
+// TestEnvironmentConfigValidation 测试环境继承和集群模式的配置检查
+func TestEnvironmentConfigValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		errPart string
+	}{
+		{
+			name:    "继承成环",
+			config:  "environments:\n  a:\n    inherits: b\n  b:\n    inherits: a\n",
+			errPart: "继承关系存在循环",
+		},
+		{
+			name:    "default 不能继承",
+			config:  "environments:\n  default:\n    inherits: prod\n",
+			errPart: "不能继承其他环境",
+		},
+		{
+			name:    "同时设置 pattern 和 regex",
+			config:  "clusterEnvironmentPatterns:\n  - pattern: \"prod-*\"\n    regex: \"prod-.*\"\n    environment: prod\n",
+			errPart: "只能设置一个",
+		},
+		{
+			name:    "缺少环境",
+			config:  "clusterEnvironmentPatterns:\n  - pattern: \"prod-*\"\n",
+			errPart: "缺少 environment",
+		},
+		{
+			name:    "无效的正则表达式",
+			config:  "clusterEnvironmentPatterns:\n  - regex: \"prod-(\"\n    environment: prod\n",
+			errPart: "无效的正则表达式",
+		},
+		{
+			name:    "无效的通配符",
+			config:  "clusterEnvironmentPatterns:\n  - pattern: \"prod-[\"\n    environment: prod\n",
+			errPart: "无效的通配符模式",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "apiVersion: inspector.k8s/v1\nkind: RulesConfig\n" + tt.config + "rules: []\n"
+			path := filepath.Join(t.TempDir(), "rules.yaml")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("写入规则文件失败: %v", err)
+			}
+			_, err := rules.NewEngine(path)
+			if err == nil || !strings.Contains(err.Error(), tt.errPart) {
+				t.Errorf("期望包含 %q 的错误，实际: %v", tt.errPart, err)
+			}
+		})
+	}
+}
+
+// TestEnvironmentLayering 测试多个规则文件合并时，后加载文件中的集群模式优先，跨文件的继承成环会被发现
+func TestEnvironmentLayering(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("apiVersion: inspector.k8s/v1\nkind: RulesConfig\n"+content+"rules: []\n"), 0644); err != nil {
+			t.Fatalf("写入规则文件失败: %v", err)
+		}
+		return path
+	}
+	org := write("org.yaml", "clusterEnvironmentPatterns:\n  - pattern: \"*-prod\"\n    environment: prod\nenvironments:\n  staging:\n    inherits: prod\n")
+	team := write("team.yaml", "clusterEnvironmentPatterns:\n  - pattern: \"team-a-*\"\n    environment: staging\n")
+
+	engine, err := rules.NewEngine(org, team)
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+	if env := engine.ResolveEnvironment("team-a-prod", nil); env != "staging" {
+		t.Errorf("后加载文件的模式应优先匹配，期望 staging，实际 %s", env)
+	}
+	if env := engine.ResolveEnvironment("shop-prod", nil); env != "prod" {
+		t.Errorf("期望 prod，实际 %s", env)
+	}
+
+	cycle := write("cycle.yaml", "environments:\n  prod:\n    inherits: staging\n")
+	if _, err := rules.NewEngine(org, cycle); err == nil || !strings.Contains(err.Error(), "继承关系存在循环") {
+		t.Errorf("跨文件的继承成环应报错，实际: %v", err)
+	}
+}
+
+// TestLintInheritedEnvironments 测试 environments 和集群模式中声明的环境不会被报告为未知的环境阈值
+func TestLintInheritedEnvironments(t *testing.T) {
+	config := &rules.RulesConfig{
+		ClusterEnvironmentPatterns: []rules.ClusterEnvironmentPattern{{Pattern: "prod-*", Environment: "prod"}},
+		Environments:               map[string]rules.EnvironmentConfig{"staging": {Inherits: "prod"}},
+		Rules: []rules.Rule{{
+			ID:       "min-replicas",
+			Name:     "副本数检查",
+			Category: "deployment",
+			Severity: "warning",
+			Condition: rules.RuleCondition{
+				Metric:     "replicas",
+				Operator:   ">=",
+				Thresholds: map[string]interface{}{"prod": 3, "staging": 2, "canary": 1},
+			},
+		}},
+	}
+
+	issues := rules.LintConfig(config)
+	if len(issues) != 1 || !strings.Contains(issues[0].Message, `"canary"`) {
+		t.Errorf("期望只有 canary 产生警告，实际: %v", issues)
+	}
+}
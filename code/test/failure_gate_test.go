@@ -0,0 +1,112 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/cmd/inspector/inspect"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+)
+
+// TestParseSeverity 测试 --fail-on 严重性级别的解析
+func TestParseSeverity(t *testing.T) {
+	testCases := map[string]report.Severity{
+		"critical": report.SeverityCritical,
+		"Error":    report.SeverityError,
+		" warning": report.SeverityWarning,
+		"INFO":     report.SeverityInfo,
+	}
+	for name, expected := range testCases {
+		actual, err := report.ParseSeverity(name)
+		if err != nil || actual != expected {
+			t.Errorf("%q: 期望 %s，实际 %s (%v)", name, expected, actual, err)
+		}
+	}
+	if _, err := report.ParseSeverity("fatal"); err == nil {
+		t.Error("期望未知严重性级别返回错误")
+	}
+}
+
+// TestCountAtOrAbove 测试按严重性阈值统计发现项，被豁免的发现项不计入
+func TestCountAtOrAbove(t *testing.T) {
+	r := &report.Report{
+		Findings: []report.Finding{
+			{RuleID: "a", Severity: report.SeverityInfo},
+			{RuleID: "b", Severity: report.SeverityWarning},
+			{RuleID: "c", Severity: report.SeverityError},
+			{RuleID: "d", Severity: report.SeverityCritical},
+			{RuleID: "e", Severity: report.SeverityWarning},
+		},
+		Suppressed: []report.SuppressedFinding{{Finding: report.Finding{RuleID: "f", Severity: report.SeverityCritical}}},
+	}
+	expected := map[report.Severity]int{
+		report.SeverityInfo:     5,
+		report.SeverityWarning:  4,
+		report.SeverityError:    2,
+		report.SeverityCritical: 1,
+	}
+	for threshold, count := range expected {
+		if actual := r.CountAtOrAbove(threshold); actual != count {
+			t.Errorf("%s: 期望 %d，实际 %d", threshold, count, actual)
+		}
+	}
+}
+
+// TestExitCode 测试错误对应的退出状态码
+func TestExitCode(t *testing.T) {
+	unreachable := fmt.Errorf("分析Pod失败: %w", &url.Error{Op: "Get", URL: "https://127.0.0.1:6443/api/v1/pods", Err: errors.New("connection refused")})
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "成功", err: nil, expected: inspect.ExitOK},
+		{name: "其他错误", err: errors.New("写入报告到文件失败"), expected: inspect.ExitError},
+		{name: "访问集群API的网络错误", err: unreachable, expected: inspect.ExitClusterUnreachable},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := inspect.ExitCode(tc.err); actual != tc.expected {
+				t.Errorf("期望 %d，实际 %d", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// TestCheckFindingsMaxFindings 测试 --max-findings 的边界：数量等于允许值时通过，超过一个时以 ExitViolations 失败
+func TestCheckFindingsMaxFindings(t *testing.T) {
+	r := &report.Report{
+		Findings: []report.Finding{
+			{RuleID: "a", Severity: report.SeverityInfo},
+			{RuleID: "b", Severity: report.SeverityWarning},
+			{RuleID: "c", Severity: report.SeverityCritical},
+		},
+	}
+	testCases := []struct {
+		name        string
+		failOn      string
+		maxFindings int
+		always      bool
+		expected    int
+	}{
+		{name: "数量等于允许值", failOn: "warning", maxFindings: 2, expected: inspect.ExitOK},
+		{name: "数量超过允许值一个", failOn: "warning", maxFindings: 1, expected: inspect.ExitViolations},
+		{name: "未指定级别时统计所有发现项", maxFindings: 3, expected: inspect.ExitOK},
+		{name: "未指定级别时超过允许值", maxFindings: 2, expected: inspect.ExitViolations},
+		{name: "只指定级别时存在发现项即失败", failOn: "critical", maxFindings: -1, expected: inspect.ExitViolations},
+		{name: "inspect未指定阈值时不检查", maxFindings: -1, expected: inspect.ExitOK},
+		{name: "lint未指定阈值时不允许任何发现项", maxFindings: -1, always: true, expected: inspect.ExitViolations},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := inspect.NewOptions()
+			opts.FailOn = tc.failOn
+			opts.MaxFindings = tc.maxFindings
+			if actual := inspect.ExitCode(opts.CheckFindings(r, tc.always)); actual != tc.expected {
+				t.Errorf("期望 %d，实际 %d", tc.expected, actual)
+			}
+		})
+	}
+}
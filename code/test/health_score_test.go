@@ -0,0 +1,191 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/pod"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestScoringModel 测试健康评分模型的权重、类别上限和下限
+func TestScoringModel(t *testing.T) {
+	items := []rules.ScoreItem{
+		{RuleID: "a", Severity: "critical"},
+		{RuleID: "b", Severity: "warning"},
+		{RuleID: "c", Severity: "info"},
+		{RuleID: "d", Severity: "error"},
+	}
+
+	testCases := []struct {
+		name     string
+		config   *rules.ScoringConfig
+		items    []rules.ScoreItem
+		expected int
+		points   []int
+	}{
+		{
+			name:     "默认扣分",
+			config:   nil,
+			items:    items,
+			expected: 65,
+			points:   []int{20, 10, 5},
+		},
+		{
+			name:     "无未通过项",
+			config:   nil,
+			expected: 100,
+		},
+		{
+			name:     "严重程度和规则权重",
+			config:   &rules.ScoringConfig{SeverityWeights: map[string]int{"error": 15, "info": 0}, RuleWeights: map[string]int{"b": 40}},
+			items:    items,
+			expected: 25,
+			points:   []int{20, 40, 15},
+		},
+		{
+			name: "类别上限",
+			config: &rules.ScoringConfig{Categories: map[string]rules.ScoreCategory{
+				"security": {Rules: []string{"a", "b", "c"}, Cap: 25},
+			}},
+			items:    items,
+			expected: 75,
+			points:   []int{20, 5, 0},
+		},
+		{
+			name:     "分数下限",
+			config:   &rules.ScoringConfig{RuleWeights: map[string]int{"a": 90}, Floor: 20},
+			items:    items,
+			expected: 20,
+			points:   []int{90, 10, 5},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			breakdown := tc.config.Score(tc.items)
+			if breakdown.Score != tc.expected {
+				t.Errorf("期望评分 %d，实际 %d", tc.expected, breakdown.Score)
+			}
+			if len(breakdown.Deductions) != len(tc.points) {
+				t.Fatalf("期望 %d 项扣分，实际 %v", len(tc.points), breakdown.Deductions)
+			}
+			for i, points := range tc.points {
+				if breakdown.Deductions[i].Points != points {
+					t.Errorf("第 %d 项扣分期望 %d，实际 %+v", i, points, breakdown.Deductions[i])
+				}
+			}
+		})
+	}
+}
+
+// TestScoringFromRulesConfig 测试分析器使用规则配置中的评分模型并生成评分解释
+func TestScoringFromRulesConfig(t *testing.T) {
+	engine, err := rules.NewEngine(filepath.Join("testdata", "scoring_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+	analyzer := pod.NewPodAnalyzer(engine)
+
+	object := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"hostNetwork": true,
+			"hostPID":     true,
+			"dnsPolicy":   "Default",
+			"containers": []interface{}{
+				map[string]interface{}{"name": "app", "securityContext": map[string]interface{}{"privileged": true}},
+			},
+		},
+	}
+	result, err := analyzer.AnalyzePod(&models.Pod{Name: "risky", Namespace: "default", Object: object})
+	if err != nil {
+		t.Fatalf("分析失败: %v", err)
+	}
+
+	// pod-privileged 按规则权重扣50，security 类别剩余10分由 pod-host-network 扣除，
+	// pod-host-pid 因类别达到上限不扣分，pod-no-service-account 扣10，pod-dns-policy 扣15，合计85分，低于下限按20分计
+	breakdown := result.ScoreBreakdown
+	if result.HealthScore != 20 || !breakdown.FloorApplied {
+		t.Errorf("期望评分为下限20，实际 %d (%+v)", result.HealthScore, breakdown)
+	}
+	expected := map[string]int{"pod-privileged": 50, "pod-host-network": 10, "pod-host-pid": 0, "pod-no-service-account": 10, "pod-dns-policy": 15}
+	for _, d := range breakdown.Deductions {
+		if points, ok := expected[d.RuleID]; !ok || points != d.Points {
+			t.Errorf("规则 %s 期望扣分 %d，实际 %+v", d.RuleID, points, d)
+		}
+		delete(expected, d.RuleID)
+	}
+	if len(expected) > 0 {
+		t.Errorf("缺少扣分项: %v", expected)
+	}
+
+	r := report.NewGenerator("test-cluster", "").GeneratePodReport([]*pod.AnalysisResult{result}, nil)
+	output := report.NewTextFormatter(false).Format(r)
+	for _, want := range []string{"SCORE EXPLANATION", "Pod: default/risky (健康评分: 20/100)", "-0   pod-host-pid", "受类别上限限制", "下限 20"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("评分解释缺少 %q:\n%s", want, output)
+		}
+	}
+
+	// JSON 中的扣分明细与报告其他字段一样使用驼峰命名
+	if output := report.NewJSONFormatter(false).Format(r); !strings.Contains(output, `"ruleID":"pod-privileged"`) {
+		t.Errorf("JSON 评分解释中扣分项应使用 ruleID 字段:\n%s", output)
+	}
+
+	r.HideScoreExplanations()
+	if strings.Contains(report.NewTextFormatter(false).Format(r), "SCORE EXPLANATION") {
+		t.Errorf("未启用 --explain-score 时不应输出评分解释")
+	}
+}
+
+// TestScoringValidation 测试评分模型配置的校验、合并和检查
+func TestScoringValidation(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, scoring string) string {
+		content := "apiVersion: inspector.k8s/v1\nkind: RulesConfig\nscoring:\n" + scoring + "\nrules:\n" +
+			"  - id: \"r1\"\n    name: \"规则\"\n    category: \"pod\"\n    severity: \"warning\"\n" +
+			"    condition:\n      metric: \"pod_missing_probes\"\n      operator: \"==\"\n      threshold: true\n    enabled: true\n"
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("写入规则文件失败: %v", err)
+		}
+		return file
+	}
+
+	invalid := map[string]string{
+		"不支持的严重程度": "  severityWeights:\n    fatal: 10",
+		"负数权重":     "  ruleWeights:\n    r1: -5",
+		"下限超出范围":   "  floor: 120",
+		"空类别":      "  categories:\n    security:\n      cap: 10",
+	}
+	for name, scoring := range invalid {
+		if _, err := rules.NewEngine(write("invalid.yaml", scoring)); err == nil || !strings.Contains(err.Error(), "scoring") {
+			t.Errorf("%s: 期望评分配置校验失败，实际: %v", name, err)
+		}
+	}
+
+	// 后面的文档按键覆盖前面的评分设置
+	base := write("base.yaml", "  severityWeights:\n    warning: 30\n  ruleWeights:\n    missing-rule: 5\n  floor: 20")
+	override := write("override.yaml", "  severityWeights:\n    info: 1")
+	engine, err := rules.NewEngine(base, override)
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+	scoring := engine.Scoring()
+	if scoring.SeverityWeights["warning"] != 30 || scoring.SeverityWeights["info"] != 1 || scoring.Floor != 20 {
+		t.Errorf("评分配置合并结果错误: %+v", scoring)
+	}
+
+	loader := rules.NewRuleLoader(base)
+	if err := loader.LoadRules(); err != nil {
+		t.Fatalf("加载规则失败: %v", err)
+	}
+	issues := rules.LintConfig(loader.GetRulesConfig())
+	if len(issues) != 1 || issues[0].RuleID != "missing-rule" || issues[0].Level != rules.LintWarning {
+		t.Errorf("期望 missing-rule 的评分引用警告，实际: %v", issues)
+	}
+}
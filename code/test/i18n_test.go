@@ -0,0 +1,245 @@
+package test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestDetectLanguage 测试 --lang 优先于环境变量，环境变量按 LC_ALL、LC_MESSAGES、LANG 的顺序生效
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		flag     string
+		env      map[string]string
+		expected i18n.Language
+		wantErr  bool
+	}{
+		{name: "默认中文", expected: i18n.Chinese},
+		{name: "LANG 英文", env: map[string]string{"LANG": "en_US.UTF-8"}, expected: i18n.English},
+		{name: "LANG 中文", env: map[string]string{"LANG": "zh_CN.UTF-8"}, expected: i18n.Chinese},
+		{name: "LC_ALL 优先于 LANG", env: map[string]string{"LC_ALL": "en_GB", "LANG": "zh_CN.UTF-8"}, expected: i18n.English},
+		{name: "C 语言环境使用中文", env: map[string]string{"LANG": "C"}, expected: i18n.Chinese},
+		{name: "参数优先于环境变量", flag: "zh", env: map[string]string{"LANG": "en_US.UTF-8"}, expected: i18n.Chinese},
+		{name: "参数支持区域写法", flag: "EN-us", expected: i18n.English},
+		{name: "不支持的参数", flag: "fr", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+				t.Setenv(key, tt.env[key])
+			}
+			lang, err := i18n.Detect(tt.flag)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("期望错误，实际语言 %s", lang)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("检测语言失败: %v", err)
+			}
+			if lang != tt.expected {
+				t.Errorf("期望语言 %s，实际 %s", tt.expected, lang)
+			}
+		})
+	}
+}
+
+// TestEnglishRuleMessages 测试英文下规则评估消息和规则文本使用译文，中文下保持原文
+func TestEnglishRuleMessages(t *testing.T) {
+	defer i18n.SetLanguage(i18n.Chinese)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rules.yaml")
+	content := `apiVersion: inspector.k8s/v1
+kind: RulesConfig
+rules:
+  - id: "min_replicas"
+    name: "副本数不少于2"
+    category: "deployment"
+    severity: "warning"
+    condition:
+      metric: "replicas"
+      operator: ">="
+      threshold: 2
+    remediation: "建议将副本数设置为2及以上"
+    enabled: true
+    locales:
+      en:
+        name: "At least 2 replicas"
+        remediation: "Set replicas to 2 or more"
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("写入规则文件失败: %v", err)
+	}
+	engine, err := rules.NewEngine(path)
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+
+	i18n.SetLanguage(i18n.English)
+	rulesList := engine.GetRules(rules.RuleFilter{Categories: []string{"deployment"}})
+	if len(rulesList) != 1 {
+		t.Fatalf("期望 1 条规则，实际 %d", len(rulesList))
+	}
+	rule := rulesList[0]
+	if rule.Name != "At least 2 replicas" || rule.Remediation != "Set replicas to 2 or more" {
+		t.Errorf("规则文本未使用英文: name=%q remediation=%q", rule.Name, rule.Remediation)
+	}
+	result, err := engine.EvaluateRule(rule, "numeric", 1)
+	if err != nil {
+		t.Fatalf("评估规则失败: %v", err)
+	}
+	if !strings.Contains(result.Message, "At least 2 replicas") || !strings.Contains(result.Message, "should be at least 2") {
+		t.Errorf("英文消息不符合预期: %s", result.Message)
+	}
+
+	i18n.SetLanguage(i18n.Chinese)
+	rule = engine.GetRules(rules.RuleFilter{Categories: []string{"deployment"}})[0]
+	if rule.Name != "副本数不少于2" {
+		t.Errorf("中文下规则名称应保持原文，实际 %q", rule.Name)
+	}
+	result, err = engine.EvaluateRule(rule, "numeric", 1)
+	if err != nil {
+		t.Fatalf("评估规则失败: %v", err)
+	}
+	if !strings.Contains(result.Message, "应大于等于 2") {
+		t.Errorf("中文消息不符合预期: %s", result.Message)
+	}
+}
+
+// TestRuleLocaleLint 测试 locales 中不支持的语言会产生警告
+func TestRuleLocaleLint(t *testing.T) {
+	config := &rules.RulesConfig{Rules: []rules.Rule{{
+		ID:       "min_replicas",
+		Name:     "副本数不少于2",
+		Category: "deployment",
+		Severity: "warning",
+		Condition: rules.RuleCondition{
+			Metric:    "replicas",
+			Operator:  ">=",
+			Threshold: 2,
+		},
+		Locales: map[string]rules.RuleText{
+			"en": {Name: "At least 2 replicas"},
+			"fr": {Name: "Au moins 2 répliques"},
+		},
+	}}}
+
+	issues := rules.LintConfig(config)
+	if len(issues) != 1 || issues[0].Level != rules.LintWarning || !strings.Contains(issues[0].Message, `"fr"`) {
+		t.Errorf("期望 fr 语言产生一条警告，实际: %v", issues)
+	}
+}
+
+// TestEnglishTextReport 测试英文下文本报告不包含中文
+func TestEnglishTextReport(t *testing.T) {
+	defer i18n.SetLanguage(i18n.Chinese)
+	i18n.SetLanguage(i18n.English)
+
+	r := newSampleReport()
+	r.Findings[0].Message = "Missing health check probes"
+	r.Findings[0].Recommendation = "Configure probes"
+	output := report.NewTextFormatter(false).Format(r)
+
+	han := regexp.MustCompile(`\p{Han}`)
+	for i, line := range strings.Split(output, "\n") {
+		if han.MatchString(line) {
+			t.Errorf("英文报告第 %d 行包含中文: %s", i+1, line)
+		}
+	}
+}
+
+// TestEnglishCatalogComplete 测试源码中需要翻译的消息、命令帮助和参数说明在英文消息目录中都有译文，且格式化动词一致
+func TestEnglishCatalogComplete(t *testing.T) {
+	verbs := regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+	han := regexp.MustCompile(`\p{Han}`)
+
+	for _, message := range translatableMessages(t, "..") {
+		if !han.MatchString(message) {
+			continue
+		}
+		if !i18n.Has(i18n.English, message) {
+			t.Errorf("英文消息目录缺少译文: %q", message)
+			continue
+		}
+		translated := i18n.Translate(i18n.English, message)
+		if got, want := verbs.FindAllString(translated, -1), verbs.FindAllString(message, -1); strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("译文的格式化动词 %v 与原文 %v 不一致: %q", got, want, message)
+		}
+	}
+}
+
+// translatableMessages 扫描源码，收集 i18n.T/i18n.Sprintf 的字符串参数、命令的 Use/Short/Long 以及参数说明
+func translatableMessages(t *testing.T, root string) []string {
+	var messages []string
+	seen := make(map[string]bool)
+	collect := func(expr ast.Expr) {
+		lit, ok := expr.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return
+		}
+		value, err := strconv.Unquote(lit.Value)
+		if err == nil && !seen[value] {
+			seen[value] = true
+			messages = append(messages, value)
+		}
+	}
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && (info.Name() == "test" || info.Name() == "i18n") {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.KeyValueExpr:
+				if key, ok := n.Key.(*ast.Ident); ok && (key.Name == "Use" || key.Name == "Short" || key.Name == "Long") {
+					collect(n.Value)
+				}
+			case *ast.CallExpr:
+				sel, ok := n.Fun.(*ast.SelectorExpr)
+				if !ok || len(n.Args) == 0 {
+					return true
+				}
+				if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "i18n" && (sel.Sel.Name == "T" || sel.Sel.Name == "Sprintf") {
+					collect(n.Args[0])
+					return true
+				}
+				// cmd.Flags().StringVar(...) 等参数定义的最后一个参数为说明
+				if inner, ok := sel.X.(*ast.CallExpr); ok {
+					if flags, ok := inner.Fun.(*ast.SelectorExpr); ok && strings.HasSuffix(flags.Sel.Name, "Flags") && len(n.Args) >= 3 {
+						collect(n.Args[len(n.Args)-1])
+					}
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("扫描源码失败: %v", err)
+	}
+	return messages
+}
@@ -0,0 +1,196 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/pod"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestJSONPathMetricDeployment 测试 jsonpath 指标从采集到的原始Deployment对象取值
+func TestJSONPathMetricDeployment(t *testing.T) {
+	engine, err := rules.NewEngine(filepath.Join("testdata", "jsonpath_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+
+	container := func(name, image, memory string) corev1.Container {
+		return corev1.Container{
+			Name:  name,
+			Image: image,
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{"memory": resourceQuantity(memory)},
+			},
+		}
+	}
+	nonRoot := true
+	fakeClientset := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "secure", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: int32Ptr(2),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						SecurityContext: &corev1.PodSecurityContext{RunAsNonRoot: &nonRoot},
+						Containers: []corev1.Container{
+							container("app", "nginx:1.25", "512Mi"),
+							container("sidecar", "envoy:1.29", "256Mi"),
+						},
+					},
+				},
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: int32Ptr(1),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							container("app", "nginx:1.25", "512Mi"),
+							container("cache", "redis:latest", "2Gi"),
+						},
+					},
+				},
+			},
+		},
+	)
+
+	cli := &cluster.Client{Clientset: fakeClientset}
+	deployments, err := collector.NewDeploymentCollector(cli).GetDeployments(context.TODO(), "default")
+	if err != nil {
+		t.Fatalf("采集失败: %v", err)
+	}
+
+	// Deployment名称 -> 规则ID -> 期望是否通过
+	expected := map[string]map[string]bool{
+		"secure": {"run-as-non-root": true, "container-memory-limit": true, "no-latest-image": true},
+		"legacy": {"run-as-non-root": false, "container-memory-limit": false, "no-latest-image": false},
+	}
+
+	analyzer := deployment.NewDeploymentAnalyzer(engine, nil)
+	for _, dep := range deployments {
+		result := analyzer.AnalyzeDeployment(dep)
+		got := make(map[string]bool)
+		for _, item := range result.Items {
+			got[item.RuleID] = item.Passed
+		}
+		for ruleID, passed := range expected[dep.Name] {
+			actual, ok := got[ruleID]
+			if !ok {
+				t.Errorf("%s: 规则 %s 未被评估", dep.Name, ruleID)
+				continue
+			}
+			if actual != passed {
+				t.Errorf("%s: 规则 %s 期望通过=%v，实际=%v", dep.Name, ruleID, passed, actual)
+			}
+		}
+	}
+
+	// 多个值时显示全部取值
+	for _, dep := range deployments {
+		if dep.Name != "legacy" {
+			continue
+		}
+		for _, item := range analyzer.AnalyzeDeployment(dep).Items {
+			if item.RuleID == "container-memory-limit" && item.Value != "[512Mi, 2Gi]" {
+				t.Errorf("期望取值 [512Mi, 2Gi]，实际 %s", item.Value)
+			}
+			if item.RuleID == "run-as-non-root" && item.Metric != "{.spec.template.spec.securityContext.runAsNonRoot}" {
+				t.Errorf("jsonpath 规则应显示路径，实际 %s", item.Metric)
+			}
+		}
+	}
+}
+
+// TestJSONPathMetricPod 测试Pod的 jsonpath 规则按问题条件反转结果
+func TestJSONPathMetricPod(t *testing.T) {
+	engine, err := rules.NewEngine(filepath.Join("testdata", "jsonpath_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+	analyzer := pod.NewPodAnalyzer(engine)
+
+	testCases := []struct {
+		name     string
+		object   map[string]interface{}
+		expected bool
+	}{
+		{name: "使用主机网络", object: map[string]interface{}{"spec": map[string]interface{}{"hostNetwork": true}}, expected: false},
+		{name: "未设置主机网络", object: map[string]interface{}{"spec": map[string]interface{}{}}, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := analyzer.AnalyzePod(&models.Pod{Name: "p", Namespace: "default", Object: tc.object})
+			if err != nil {
+				t.Fatalf("分析失败: %v", err)
+			}
+			for _, item := range result.Items {
+				if item.RuleID == "pod-host-network" {
+					if item.Passed != tc.expected {
+						t.Errorf("期望通过=%v，实际=%v", tc.expected, item.Passed)
+					}
+					return
+				}
+			}
+			t.Errorf("规则 pod-host-network 未被评估")
+		})
+	}
+}
+
+// TestJSONPathConditionValidation 测试 jsonpath 条件的加载校验
+func TestJSONPathConditionValidation(t *testing.T) {
+	testCases := []struct {
+		name      string
+		condition string
+		message   string
+	}{
+		{name: "缺少path", condition: "metric: jsonpath\n      operator: \"==\"\n      threshold: true", message: "缺少 path"},
+		{name: "无效path", condition: "metric: jsonpath\n      path: \"{.spec.containers[\"\n      operator: \"==\"\n      threshold: true", message: "无效的JSONPath"},
+		{name: "无效valueType", condition: "metric: jsonpath\n      path: \"{.spec.replicas}\"\n      valueType: integer\n      operator: \"==\"\n      threshold: 1", message: "valueType"},
+		{name: "无效fanOut", condition: "metric: jsonpath\n      path: \"{.spec.replicas}\"\n      fanOut: some\n      operator: \"==\"\n      threshold: 1", message: "fanOut"},
+	}
+
+	dir := t.TempDir()
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := "apiVersion: inspector.k8s/v1\nkind: RulesConfig\nrules:\n  - id: \"invalid\"\n    name: \"无效规则\"\n    category: \"deployment\"\n    severity: \"warning\"\n    condition:\n      " + tc.condition + "\n    enabled: true\n"
+			file := filepath.Join(dir, string(rune('a'+i))+".yaml")
+			if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+				t.Fatalf("写入规则文件失败: %v", err)
+			}
+			_, err := rules.NewEngine(file)
+			if err == nil {
+				t.Fatalf("期望加载失败")
+			}
+			if !strings.Contains(err.Error(), tc.message) {
+				t.Errorf("期望错误包含 %q，实际: %v", tc.message, err)
+			}
+		})
+	}
+}
+
+// TestJSONPathRulesLint 测试 jsonpath 规则通过指标目录检查
+func TestJSONPathRulesLint(t *testing.T) {
+	loader := rules.NewRuleLoader(filepath.Join("testdata", "jsonpath_rules_test.yaml"))
+	if err := loader.LoadRules(); err != nil {
+		t.Fatalf("加载规则失败: %v", err)
+	}
+	for _, issue := range rules.LintConfig(loader.GetRulesConfig()) {
+		t.Errorf("不应有检查问题: %s", issue)
+	}
+}
@@ -0,0 +1,204 @@
+package test
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/configs"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/manifest"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestRequiresRuntime 测试规则条件是否依赖集群运行时数据的判断
+func TestRequiresRuntime(t *testing.T) {
+	duration := 5 * time.Minute
+	testCases := []struct {
+		name      string
+		kind      string
+		condition rules.RuleCondition
+		expected  bool
+	}{
+		{name: "静态指标", kind: "pod", condition: rules.RuleCondition{Metric: "pod_missing_probes"}, expected: false},
+		{name: "运行时指标", kind: "pod", condition: rules.RuleCondition{Metric: "pod_restart_count"}, expected: true},
+		{name: "jsonpath", kind: "pod", condition: rules.RuleCondition{Metric: rules.JSONPathMetric}, expected: false},
+		{name: "持续时间", kind: "deployment", condition: rules.RuleCondition{Metric: "replicas", Duration: &duration}, expected: true},
+		{name: "组合条件中的运行时指标", kind: "service", condition: rules.RuleCondition{
+			Any: []rules.RuleCondition{{Metric: "has_selector"}, {Not: &rules.RuleCondition{Metric: "has_matching_pods"}}},
+		}, expected: true},
+		{name: "组合条件全部为静态指标", kind: "service", condition: rules.RuleCondition{
+			All: []rules.RuleCondition{{Metric: "is_nodeport_type"}, {Metric: "has_selector"}},
+		}, expected: false},
+		{name: "通用指标", kind: "statefulset", condition: rules.RuleCondition{Metric: "has_labels"}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := rules.RequiresRuntime(tc.condition, tc.kind); actual != tc.expected {
+				t.Errorf("期望 %v，实际 %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// TestManifestLoad 测试清单的多文档解析、行号记录和命名空间补全
+func TestManifestLoad(t *testing.T) {
+	stdin := strings.NewReader("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: shop\n")
+	objects, err := manifest.Load([]string{filepath.Join("testdata", "manifests", "lint"), manifest.Stdin}, stdin, "shop")
+	if err != nil {
+		t.Fatalf("加载清单失败: %v", err)
+	}
+
+	actual := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		accessor, err := meta.Accessor(obj.Object)
+		if err != nil {
+			t.Fatalf("读取对象元数据失败: %v", err)
+		}
+		actual = append(actual, strings.Join([]string{
+			obj.GetObjectKind().GroupVersionKind().Kind,
+			accessor.GetNamespace() + "/" + accessor.GetName(),
+			report.SourceLocation{File: filepath.Base(obj.File), Line: obj.Line}.String(),
+		}, " "))
+	}
+	expected := []string{
+		"Deployment shop/web app.yaml:3",
+		"Service shop/web app.yaml:21",
+		"StatefulSet data/db app.yaml:32",
+		"Pod tools/debug pod.json:1",
+		"Namespace /shop -:1",
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("期望:\n%s\n实际:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+
+	if _, err := manifest.Load([]string{manifest.Stdin}, strings.NewReader("# empty\n---\n"), "default"); err == nil || !strings.Contains(err.Error(), "没有Kubernetes对象") {
+		t.Errorf("期望没有对象的错误，实际: %v", err)
+	}
+	if _, err := manifest.Load([]string{manifest.Stdin}, strings.NewReader("kind: Pod\n---\napiVersion: v1\n"), "default"); err == nil || !strings.Contains(err.Error(), "-:1") {
+		t.Errorf("期望错误包含文档位置，实际: %v", err)
+	}
+}
+
+// TestManifestAnalyze 测试清单检查只评估静态规则，发现项带有清单中的位置
+func TestManifestAnalyze(t *testing.T) {
+	engine, err := rules.NewEngine(filepath.Join("testdata", "lint_manifest_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则失败: %v", err)
+	}
+	engine.SetStaticOnly(true)
+
+	objects, err := manifest.Load([]string{filepath.Join("testdata", "manifests", "lint")}, nil, "default")
+	if err != nil {
+		t.Fatalf("加载清单失败: %v", err)
+	}
+	r, err := manifest.Analyze(engine, objects, "", "")
+	if err != nil {
+		t.Fatalf("分析清单失败: %v", err)
+	}
+
+	actual := make([]string, 0, len(r.Findings))
+	for _, finding := range r.Findings {
+		if finding.Source == nil {
+			t.Errorf("发现项 %s %s 缺少清单位置", finding.ResourceName, finding.RuleID)
+			continue
+		}
+		actual = append(actual, fmt.Sprintf("%s %s:%d", finding.RuleID, filepath.Base(finding.Source.File), finding.Source.Line))
+	}
+	sort.Strings(actual)
+	expected := []string{
+		"deployment-min-replicas app.yaml:3",
+		"pod-missing-probes pod.json:1",
+		"service-no-nodeport app.yaml:21",
+		"statefulset-no-host-network app.yaml:32",
+	}
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("期望发现项:\n%s\n实际:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+	if r.Summary.TotalResources != 4 || r.Summary.ResourcesWithIssues != 4 {
+		t.Errorf("汇总不符合预期: %+v", r.Summary)
+	}
+	if r.Summary.FindingCounts[report.SeverityError] != 2 || r.Summary.FindingCounts[report.SeverityCritical] != 0 {
+		t.Errorf("严重性计数不符合预期: %v", r.Summary.FindingCounts)
+	}
+}
+
+// TestManifestAnalyzeBuiltinRules 测试使用内置规则检查清单：StatefulSet 使用 security 规则包中的规则，
+// Service 端口按API服务器的默认值填充协议和目标端口
+func TestManifestAnalyzeBuiltinRules(t *testing.T) {
+	engine, err := rules.NewEngineFromFS(configs.RulesFS, configs.RulesDir)
+	if err != nil {
+		t.Fatalf("加载内置规则失败: %v", err)
+	}
+	engine.SetStaticOnly(true)
+
+	objects, err := manifest.Load([]string{filepath.Join("testdata", "manifests", "lint", "app.yaml")}, nil, "default")
+	if err != nil {
+		t.Fatalf("加载清单失败: %v", err)
+	}
+	r, err := manifest.Analyze(engine, objects, "", "")
+	if err != nil {
+		t.Fatalf("分析清单失败: %v", err)
+	}
+
+	found := false
+	for _, finding := range r.Findings {
+		if finding.ResourceKind == "StatefulSet" && finding.RuleID == "statefulset_no_host_network" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("StatefulSet data/db 应报告 statefulset_no_host_network，实际发现项 %+v", r.Findings)
+	}
+
+	if len(r.ServiceDetails) != 1 || len(r.ServiceDetails[0].Ports) != 1 {
+		t.Fatalf("期望1个Service及1个端口，实际 %+v", r.ServiceDetails)
+	}
+	if port := r.ServiceDetails[0].Ports[0]; port.Protocol != "TCP" || port.TargetPort != "8080" {
+		t.Errorf("端口应按默认值填充为 8080/TCP -> 8080，实际 %+v", port)
+	}
+}
+
+// TestReportMerge 测试合并报告时汇总计数和合规控制项合并
+func TestReportMerge(t *testing.T) {
+	first := &report.Report{
+		ClusterName: "prod",
+		Rules:       []report.RuleInfo{{ID: "a"}, {ID: "b"}},
+		Findings:    []report.Finding{{RuleID: "a", Severity: report.SeverityWarning}},
+		Summary:     report.ReportSummary{TotalResources: 2, ResourcesWithIssues: 1, FindingCounts: map[report.Severity]int{report.SeverityWarning: 1}},
+		Compliance:  []report.ControlResult{{Framework: "cis", ControlID: "5.2.12", Rules: []string{"a"}, Passed: 1, Status: report.ControlPass}},
+	}
+	second := &report.Report{
+		Rules:    []report.RuleInfo{{ID: "b"}, {ID: "c"}},
+		Findings: []report.Finding{{RuleID: "b", Severity: report.SeverityError}},
+		Summary:  report.ReportSummary{TotalResources: 1, ResourcesWithIssues: 1, FindingCounts: map[report.Severity]int{report.SeverityError: 1}},
+		Compliance: []report.ControlResult{
+			{Framework: "cis", ControlID: "5.2.12", Rules: []string{"b"}, Failed: 1, Status: report.ControlFail},
+			{Framework: "cis", ControlID: "5.2.2", Rules: []string{"b"}, Status: report.ControlNotEvaluated},
+		},
+	}
+
+	merged := report.Merge(first, second)
+	if merged.ClusterName != "prod" || len(merged.Findings) != 2 || merged.Summary.TotalResources != 3 || merged.Summary.ResourcesWithIssues != 2 {
+		t.Errorf("合并结果不符合预期: %+v", merged)
+	}
+	if merged.Summary.FindingCounts[report.SeverityWarning] != 1 || merged.Summary.FindingCounts[report.SeverityError] != 1 {
+		t.Errorf("严重性计数不符合预期: %v", merged.Summary.FindingCounts)
+	}
+	if len(merged.Rules) != 3 || merged.Rules[2].ID != "c" {
+		t.Errorf("规则应按ID去重合并: %+v", merged.Rules)
+	}
+	if len(merged.Compliance) != 2 || merged.Compliance[0].ControlID != "5.2.2" {
+		t.Fatalf("合规控制项应合并并排序: %+v", merged.Compliance)
+	}
+	control := merged.Compliance[1]
+	if control.Passed != 1 || control.Failed != 1 || control.Status != report.ControlFail || strings.Join(control.Rules, ",") != "a,b" {
+		t.Errorf("控制项 5.2.12 合并不符合预期: %+v", control)
+	}
+}
@@ -0,0 +1,103 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestQuantityValidator 测试按Kubernetes资源数量比较
+func TestQuantityValidator(t *testing.T) {
+	validator := &rules.QuantityValidator{}
+
+	tests := []struct {
+		name        string
+		actual      interface{}
+		operator    string
+		threshold   interface{}
+		expected    bool
+		expectError bool
+	}{
+		{name: "不同单位相等", actual: "512Mi", operator: "==", threshold: "0.5Gi", expected: true},
+		{name: "毫核小于整核", actual: "250m", operator: "<", threshold: "1", expected: true},
+		{name: "十进制与二进制单位", actual: "1G", operator: "<", threshold: "1Gi", expected: true},
+		{name: "数字阈值", actual: "4Gi", operator: ">=", threshold: 4294967296, expected: true},
+		{name: "Quantity实际值", actual: resource.MustParse("8Gi"), operator: ">", threshold: "4Gi", expected: true},
+		{name: "不等于", actual: "100m", operator: "!=", threshold: "0.1", expected: false},
+		{name: "小于等于", actual: "2Gi", operator: "<=", threshold: "2048Mi", expected: true},
+		{name: "无效实际值", actual: "lots", operator: ">", threshold: "1Gi", expectError: true},
+		{name: "无效阈值", actual: "1Gi", operator: ">", threshold: "big", expectError: true},
+		{name: "不支持的操作符", actual: "1Gi", operator: "contains", threshold: "1Gi", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := rules.RuleCondition{Metric: "memory", Operator: tt.operator, Threshold: tt.threshold}
+			passed, err := validator.Validate("memory", tt.actual, condition, "prod")
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("期望返回错误，实际结果 %v", passed)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("验证失败: %v", err)
+			}
+			if passed != tt.expected {
+				t.Errorf("期望 %v，实际 %v", tt.expected, passed)
+			}
+		})
+	}
+
+	if got := validator.FormatValue(resource.MustParse("4096Mi")); got != "4Gi" {
+		t.Errorf("期望格式化为 4Gi，实际 %s", got)
+	}
+}
+
+// TestDeploymentMemoryLimitRule 测试默认规则中的容器内存上限检查
+func TestDeploymentMemoryLimitRule(t *testing.T) {
+	rulesEngine, err := rules.NewEngine("../configs/rules/deployment.yaml")
+	if err != nil {
+		t.Fatalf("创建规则引擎失败: %v", err)
+	}
+	analyzer := deployment.NewDeploymentAnalyzer(rulesEngine, nil)
+
+	dep := models.Deployment{
+		Name:      "big-memory",
+		Namespace: "default",
+		Replicas:  2,
+		Containers: []models.DeploymentContainer{
+			{Name: "app", Resources: models.ResourceSpec{Limits: map[string]string{"memory": "8Gi"}}},
+			{Name: "sidecar", Resources: models.ResourceSpec{Limits: map[string]string{"memory": "128Mi"}}},
+		},
+	}
+
+	var item *deployment.AnalysisItem
+	result := analyzer.AnalyzeDeployment(dep)
+	for i := range result.Items {
+		if result.Items[i].RuleID == "container_memory_limit_cap" {
+			item = &result.Items[i]
+		}
+	}
+	if item == nil {
+		t.Fatal("未找到 container_memory_limit_cap 的分析项")
+	}
+	if item.Passed {
+		t.Error("8Gi 内存限制应未通过检查")
+	}
+	if item.Value != "8Gi" || !strings.Contains(item.Description, "8Gi") || !strings.Contains(item.Description, "4Gi") {
+		t.Errorf("结果应使用资源单位显示，实际 Value=%s Description=%s", item.Value, item.Description)
+	}
+
+	dep.Containers[0].Resources.Limits["memory"] = "2Gi"
+	for _, it := range analyzer.AnalyzeDeployment(dep).Items {
+		if it.RuleID == "container_memory_limit_cap" && !it.Passed {
+			t.Errorf("2Gi 内存限制应通过检查: %s", it.Description)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+)
+
+// newSampleReport 构造一个包含节点、Pod详情和发现项的报告
+func newSampleReport() *report.Report {
+	r := &report.Report{
+		Timestamp:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		ClusterName: "test-cluster",
+		Namespace:   "default",
+		NodeDetails: []report.NodeDetail{{Name: "node-1", Ready: true, HealthScore: 90}},
+		PodDetails: []report.PodDetail{{
+			Name:      "web-1",
+			Namespace: "default",
+			Phase:     "Running",
+			Containers: []report.PodContainerDetail{{
+				Name:  "nginx",
+				Image: "nginx:latest",
+				CPU:   report.ContainerResourceDetail{Limit: "500m", Utilization: 12.5},
+			}},
+			HealthScore: 80,
+		}},
+		Findings: []report.Finding{{
+			ResourceName:   "web-1",
+			ResourceKind:   "Pod",
+			RuleID:         "pod-missing-probes",
+			Message:        "缺少健康检查探针",
+			Severity:       report.SeverityInfo,
+			Recommendation: "配置探针",
+			Details:        map[string]interface{}{"metric": "pod_missing_probes"},
+		}},
+		Summary: report.ReportSummary{
+			TotalResources:      1,
+			ResourcesWithIssues: 1,
+			FindingCounts:       map[report.Severity]int{report.SeverityInfo: 1},
+		},
+	}
+	return r
+}
+
+// TestJSONFormatter 测试JSON输出包含版本信息和完整的报告内容
+func TestJSONFormatter(t *testing.T) {
+	formatter, err := report.NewFormatter("json", false)
+	if err != nil {
+		t.Fatalf("创建JSON格式化器失败: %v", err)
+	}
+	output := formatter.Format(newSampleReport())
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("JSON输出无法解析: %v\n%s", err, output)
+	}
+
+	if doc["apiVersion"] != report.ReportAPIVersion {
+		t.Errorf("apiVersion 期望 %s，实际 %v", report.ReportAPIVersion, doc["apiVersion"])
+	}
+	if doc["kind"] != report.ReportKind {
+		t.Errorf("kind 期望 %s，实际 %v", report.ReportKind, doc["kind"])
+	}
+	for _, key := range []string{"timestamp", "clusterName", "nodeDetails", "podDetails", "findings", "summary"} {
+		if _, ok := doc[key]; !ok {
+			t.Errorf("JSON输出缺少字段 %s", key)
+		}
+	}
+
+	// 反序列化回文档结构，验证字段可以完整还原
+	var parsed report.Document
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("反序列化报告文档失败: %v", err)
+	}
+	if parsed.Report == nil || len(parsed.Findings) != 1 || parsed.Findings[0].RuleID != "pod-missing-probes" {
+		t.Errorf("发现项未正确还原: %+v", parsed.Report)
+	}
+	if parsed.Summary.FindingCounts[report.SeverityInfo] != 1 {
+		t.Errorf("汇总计数未正确还原: %+v", parsed.Summary)
+	}
+	if len(parsed.PodDetails) != 1 || parsed.PodDetails[0].Containers[0].CPU.Limit != "500m" {
+		t.Errorf("Pod详情未正确还原: %+v", parsed.PodDetails)
+	}
+}
+
+// TestYAMLFormatterMatchesJSON 测试YAML输出与JSON输出的结构一致
+func TestYAMLFormatterMatchesJSON(t *testing.T) {
+	sample := newSampleReport()
+
+	yamlFormatter, err := report.NewFormatter("yaml", false)
+	if err != nil {
+		t.Fatalf("创建YAML格式化器失败: %v", err)
+	}
+	jsonFromYAML, err := yaml.YAMLToJSON([]byte(yamlFormatter.Format(sample)))
+	if err != nil {
+		t.Fatalf("YAML输出无法解析: %v", err)
+	}
+
+	var fromYAML, fromJSON map[string]interface{}
+	if err := json.Unmarshal(jsonFromYAML, &fromYAML); err != nil {
+		t.Fatalf("解析YAML转换结果失败: %v", err)
+	}
+	if err := json.Unmarshal([]byte(report.NewJSONFormatter(false).Format(sample)), &fromJSON); err != nil {
+		t.Fatalf("解析JSON输出失败: %v", err)
+	}
+
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		t.Errorf("YAML与JSON输出结构不一致\nYAML: %v\nJSON: %v", fromYAML, fromJSON)
+	}
+}
+
+// TestNewFormatterUnsupported 测试不支持的输出格式返回错误
+func TestNewFormatterUnsupported(t *testing.T) {
+	if _, err := report.NewFormatter("xml", false); err == nil {
+		t.Error("不支持的输出格式应返回错误")
+	}
+	if _, err := report.NewFormatter("text", true); err != nil {
+		t.Errorf("text格式应被支持: %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package test
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/pod"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/service"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestServiceReportGeneration 测试Service分析结果经过报告生成器后的发现项与汇总
+func TestServiceReportGeneration(t *testing.T) {
+	rulesEngine, err := rules.NewEngine("../configs/rules/service.yaml")
+	if err != nil {
+		t.Fatalf("创建规则引擎失败: %v", err)
+	}
+
+	// 与 testdata/manifests/service_risky.yaml 对应的Service
+	risky := models.Service{
+		Name:        "risky-service",
+		Namespace:   "default",
+		Labels:      map[string]string{"app": "web"},
+		Annotations: map[string]string{"secret-token": "abc123"},
+		Type:        "LoadBalancer",
+		Ports:       []models.ServicePort{{Name: "ssh", Protocol: "TCP", Port: 22, TargetPort: "22"}},
+		Selector:    map[string]string{"app": "nonexistent"},
+	}
+	healthy := models.Service{
+		Name:           "healthy-service",
+		Namespace:      "default",
+		Labels:         map[string]string{"owner": "team-backend"},
+		Type:           "ClusterIP",
+		Ports:          []models.ServicePort{{Name: "http", Protocol: "TCP", Port: 8080, TargetPort: "8080"}},
+		Selector:       map[string]string{"app": "backend"},
+		ReadyEndpoints: 1,
+		MatchingPods:   []models.ServicePod{{Name: "backend-1", Namespace: "default", Ready: true, Phase: "Running"}},
+	}
+
+	analyzer := service.NewServiceAnalyzer(rulesEngine, nil)
+	results := []*service.AnalysisResult{
+		analyzer.AnalyzeService(&risky),
+		analyzer.AnalyzeService(&healthy),
+	}
+
+	r := report.NewGenerator("test-cluster", "").GenerateServiceReport(results, rulesEngine.GetRules(rules.RuleFilter{}))
+
+	if r.Summary.TotalResources != 2 {
+		t.Errorf("TotalResources 期望 2，实际 %d", r.Summary.TotalResources)
+	}
+	if r.Summary.ResourcesWithIssues != 1 {
+		t.Errorf("ResourcesWithIssues 期望 1，实际 %d", r.Summary.ResourcesWithIssues)
+	}
+	if len(r.ServiceDetails) != 2 {
+		t.Fatalf("ServiceDetails 期望 2 项，实际 %d", len(r.ServiceDetails))
+	}
+	if r.ServiceDetails[1].ChecksFailed != 0 {
+		t.Errorf("健康的Service不应有未通过的检查，实际 %d", r.ServiceDetails[1].ChecksFailed)
+	}
+
+	expectedRules := []string{
+		"loadbalancer_security_risk",
+		"loadbalancer_source_ranges",
+		"avoid_privileged_ports",
+		"sensitive_annotations",
+		"endpoint_availability",
+		"valid_selector",
+		"service_require_owner_label",
+	}
+	failed := make(map[string]bool)
+	for _, finding := range r.Findings {
+		if finding.ResourceKind != "Service" || finding.ResourceName != "default/risky-service" {
+			t.Errorf("发现项资源不正确: %s %s", finding.ResourceKind, finding.ResourceName)
+		}
+		failed[finding.RuleID] = true
+	}
+	for _, id := range expectedRules {
+		if !failed[id] {
+			t.Errorf("期望规则 %s 未通过", id)
+		}
+	}
+
+	// 汇总计数应与发现项数量一致
+	total := 0
+	for _, count := range r.Summary.FindingCounts {
+		total += count
+	}
+	if total != len(r.Findings) {
+		t.Errorf("严重性计数之和 %d 与发现项数量 %d 不一致", total, len(r.Findings))
+	}
+}
+
+// TestDeploymentReportGeneration 测试Deployment通过采集器、分析器和报告生成器的完整流程
+func TestDeploymentReportGeneration(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "single-replica",
+				Namespace: "default",
+				Labels:    map[string]string{"owner": "team-a"},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: int32Ptr(1),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:            "app",
+							Image:           "nginx:latest",
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Resources: corev1.ResourceRequirements{
+								Limits:   corev1.ResourceList{"cpu": resourceQuantity("500m")},
+								Requests: corev1.ResourceList{"cpu": resourceQuantity("100m")},
+							},
+						}},
+					},
+				},
+			},
+		},
+	)
+
+	rulesEngine, err := rules.NewEngine("testdata/deployment_rules_test.yaml")
+	if err != nil {
+		t.Fatalf("创建规则引擎失败: %v", err)
+	}
+
+	cli := &cluster.Client{Clientset: fakeClientset}
+	analyzer := deployment.NewDeploymentAnalyzer(rulesEngine, collector.NewDeploymentCollector(cli))
+	results, err := analyzer.AnalyzeDeploymentsInNamespace("default")
+	if err != nil {
+		t.Fatalf("分析Deployment失败: %v", err)
+	}
+
+	r := report.NewGenerator("test-cluster", "").GenerateDeploymentReport(results, rulesEngine.GetRules(rules.RuleFilter{}))
+
+	if len(r.Findings) != 1 || r.Findings[0].RuleID != "min_replicas" {
+		t.Fatalf("期望仅 min_replicas 未通过，实际: %+v", r.Findings)
+	}
+	if r.Findings[0].ResourceName != "default/single-replica" || r.Findings[0].ResourceKind != "Deployment" {
+		t.Errorf("发现项资源不正确: %s %s", r.Findings[0].ResourceKind, r.Findings[0].ResourceName)
+	}
+	if r.Summary.FindingCounts[report.SeverityWarning] != 1 {
+		t.Errorf("WARNING 计数期望 1，实际 %d", r.Summary.FindingCounts[report.SeverityWarning])
+	}
+	if len(r.DeploymentDetails) != 1 || r.DeploymentDetails[0].Replicas != 1 || r.DeploymentDetails[0].ChecksFailed != 1 {
+		t.Errorf("Deployment详情不正确: %+v", r.DeploymentDetails)
+	}
+}
+
+// TestPodReportGeneration 测试Pod发现项保留分析器生成的消息，容器级检查在详情中记录容器名称
+func TestPodReportGeneration(t *testing.T) {
+	result := &pod.AnalysisResult{
+		PodName:   "web-0",
+		Namespace: "shop",
+		Items: []pod.AnalysisItem{
+			{RuleID: "pod-missing-limits", Severity: "warning", Description: "容器 app 缺少资源限制", Container: "app"},
+			{RuleID: "pod-missing-probes", Severity: "info", Description: "Pod缺少健康检查探针"},
+		},
+	}
+
+	r := report.NewGenerator("test-cluster", "").GeneratePodReport([]*pod.AnalysisResult{result}, nil)
+	if len(r.Findings) != 2 {
+		t.Fatalf("期望2个发现项，实际 %d", len(r.Findings))
+	}
+	for i, item := range result.Items {
+		finding := r.Findings[i]
+		if finding.Message != item.Description {
+			t.Errorf("发现项 %s 的消息应为 %q，实际 %q", item.RuleID, item.Description, finding.Message)
+		}
+		if container, _ := finding.Details["container"].(string); container != item.Container {
+			t.Errorf("发现项 %s 的容器应为 %q，实际 %q", item.RuleID, item.Container, container)
+		}
+	}
+}
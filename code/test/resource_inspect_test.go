@@ -0,0 +1,178 @@
+package test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/generic"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/cluster"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestParseGroupVersionKind 测试资源类型参数的解析
+func TestParseGroupVersionKind(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected schema.GroupVersionKind
+		invalid  bool
+	}{
+		{value: "cert-manager.io/v1/Certificate", expected: schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}},
+		{value: "apps/v1/StatefulSet", expected: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}},
+		{value: "v1/ConfigMap", expected: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}},
+		{value: "Certificate", invalid: true},
+		{value: "cert-manager.io/v1/", invalid: true},
+		{value: "a/b/c/Kind", invalid: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			gvk, err := cluster.ParseGroupVersionKind(tc.value)
+			if tc.invalid {
+				if err == nil {
+					t.Errorf("期望解析失败，实际: %v", gvk)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("解析失败: %v", err)
+			}
+			if gvk != tc.expected {
+				t.Errorf("期望 %v，实际 %v", tc.expected, gvk)
+			}
+		})
+	}
+}
+
+// newCertificate 创建 cert-manager Certificate 对象
+func newCertificate(namespace, name, issuerKind, secretName string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cert-manager.io/v1",
+		"kind":       "Certificate",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"secretName": secretName,
+			"issuerRef": map[string]interface{}{
+				"name": "letsencrypt",
+				"kind": issuerKind,
+			},
+		},
+	}}
+}
+
+// TestResourceInspectCRD 测试通过 dynamic client 检查CRD资源并生成报告
+func TestResourceInspectCRD(t *testing.T) {
+	gvr := schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+	clientset := fake.NewSimpleClientset()
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "cert-manager.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "certificates", SingularName: "certificate", Namespaced: true, Kind: "Certificate"},
+				{Name: "certificates/status", Namespaced: true, Kind: "Certificate"},
+			},
+		},
+	}
+	dynamicClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "CertificateList"},
+		newCertificate("default", "web", "ClusterIssuer", "web-tls"),
+		newCertificate("default", "legacy", "Issuer", "legacy-cert"),
+		newCertificate("kube-system", "webhook", "ClusterIssuer", "webhook-cert"),
+	)
+	cli := &cluster.Client{Clientset: clientset, DynamicClient: dynamicClient}
+
+	engine, err := rules.NewEngine(filepath.Join("testdata", "resource_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+
+	// 资源类型不区分大小写
+	gvk, err := cluster.ParseGroupVersionKind("cert-manager.io/v1/certificate")
+	if err != nil {
+		t.Fatalf("解析资源类型失败: %v", err)
+	}
+	analyzer := generic.NewResourceAnalyzer(engine, collector.NewResourceCollector(cli))
+	results, err := analyzer.AnalyzeResources(gvk, "")
+	if err != nil {
+		t.Fatalf("分析失败: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("期望分析3个Certificate，实际: %d", len(results))
+	}
+
+	// 资源 -> 规则ID -> 期望是否通过
+	expected := map[string]map[string]bool{
+		"default/web":         {"certificate-cluster-issuer": true, "certificate-secret-name": true},
+		"default/legacy":      {"certificate-cluster-issuer": false, "certificate-secret-name": false},
+		"kube-system/webhook": {"certificate-cluster-issuer": true, "certificate-secret-name": true},
+	}
+	for _, result := range results {
+		key := result.Namespace + "/" + result.ResourceName
+		got := make(map[string]bool)
+		for _, item := range result.Items {
+			got[item.RuleID] = item.Passed
+		}
+		if len(got) != len(expected[key]) {
+			t.Errorf("%s: 期望评估规则 %v，实际 %v", key, expected[key], got)
+		}
+		for ruleID, passed := range expected[key] {
+			if actual, ok := got[ruleID]; !ok || actual != passed {
+				t.Errorf("%s: 规则 %s 期望通过=%v，实际=%v (已评估: %v)", key, ruleID, passed, actual, ok)
+			}
+		}
+	}
+
+	r := report.NewGenerator("test-cluster", "").GenerateResourceReport(results, nil)
+	if r.Summary.TotalResources != 3 || r.Summary.ResourcesWithIssues != 1 {
+		t.Errorf("期望3个资源中1个有问题，实际 %d/%d", r.Summary.ResourcesWithIssues, r.Summary.TotalResources)
+	}
+	for _, finding := range r.Findings {
+		if finding.ResourceKind != "Certificate" || finding.ResourceName != "default/legacy" {
+			t.Errorf("发现项资源错误: %s %s", finding.ResourceKind, finding.ResourceName)
+		}
+	}
+	output := report.NewTextFormatter(false).Format(r)
+	if !strings.Contains(output, "RESOURCE DETAILS") || !strings.Contains(output, "Certificate: default/legacy") {
+		t.Errorf("文本报告缺少资源详情:\n%s", output)
+	}
+}
+
+// TestResourceInspectUnknownKind 测试集群中不存在的资源类型
+func TestResourceInspectUnknownKind(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: []metav1.APIResource{{Name: "configmaps", Namespaced: true, Kind: "ConfigMap"}}},
+	}
+	cli := &cluster.Client{Clientset: clientset}
+
+	_, err := collector.NewResourceCollector(cli).GetResources(context.TODO(), schema.GroupVersionKind{Version: "v1", Kind: "Widget"}, "")
+	if err == nil || !strings.Contains(err.Error(), "不存在资源类型") {
+		t.Errorf("期望资源类型不存在的错误，实际: %v", err)
+	}
+}
+
+// TestGenericRulesLint 测试没有专用分析器的资源类型只能使用通用指标
+func TestGenericRulesLint(t *testing.T) {
+	loader := rules.NewRuleLoader(filepath.Join("testdata", "resource_rules_test.yaml"))
+	if err := loader.LoadRules(); err != nil {
+		t.Fatalf("加载规则失败: %v", err)
+	}
+	issues := rules.LintConfig(loader.GetRulesConfig())
+	if len(issues) != 1 || issues[0].RuleID != "certificate-replicas" || issues[0].Level != rules.LintError {
+		t.Errorf("期望只有 certificate-replicas 报告错误，实际: %v", issues)
+	}
+}
@@ -0,0 +1,153 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/pod"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/i18n"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// deploymentItemDescription 返回Deployment分析结果中指定规则的描述
+func deploymentItemDescription(t *testing.T, result *deployment.AnalysisResult, ruleID string) string {
+	for _, item := range result.Items {
+		if item.RuleID == ruleID {
+			return item.Description
+		}
+	}
+	t.Fatalf("分析结果中没有规则 %s", ruleID)
+	return ""
+}
+
+// TestRuleMessageTemplate 测试规则消息模板使用资源、实际值、期望值和环境生成发现项消息
+func TestRuleMessageTemplate(t *testing.T) {
+	defer i18n.SetLanguage(i18n.Chinese)
+
+	engine, err := rules.NewEngine(filepath.Join("testdata", "message_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+	analyzer := deployment.NewDeploymentAnalyzer(engine, nil)
+	dep := models.Deployment{Name: "api", Namespace: "shop", Replicas: 1}
+
+	result := analyzer.AnalyzeDeployment(dep)
+	if got, want := deploymentItemDescription(t, result, "min-replicas"), "Deployment shop/api 只有 1 个副本，prod 环境要求至少 3 个"; got != want {
+		t.Errorf("模板消息期望 %q，实际 %q", want, got)
+	}
+	// 未设置模板的规则使用默认消息
+	if got := deploymentItemDescription(t, result, "owner-label"); !strings.HasPrefix(got, "必须设置负责人标签: 检查失败") {
+		t.Errorf("默认消息不符合预期: %q", got)
+	}
+
+	// 检查通过时不使用模板
+	dep.Replicas = 3
+	if got := deploymentItemDescription(t, analyzer.AnalyzeDeployment(dep), "min-replicas"); !strings.Contains(got, "检查通过") {
+		t.Errorf("检查通过时应使用默认消息，实际 %q", got)
+	}
+
+	// 英文使用 locales 中的模板
+	i18n.SetLanguage(i18n.English)
+	dep.Replicas = 1
+	if got, want := deploymentItemDescription(t, analyzer.AnalyzeDeployment(dep), "min-replicas"), "Deployment api has 1 replica; prod requires 3"; got != want {
+		t.Errorf("英文模板消息期望 %q，实际 %q", want, got)
+	}
+}
+
+// TestRuleMessageTemplateForProblemRules 测试描述问题状态的Pod规则在条件成立时使用模板消息
+func TestRuleMessageTemplateForProblemRules(t *testing.T) {
+	engine, err := rules.NewEngine(filepath.Join("testdata", "message_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+	analyzer := pod.NewPodAnalyzer(engine)
+
+	testCases := []struct {
+		name     string
+		restarts int
+		passed   bool
+		expected string
+	}{
+		{name: "重启过多", restarts: 5, passed: false, expected: "Pod web 已重启 5 次 (上限 3)，应用: shop"},
+		{name: "重启次数正常", restarts: 1, passed: true, expected: "Pod总重启次数为 1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := analyzer.AnalyzePod(&models.Pod{
+				Name:          "web",
+				Namespace:     "default",
+				Labels:        map[string]string{"app": "shop"},
+				Phase:         corev1.PodRunning,
+				TotalRestarts: tc.restarts,
+			})
+			if err != nil {
+				t.Fatalf("分析失败: %v", err)
+			}
+			for _, item := range result.Items {
+				if item.RuleID != "pod-restarts" {
+					continue
+				}
+				if item.Passed != tc.passed || item.Description != tc.expected {
+					t.Errorf("期望 Passed=%v 描述 %q，实际 Passed=%v 描述 %q", tc.passed, tc.expected, item.Passed, item.Description)
+				}
+				return
+			}
+			t.Fatalf("分析结果中没有规则 pod-restarts")
+		})
+	}
+}
+
+// TestRuleMessageTemplateValidation 测试加载规则时拒绝无效的消息模板
+func TestRuleMessageTemplateValidation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		message string
+		locale  string
+		errPart string
+	}{
+		{name: "语法错误", message: "{{.Name", errPart: "message 模板无效"},
+		{name: "未知字段", message: "{{.Replicas}}", errPart: "message 模板无效"},
+		{name: "语言模板未知字段", locale: "{{.Owner}}", errPart: "locales.en.message 模板无效"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := `apiVersion: inspector.k8s/v1
+kind: RulesConfig
+rules:
+  - id: "min-replicas"
+    name: "副本数检查"
+    category: "deployment"
+    severity: "warning"
+    condition:
+      metric: "replicas"
+      operator: ">="
+      threshold: 2
+    enabled: true
+`
+			if tc.message != "" {
+				content += "    message: " + strconv.Quote(tc.message) + "\n"
+			}
+			if tc.locale != "" {
+				content += "    locales:\n      en:\n        message: " + strconv.Quote(tc.locale) + "\n"
+			}
+			path := filepath.Join(t.TempDir(), "rules.yaml")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("写入规则文件失败: %v", err)
+			}
+
+			_, err := rules.NewEngine(path)
+			if err == nil || !strings.Contains(err.Error(), tc.errPart) {
+				t.Errorf("期望包含 %q 的错误，实际: %v", tc.errPart, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/node"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestSetRangeVersionOperators 测试集合、区间和版本操作符在各类型验证器中的行为
+func TestSetRangeVersionOperators(t *testing.T) {
+	tests := []struct {
+		name        string
+		validator   rules.Validator
+		actual      interface{}
+		operator    string
+		threshold   interface{}
+		expected    bool
+		expectError bool
+	}{
+		{name: "数值在列表中", validator: &rules.NumericValidator{}, actual: 443, operator: "in", threshold: []interface{}{80, 443}, expected: true},
+		{name: "数值不在列表中", validator: &rules.NumericValidator{}, actual: 8080, operator: "not_in", threshold: []interface{}{80, 443}, expected: true},
+		{name: "端口在区间内", validator: &rules.NumericValidator{}, actual: 30000, operator: "between", threshold: []interface{}{30000, 32767}, expected: true},
+		{name: "端口超出区间", validator: &rules.NumericValidator{}, actual: 40000, operator: "between", threshold: []interface{}{30000, 32767}, expected: false},
+		{name: "区间元素个数错误", validator: &rules.NumericValidator{}, actual: 1, operator: "between", threshold: []interface{}{1, 2, 3}, expectError: true},
+		{name: "资源数量在区间内", validator: &rules.QuantityValidator{}, actual: "512Mi", operator: "between", threshold: []interface{}{"256Mi", "1Gi"}, expected: true},
+		{name: "资源数量按单位匹配列表", validator: &rules.QuantityValidator{}, actual: "1024Mi", operator: "in", threshold: []interface{}{"1Gi", "2Gi"}, expected: true},
+		{name: "字符串在允许列表中", validator: &rules.StringValidator{}, actual: "ClusterIP", operator: "in", threshold: []interface{}{"ClusterIP", "NodePort"}, expected: true},
+		{name: "字符串在禁止列表中", validator: &rules.StringValidator{}, actual: "LoadBalancer", operator: "not_in", threshold: []interface{}{"LoadBalancer"}, expected: false},
+		{name: "字符串前缀", validator: &rules.StringValidator{}, actual: "registry.example.com/app", operator: "starts_with", threshold: "registry.example.com/", expected: true},
+		{name: "字符串后缀", validator: &rules.StringValidator{}, actual: "app:latest", operator: "ends_with", threshold: ":latest", expected: true},
+		{name: "所有值都在列表中", validator: &rules.StringValidator{}, actual: []string{"ghcr.io", "registry.example.com"}, operator: "in", threshold: []interface{}{"ghcr.io", "registry.example.com"}, expected: true},
+		{name: "部分值不在列表中", validator: &rules.StringValidator{}, actual: []string{"ghcr.io", "docker.io"}, operator: "in", threshold: []interface{}{"ghcr.io"}, expected: false},
+		{name: "带发行版后缀的版本较低", validator: &rules.StringValidator{}, actual: "v1.27.4-eks-abc", operator: "version_lt", threshold: "1.28", expected: true},
+		{name: "补零后版本相等", validator: &rules.StringValidator{}, actual: "v1.28", operator: "version_gte", threshold: "1.28.0", expected: true},
+		{name: "次版本号按数值比较", validator: &rules.StringValidator{}, actual: "1.9.0", operator: "version_lt", threshold: "1.10.0", expected: true},
+		{name: "无效版本号", validator: &rules.StringValidator{}, actual: "unknown", operator: "version_lt", threshold: "1.28", expectError: true},
+		{name: "标签值在列表中", validator: &rules.MapValidator{}, actual: map[string]string{"env": "prod"}, operator: "in", threshold: map[interface{}]interface{}{"env": []interface{}{"prod", "staging"}}, expected: true},
+		{name: "缺少标签不满足in", validator: &rules.MapValidator{}, actual: map[string]string{}, operator: "in", threshold: map[interface{}]interface{}{"env": []interface{}{"prod"}}, expected: false},
+		{name: "缺少标签满足not_in", validator: &rules.MapValidator{}, actual: map[string]string{}, operator: "not_in", threshold: map[interface{}]interface{}{"env": []interface{}{"dev"}}, expected: true},
+		{name: "标签值前缀", validator: &rules.MapValidator{}, actual: map[string]string{"team": "platform-core"}, operator: "starts_with", threshold: map[interface{}]interface{}{"team": "platform-"}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := rules.RuleCondition{Metric: "metric", Operator: tt.operator, Threshold: tt.threshold}
+			passed, err := tt.validator.Validate("metric", tt.actual, condition, "prod")
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("期望返回错误，实际结果 %v", passed)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("验证失败: %v", err)
+			}
+			if passed != tt.expected {
+				t.Errorf("期望 %v，实际 %v", tt.expected, passed)
+			}
+		})
+	}
+}
+
+// TestOperatorThresholdShape 测试加载规则时对新操作符阈值格式的校验
+func TestOperatorThresholdShape(t *testing.T) {
+	content := `apiVersion: inspector.k8s/v1
+kind: RulesConfig
+rules:
+  - id: "nodeport_range"
+    name: "NodePort端口范围"
+    category: "service"
+    severity: "warning"
+    condition: {metric: "min_port", operator: "between", threshold: [30000, 31000, 32767]}
+    enabled: true
+`
+	file := filepath.Join(t.TempDir(), "between.yaml")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("写入规则文件失败: %v", err)
+	}
+	_, err := rules.NewEngine(file)
+	if err == nil || !strings.Contains(err.Error(), "nodeport_range") {
+		t.Errorf("期望 between 阈值格式错误，实际: %v", err)
+	}
+}
+
+// TestImageRegistriesAndKubeletVersion 测试镜像仓库和Kubelet版本指标
+func TestImageRegistriesAndKubeletVersion(t *testing.T) {
+	dep := models.Deployment{
+		Containers: []models.DeploymentContainer{
+			{Name: "app", Image: "registry.example.com/team/app:1.0"},
+			{Name: "proxy", Image: "nginx:1.25"},
+			{Name: "local", Image: "localhost/tools"},
+		},
+	}
+	registries := deployment.ImageRegistries(dep)
+	if strings.Join(registries, ",") != "registry.example.com,docker.io,localhost" {
+		t.Errorf("镜像仓库解析错误: %v", registries)
+	}
+
+	rulesEngine, err := rules.NewEngine("../configs/rules/node.yaml")
+	if err != nil {
+		t.Fatalf("创建规则引擎失败: %v", err)
+	}
+	analyzer := node.NewNodeAnalyzer(rulesEngine, nil)
+
+	for _, tt := range []struct {
+		version string
+		passed  bool
+	}{
+		{version: "v1.25.16-eks-59bf375", passed: false},
+		{version: "v1.29.2", passed: true},
+	} {
+		result, err := analyzer.AnalyzeNode(&models.Node{
+			Name:     "worker-1",
+			Ready:    true,
+			NodeInfo: models.NodeInfo{KubeletVersion: tt.version},
+		})
+		if err != nil {
+			t.Fatalf("分析节点失败: %v", err)
+		}
+		found := false
+		for _, item := range result.Items {
+			if item.RuleID == "node-outdated-kubelet" {
+				found = true
+				if item.Passed != tt.passed {
+					t.Errorf("版本 %s 期望通过=%v，实际 %v", tt.version, tt.passed, item.Passed)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("版本 %s 未生成 node-outdated-kubelet 分析项", tt.version)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestRuleScopeMatch 测试规则的 match/exclude 作用范围
+func TestRuleScopeMatch(t *testing.T) {
+	engine, err := rules.NewEngine(filepath.Join("testdata", "scope_rules_test.yaml"))
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+	if !engine.UsesNamespaceSelectors() {
+		t.Fatalf("规则使用了命名空间标签选择器，UsesNamespaceSelectors 应返回true")
+	}
+	engine.SetNamespaceLabels(map[string]map[string]string{
+		"jobs": {"workload": "batch"},
+		"prod": {"env": "prod"},
+	})
+
+	testCases := []struct {
+		name     string
+		category string
+		resource rules.ResourceMeta
+		expected []string
+	}{
+		{
+			name:     "普通命名空间的Pod",
+			category: "pod",
+			resource: rules.ResourceMeta{Name: "api-0", Namespace: "prod"},
+			expected: []string{"pod-missing-probes"},
+		},
+		{
+			name:     "批处理命名空间的Pod被排除",
+			category: "pod",
+			resource: rules.ResourceMeta{Name: "job-0", Namespace: "jobs"},
+			expected: nil,
+		},
+		{
+			name:     "生产环境前端Deployment",
+			category: "deployment",
+			resource: rules.ResourceMeta{Name: "web", Namespace: "prod", Labels: map[string]string{"tier": "frontend"}},
+			expected: []string{"frontend-min-replicas"},
+		},
+		{
+			name:     "后端Deployment不匹配标签选择器",
+			category: "deployment",
+			resource: rules.ResourceMeta{Name: "db", Namespace: "prod", Labels: map[string]string{"tier": "backend"}},
+			expected: nil,
+		},
+		{
+			name:     "不在指定命名空间",
+			category: "deployment",
+			resource: rules.ResourceMeta{Name: "web", Namespace: "dev", Labels: map[string]string{"tier": "frontend"}},
+			expected: nil,
+		},
+		{
+			name:     "名称匹配排除正则",
+			category: "deployment",
+			resource: rules.ResourceMeta{Name: "canary-web", Namespace: "staging", Labels: map[string]string{"tier": "frontend"}},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resource := tc.resource
+			got := engine.GetRules(rules.RuleFilter{Categories: []string{tc.category}, Resource: &resource})
+			var ids []string
+			for _, rule := range got {
+				ids = append(ids, rule.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("期望规则 %v，实际 %v", tc.expected, ids)
+			}
+		})
+	}
+
+	// 未指定资源时返回全部规则
+	if all := engine.GetRules(rules.RuleFilter{}); len(all) != 2 {
+		t.Errorf("期望2条规则，实际 %d", len(all))
+	}
+}
+
+// TestRuleScopeInvalidSelector 测试作用范围中无效的选择器和正则表达式
+func TestRuleScopeInvalidSelector(t *testing.T) {
+	testCases := []struct {
+		name   string
+		scope  string
+		errMsg string
+	}{
+		{"无效标签选择器", `match: {labelSelector: "tier in (a"}`, "无效的标签选择器"},
+		{"无效名称正则", `exclude: {names: ["web-("]}`, "无效的名称正则表达式"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := `apiVersion: inspector.k8s/v1
+kind: RulesConfig
+rules:
+  - id: "scoped"
+    name: "作用范围规则"
+    category: "deployment"
+    severity: "warning"
+    condition: {metric: "replicas", operator: ">=", threshold: 2}
+    ` + tc.scope + `
+    enabled: true
+`
+			path := filepath.Join(t.TempDir(), "rules.yaml")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("写入规则文件失败: %v", err)
+			}
+			if _, err := rules.NewEngine(path); err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("期望错误包含 %q，实际: %v", tc.errMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/ruletest"
+)
+
+// TestDefaultRuleSuites 使用测试夹具验证内置规则包的结果
+func TestDefaultRuleSuites(t *testing.T) {
+	suites, err := ruletest.LoadSuites("testdata/rulesuites")
+	if err != nil {
+		t.Fatalf("加载测试套件失败: %v", err)
+	}
+	if len(suites) == 0 {
+		t.Fatal("未找到测试套件")
+	}
+	for _, suite := range suites {
+		for _, result := range ruletest.Run(suite) {
+			if result.Err != nil {
+				t.Errorf("%s/%s: %v", suite.Name, result.Name, result.Err)
+			}
+			for _, diff := range result.Diffs {
+				t.Errorf("%s/%s: %s %s 期望 %s，实际 %s (%s)",
+					suite.Name, result.Name, diff.Resource, diff.RuleID, diff.Expected, diff.Actual, diff.Detail)
+			}
+		}
+	}
+}
+
+// TestRuleSuiteDiffs 测试期望不一致和规则ID错误时的用例结果
+func TestRuleSuiteDiffs(t *testing.T) {
+	fixture, err := filepath.Abs("testdata/manifests/service_risky.yaml")
+	if err != nil {
+		t.Fatalf("获取夹具路径失败: %v", err)
+	}
+	rulesFile, err := filepath.Abs("../configs/rules/service.yaml")
+	if err != nil {
+		t.Fatalf("获取规则路径失败: %v", err)
+	}
+	content := `name: "差异"
+rules: ["` + rulesFile + `"]
+tests:
+  - name: "错误的期望"
+    kind: service
+    fixtures: ["` + fixture + `"]
+    expect:
+      service_require_owner_label: pass
+      loadbalancer_security_risk: fail
+  - name: "未知规则"
+    kind: service
+    fixtures: ["` + fixture + `"]
+    expect:
+      no_such_rule: fail
+`
+	file := filepath.Join(t.TempDir(), "suite.yaml")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("写入测试套件失败: %v", err)
+	}
+
+	suite, err := ruletest.LoadSuite(file)
+	if err != nil {
+		t.Fatalf("加载测试套件失败: %v", err)
+	}
+	results := ruletest.Run(suite)
+	if len(results) != 2 {
+		t.Fatalf("期望 2 个用例结果，实际 %d", len(results))
+	}
+
+	if results[0].Passed() || len(results[0].Diffs) != 1 {
+		t.Fatalf("期望 1 个差异，实际: %+v", results[0])
+	}
+	diff := results[0].Diffs[0]
+	if diff.Resource != "default/risky-service" || diff.RuleID != "service_require_owner_label" ||
+		diff.Expected != ruletest.OutcomePass || diff.Actual != ruletest.OutcomeFail {
+		t.Errorf("差异内容不正确: %+v", diff)
+	}
+
+	if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "no_such_rule") {
+		t.Errorf("期望规则不存在错误，实际: %v", results[1].Err)
+	}
+}
+
+// TestRuleSuiteValidation 测试无效的期望结果在加载时报错
+func TestRuleSuiteValidation(t *testing.T) {
+	content := `tests:
+  - name: "无效期望"
+    kind: service
+    fixtures: ["svc.yaml"]
+    expect:
+      service_require_owner_label: failed
+`
+	file := filepath.Join(t.TempDir(), "suite.yaml")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("写入测试套件失败: %v", err)
+	}
+	if _, err := ruletest.LoadSuite(file); err == nil || !strings.Contains(err.Error(), "failed") {
+		t.Errorf("期望返回无效期望结果错误，实际: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/configs"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/deployment"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/analyzer/service"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/models"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestRulesLint 测试按指标目录检查规则配置
+func TestRulesLint(t *testing.T) {
+	loader := rules.NewRuleLoader("testdata/lint_rules_test.yaml")
+	if err := loader.LoadRules(); err != nil {
+		t.Fatalf("加载规则失败: %v", err)
+	}
+	issues := rules.LintConfig(loader.GetRulesConfig())
+
+	// 规则ID -> 期望的问题描述片段
+	expected := []struct {
+		ruleID  string
+		level   string
+		path    string
+		message string
+	}{
+		{ruleID: "typo-metric", level: rules.LintError, message: `是否为 "replicas"`},
+		{ruleID: "quantity-as-number", level: rules.LintError, message: "应为数字"},
+		{ruleID: "bool-operator", level: rules.LintError, message: `不支持操作符 ">"`},
+		{ruleID: "env-thresholds", level: rules.LintWarning, message: `环境阈值 "prod"`},
+		{ruleID: "env-thresholds", level: rules.LintError, message: "环境 default 的阈值 high"},
+		{ruleID: "compound-child", level: rules.LintError, path: "not.any[1]", message: "max_port"},
+		{ruleID: "unknown-kind", level: rules.LintError, message: "未知的资源类型"},
+	}
+
+	for _, want := range expected {
+		found := false
+		for _, issue := range issues {
+			if issue.RuleID == want.ruleID && issue.Level == want.level && issue.Path == want.path &&
+				strings.Contains(issue.Message, want.message) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("未找到规则 %s 的问题 %q，实际: %v", want.ruleID, want.message, issues)
+		}
+	}
+
+	for _, issue := range issues {
+		if issue.RuleID == "valid-rule" || issue.RuleID == "compound-node" || (issue.RuleID == "env-thresholds" && strings.Contains(issue.Message, `"production"`)) {
+			t.Errorf("不应报告问题: %s", issue)
+		}
+	}
+	if len(issues) != len(expected) {
+		t.Errorf("期望 %d 个问题，实际 %d 个: %v", len(expected), len(issues), issues)
+	}
+}
+
+// TestEmbeddedRulePacksLint 测试内置规则包通过检查
+func TestEmbeddedRulePacksLint(t *testing.T) {
+	packs, err := configs.RulePacks()
+	if err != nil {
+		t.Fatalf("读取内置规则包失败: %v", err)
+	}
+	for _, pack := range packs {
+		loader := rules.NewRuleLoaderFromFS(configs.RulesFS, configs.RulePackPath(pack))
+		if err := loader.LoadRules(); err != nil {
+			t.Fatalf("加载规则包 %s 失败: %v", pack, err)
+		}
+		for _, issue := range rules.LintConfig(loader.GetRulesConfig()) {
+			t.Errorf("规则包 %s: %s", pack, issue)
+		}
+	}
+}
+
+// TestMetricCatalogMatchesAnalyzers 测试指标目录与分析器实际返回的指标类型一致
+func TestMetricCatalogMatchesAnalyzers(t *testing.T) {
+	// jsonpath 指标由分析器返回原始对象
+	expectedType := func(spec rules.MetricSpec) string {
+		if spec.Type == rules.JSONPathMetric {
+			return rules.ObjectMetricType
+		}
+		return spec.Type
+	}
+	object := map[string]interface{}{}
+
+	for _, spec := range rules.CatalogMetrics("deployment") {
+		_, metricType, ok := deployment.GetMetricValue(models.Deployment{Object: object}, spec.Name)
+		if !ok || metricType != expectedType(spec) {
+			t.Errorf("deployment 指标 %s: 目录类型 %s，分析器返回 %s (存在: %v)", spec.Name, spec.Type, metricType, ok)
+		}
+	}
+
+	analyzer := service.NewServiceAnalyzer(nil, nil)
+	for _, spec := range rules.CatalogMetrics("service") {
+		_, metricType, ok := analyzer.GetMetricValue(&models.Service{Object: object}, spec.Name)
+		if !ok || metricType != expectedType(spec) {
+			t.Errorf("service 指标 %s: 目录类型 %s，分析器返回 %s (存在: %v)", spec.Name, spec.Type, metricType, ok)
+		}
+	}
+}
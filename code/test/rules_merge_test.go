@@ -0,0 +1,149 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// TestRulesDirectoryMerge 测试从目录加载多个规则文件并按ID覆盖和禁用规则
+func TestRulesDirectoryMerge(t *testing.T) {
+	engine, err := rules.NewEngine(filepath.Join("testdata", "rules_layered"))
+	if err != nil {
+		t.Fatalf("加载规则目录失败: %v", err)
+	}
+
+	all := engine.GetRules(rules.RuleFilter{})
+	if len(all) != 2 {
+		t.Fatalf("期望合并后有2条规则，实际 %d", len(all))
+	}
+
+	// 后加载的文件按ID覆盖规则，且保持原有顺序
+	if all[0].ID != "min-replicas" || all[0].Severity != "info" || all[0].Condition.Threshold != 1 {
+		t.Errorf("min-replicas 应被团队规则覆盖，实际 %+v", all[0])
+	}
+	// 只包含 id 和 enabled 的条目禁用规则
+	if all[1].ID != "require-owner-label" || all[1].Enabled {
+		t.Errorf("require-owner-label 应被禁用，实际 %+v", all[1])
+	}
+	enabled := true
+	if enabledRules := engine.GetRules(rules.RuleFilter{Enabled: &enabled}); len(enabledRules) != 1 {
+		t.Errorf("期望1条启用的规则，实际 %d", len(enabledRules))
+	}
+
+	// 集群环境映射按文件合并
+	if env := engine.DetermineEnvironment("prod-cluster"); env != "prod" {
+		t.Errorf("prod-cluster 环境应为 prod，实际 %s", env)
+	}
+	if env := engine.DetermineEnvironment("dev-cluster"); env != "dev" {
+		t.Errorf("dev-cluster 环境应为 dev，实际 %s", env)
+	}
+}
+
+// TestRulesMultipleFiles 测试按参数顺序合并多个规则文件
+func TestRulesMultipleFiles(t *testing.T) {
+	org := filepath.Join("testdata", "rules_layered", "00-org.yaml")
+	team := filepath.Join("testdata", "rules_layered", "10-team.yaml")
+
+	// 反向顺序时组织规则覆盖团队规则，但禁用条目引用的规则尚未定义
+	if _, err := rules.NewEngine(team, org); err == nil || !strings.Contains(err.Error(), "无法启用或禁用") {
+		t.Errorf("禁用未定义的规则应返回错误，实际: %v", err)
+	}
+
+	engine, err := rules.NewEngine(org, team, org)
+	if err != nil {
+		t.Fatalf("加载规则文件失败: %v", err)
+	}
+	for _, rule := range engine.GetRules(rules.RuleFilter{}) {
+		if rule.ID == "min-replicas" && rule.Severity != "warning" {
+			t.Errorf("最后加载的组织规则应覆盖团队规则，实际严重程度 %s", rule.Severity)
+		}
+	}
+}
+
+// TestRulesDuplicateID 测试同一文档内规则ID重复时报错
+func TestRulesDuplicateID(t *testing.T) {
+	content := `apiVersion: inspector.k8s/v1
+kind: RulesConfig
+rules:
+  - id: "dup"
+    name: "规则一"
+    category: "deployment"
+    severity: "warning"
+    condition: {metric: "replicas", operator: ">=", threshold: 2}
+    enabled: true
+  - id: "dup"
+    name: "规则二"
+    category: "deployment"
+    severity: "warning"
+    condition: {metric: "replicas", operator: ">=", threshold: 3}
+    enabled: true
+`
+	file := filepath.Join(t.TempDir(), "dup.yaml")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("写入规则文件失败: %v", err)
+	}
+	_, err := rules.NewEngine(file)
+	if err == nil || !strings.Contains(err.Error(), "规则ID重复: dup") || !strings.Contains(err.Error(), file) {
+		t.Errorf("期望包含文件路径的重复ID错误，实际: %v", err)
+	}
+}
+
+// TestRulesOverrideCategoryMismatch 测试后续文档以不同类别重新定义同ID规则时报错，而不是静默覆盖
+func TestRulesOverrideCategoryMismatch(t *testing.T) {
+	content := `apiVersion: inspector.k8s/v1
+kind: RulesConfig
+rules:
+  - id: "owner"
+    name: "Deployment owner标签"
+    category: "deployment"
+    severity: "warning"
+    condition: {metric: "replicas", operator: ">=", threshold: 2}
+    enabled: true
+---
+apiVersion: inspector.k8s/v1
+kind: RulesConfig
+rules:
+  - id: "owner"
+    name: "Service owner标签"
+    category: "service"
+    severity: "warning"
+    condition: {metric: "has_selector", operator: "==", threshold: true}
+    enabled: true
+`
+	file := filepath.Join(t.TempDir(), "mismatch.yaml")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("写入规则文件失败: %v", err)
+	}
+	_, err := rules.NewEngine(file)
+	if err == nil || !strings.Contains(err.Error(), "'owner'") || !strings.Contains(err.Error(), "deployment") || !strings.Contains(err.Error(), "service") {
+		t.Errorf("期望类别不一致的覆盖错误，实际: %v", err)
+	}
+}
+
+// TestRulesToggleRenamedRule 测试启用或禁用已改名的内置规则时，错误中提示新的规则ID
+func TestRulesToggleRenamedRule(t *testing.T) {
+	content := `apiVersion: inspector.k8s/v1
+kind: RulesConfig
+rules:
+  - id: "min-replicas"
+    name: "副本数检查"
+    category: "deployment"
+    severity: "warning"
+    condition: {metric: "replicas", operator: ">=", threshold: 2}
+    enabled: true
+  - id: "require_owner_label"
+    enabled: false
+`
+	file := filepath.Join(t.TempDir(), "renamed.yaml")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("写入规则文件失败: %v", err)
+	}
+	_, err := rules.NewEngine(file)
+	if err == nil || !strings.Contains(err.Error(), "deployment_require_owner_label") || !strings.Contains(err.Error(), "service_require_owner_label") {
+		t.Errorf("期望提示新规则ID的错误，实际: %v", err)
+	}
+}
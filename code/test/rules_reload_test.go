@@ -0,0 +1,162 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/rules"
+)
+
+// reloadRulesTemplate 自动重载测试使用的规则文件模板
+const reloadRulesTemplate = `apiVersion: inspector.k8s/v1
+kind: RulesConfig
+config:
+  autoReload: true
+  reloadInterval: "20ms"
+rules:
+  - id: "min-replicas"
+    name: "副本数检查"
+    category: "deployment"
+    severity: "warning"
+    condition:
+      metric: "replicas"
+      operator: ">="
+      threshold: THRESHOLD
+    enabled: true
+`
+
+// writeRulesFile 写入规则文件，并推进修改时间以保证变更可被检测到
+func writeRulesFile(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("写入规则文件失败: %v", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("修改文件时间失败: %v", err)
+	}
+}
+
+// TestRulesReload 测试规则文件变更后的重载以及无效配置的处理
+func TestRulesReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	base := time.Now().Add(-time.Hour)
+	writeRulesFile(t, path, strings.Replace(reloadRulesTemplate, "THRESHOLD", "2", 1), base)
+
+	engine, err := rules.NewEngine(path)
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+	threshold := func() interface{} {
+		return engine.GetRules(rules.RuleFilter{})[0].Condition.Threshold
+	}
+
+	// 文件未变更时不产生事件
+	if event := engine.Reload(); event != nil {
+		t.Errorf("文件未变更时不应产生重载事件: %+v", event)
+	}
+
+	// 文件变更后替换规则
+	writeRulesFile(t, path, strings.Replace(reloadRulesTemplate, "THRESHOLD", "3", 1), base.Add(time.Minute))
+	event := engine.Reload()
+	if event == nil || !event.Reloaded || event.Err != nil || event.RuleCount != 1 {
+		t.Fatalf("期望成功重载事件，实际 %+v", event)
+	}
+	if threshold() != 3 {
+		t.Errorf("重载后阈值应为3，实际 %v", threshold())
+	}
+
+	// 新配置无效时保留原有规则，且同一版本只报告一次
+	writeRulesFile(t, path, strings.Replace(reloadRulesTemplate, `operator: ">="`, `operator: "~"`, 1), base.Add(2*time.Minute))
+	event = engine.Reload()
+	if event == nil || event.Reloaded || event.Err == nil {
+		t.Fatalf("期望重载失败事件，实际 %+v", event)
+	}
+	if threshold() != 3 {
+		t.Errorf("重载失败时应保留原有规则，实际阈值 %v", threshold())
+	}
+	if event := engine.Reload(); event != nil {
+		t.Errorf("同一个无效版本不应重复报告: %+v", event)
+	}
+}
+
+// TestRulesAutoReload 测试按配置的间隔在后台自动重载
+func TestRulesAutoReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	base := time.Now().Add(-time.Hour)
+	writeRulesFile(t, path, strings.Replace(reloadRulesTemplate, "THRESHOLD", "2", 1), base)
+
+	engine, err := rules.NewEngine(path)
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+
+	events := make(chan rules.ReloadEvent, 1)
+	stop, err := engine.StartAutoReload(func(event rules.ReloadEvent) {
+		events <- event
+	})
+	if err != nil {
+		t.Fatalf("启动自动重载失败: %v", err)
+	}
+	defer stop()
+
+	writeRulesFile(t, path, strings.Replace(reloadRulesTemplate, "THRESHOLD", "5", 1), base.Add(time.Minute))
+	select {
+	case event := <-events:
+		if !event.Reloaded || event.File != path {
+			t.Errorf("期望成功重载事件，实际 %+v", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("等待自动重载超时")
+	}
+	if got := engine.GetRules(rules.RuleFilter{})[0].Condition.Threshold; got != 5 {
+		t.Errorf("自动重载后阈值应为5，实际 %v", got)
+	}
+}
+
+// TestReloadWaitsForPass 测试重载等待正在进行的分析结束后再替换规则
+func TestReloadWaitsForPass(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	base := time.Now().Add(-time.Hour)
+	writeRulesFile(t, path, strings.Replace(reloadRulesTemplate, "THRESHOLD", "2", 1), base)
+
+	engine, err := rules.NewEngine(path)
+	if err != nil {
+		t.Fatalf("加载规则引擎失败: %v", err)
+	}
+	threshold := func() interface{} {
+		return engine.GetRules(rules.RuleFilter{})[0].Condition.Threshold
+	}
+	writeRulesFile(t, path, strings.Replace(reloadRulesTemplate, "THRESHOLD", "3", 1), base.Add(time.Minute))
+
+	reloaded := make(chan *rules.ReloadEvent, 1)
+	err = engine.Pass(func() error {
+		go func() { reloaded <- engine.Reload() }()
+		select {
+		case <-reloaded:
+			t.Error("分析过程中不应替换规则")
+		case <-time.After(100 * time.Millisecond):
+		}
+		if threshold() != 2 {
+			t.Errorf("分析过程中阈值应保持为2，实际 %v", threshold())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("执行分析失败: %v", err)
+	}
+
+	select {
+	case event := <-reloaded:
+		if event == nil || !event.Reloaded {
+			t.Errorf("期望分析结束后成功重载，实际 %+v", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("等待重载超时")
+	}
+	if threshold() != 3 {
+		t.Errorf("重载后阈值应为3，实际 %v", threshold())
+	}
+}
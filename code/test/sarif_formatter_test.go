@@ -0,0 +1,173 @@
+package test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/waiver"
+)
+
+// sarifOutput 测试中读取的 SARIF 字段
+type sarifOutput struct {
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Name  string `json:"name"`
+				Rules []struct {
+					ID   string `json:"id"`
+					Help *struct {
+						Text string `json:"text"`
+					} `json:"help"`
+					DefaultConfiguration struct {
+						Level string `json:"level"`
+					} `json:"defaultConfiguration"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID    string `json:"ruleId"`
+			RuleIndex int    `json:"ruleIndex"`
+			Level     string `json:"level"`
+			Locations []struct {
+				PhysicalLocation *struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region *struct {
+						StartLine int `json:"startLine"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+				LogicalLocations []struct {
+					FullyQualifiedName string `json:"fullyQualifiedName"`
+				} `json:"logicalLocations"`
+			} `json:"locations"`
+			Suppressions []struct {
+				Kind          string `json:"kind"`
+				Justification string `json:"justification"`
+			} `json:"suppressions"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+// newSARIFReport 构造一个包含清单发现项、集群发现项和被豁免发现项的报告
+func newSARIFReport() *report.Report {
+	return &report.Report{
+		Rules: []report.RuleInfo{
+			{ID: "deployment-min-replicas", Name: "副本数不少于2", Severity: report.SeverityWarning, Remediation: "将副本数设置为2及以上"},
+			{ID: "pod-missing-probes", Name: "缺少探针", Severity: report.SeverityInfo, Remediation: "配置探针"},
+			{ID: "unused-rule", Name: "未触发的规则", Severity: report.SeverityCritical},
+		},
+		Findings: []report.Finding{
+			{
+				ResourceName: "web", ResourceKind: "Deployment", RuleID: "deployment-min-replicas",
+				Message: "副本数不足", Severity: report.SeverityWarning,
+				Details: map[string]interface{}{"namespace": "shop"},
+				Source:  &report.SourceLocation{File: "./deploy/app.yaml", Line: 3},
+			},
+			{
+				ResourceName: "shop/web-1", ResourceKind: "Pod", RuleID: "pod-missing-probes",
+				Message: "缺少健康检查探针", Severity: report.SeverityInfo,
+			},
+			{
+				ResourceName: "node-1", ResourceKind: "Node", RuleID: report.RuleWaiverExpired,
+				Message: "豁免已过期", Severity: report.SeverityWarning, Recommendation: "延长豁免",
+			},
+		},
+		Suppressed: []report.SuppressedFinding{{
+			Finding: report.Finding{ResourceName: "shop/web-2", ResourceKind: "Pod", RuleID: "pod-missing-probes", Message: "缺少健康检查探针", Severity: report.SeverityInfo},
+			Waiver:  waiver.Waiver{RuleID: "pod-missing-probes", Reason: "批处理任务"},
+		}},
+	}
+}
+
+// TestSARIFFormatter 测试 SARIF 输出的规则描述、结果位置和豁免
+func TestSARIFFormatter(t *testing.T) {
+	formatter, err := report.NewFormatter("sarif", false)
+	if err != nil {
+		t.Fatalf("创建SARIF格式化器失败: %v", err)
+	}
+	output := formatter.Format(newSARIFReport())
+
+	var log sarifOutput
+	if err := json.Unmarshal([]byte(output), &log); err != nil {
+		t.Fatalf("SARIF输出无法解析: %v\n%s", err, output)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || log.Runs[0].Tool.Driver.Name == "" {
+		t.Fatalf("SARIF日志头部不符合预期:\n%s", output)
+	}
+	run := log.Runs[0]
+
+	// 报告中评估的每条规则对应一个描述，只出现在发现项中的规则补充描述
+	ids := make([]string, 0, len(run.Tool.Driver.Rules))
+	for _, rule := range run.Tool.Driver.Rules {
+		ids = append(ids, rule.ID)
+	}
+	expectedIDs := "deployment-min-replicas,pod-missing-probes,unused-rule," + report.RuleWaiverExpired
+	if strings.Join(ids, ",") != expectedIDs {
+		t.Errorf("期望规则 %s，实际 %s", expectedIDs, strings.Join(ids, ","))
+	}
+	first := run.Tool.Driver.Rules[0]
+	if first.Help == nil || first.Help.Text != "将副本数设置为2及以上" || first.DefaultConfiguration.Level != "warning" {
+		t.Errorf("规则描述应以修复建议作为帮助文本: %+v", first)
+	}
+	if expired := run.Tool.Driver.Rules[3]; expired.Help == nil || expired.Help.Text != "延长豁免" {
+		t.Errorf("补充的规则描述应使用发现项的修复建议: %+v", expired)
+	}
+	if level := run.Tool.Driver.Rules[2].DefaultConfiguration.Level; level != "error" {
+		t.Errorf("CRITICAL 规则的级别应为 error，实际 %s", level)
+	}
+
+	if len(run.Results) != 4 {
+		t.Fatalf("期望4个结果，实际 %d", len(run.Results))
+	}
+
+	// 清单中的发现项位于文件和行号
+	manifestResult := run.Results[0]
+	location := manifestResult.Locations[0]
+	if location.PhysicalLocation == nil || location.PhysicalLocation.ArtifactLocation.URI != "deploy/app.yaml" ||
+		location.PhysicalLocation.Region == nil || location.PhysicalLocation.Region.StartLine != 3 {
+		t.Errorf("清单发现项的位置不符合预期:\n%s", output)
+	}
+	if location.LogicalLocations[0].FullyQualifiedName != "shop/Deployment/web" {
+		t.Errorf("期望逻辑位置 shop/Deployment/web，实际 %s", location.LogicalLocations[0].FullyQualifiedName)
+	}
+
+	// 集群中的发现项只有逻辑位置
+	clusterResult := run.Results[1]
+	if clusterResult.Locations[0].PhysicalLocation != nil || clusterResult.Locations[0].LogicalLocations[0].FullyQualifiedName != "shop/Pod/web-1" {
+		t.Errorf("集群发现项的位置不符合预期:\n%s", output)
+	}
+	if clusterResult.RuleIndex != 1 || clusterResult.Level != "note" {
+		t.Errorf("期望规则索引1、级别 note，实际 %d、%s", clusterResult.RuleIndex, clusterResult.Level)
+	}
+	if name := run.Results[2].Locations[0].LogicalLocations[0].FullyQualifiedName; name != "Node/node-1" {
+		t.Errorf("集群级资源的逻辑位置应为 Node/node-1，实际 %s", name)
+	}
+
+	// 被豁免的发现项带有 suppressions
+	suppressed := run.Results[3]
+	if len(suppressed.Suppressions) != 1 || suppressed.Suppressions[0].Kind != "external" || suppressed.Suppressions[0].Justification != "批处理任务" {
+		t.Errorf("被豁免的发现项应带有 suppressions: %+v", suppressed)
+	}
+	if len(run.Results[0].Suppressions) != 0 {
+		t.Error("未豁免的发现项不应带有 suppressions")
+	}
+}
+
+// TestReportRulesNotInJSON 测试规则元数据不改变JSON报告的结构
+func TestReportRulesNotInJSON(t *testing.T) {
+	formatter, err := report.NewFormatter("json", false)
+	if err != nil {
+		t.Fatalf("创建JSON格式化器失败: %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(formatter.Format(newSARIFReport())), &doc); err != nil {
+		t.Fatalf("JSON输出无法解析: %v", err)
+	}
+	if _, exists := doc["rules"]; exists {
+		t.Error("JSON报告不应包含规则元数据")
+	}
+}
@@ -0,0 +1,287 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/collector"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/snapshot"
+)
+
+// TestSnapshotCollectors 测试采集器从快照目录读取节点、指标、Pod、Deployment、Service 和CRD资源
+func TestSnapshotCollectors(t *testing.T) {
+	client, err := snapshot.Load(filepath.Join("testdata", "snapshots", "prod-eu"))
+	if err != nil {
+		t.Fatalf("加载快照失败: %v", err)
+	}
+	if client.ContextName != "prod-eu" {
+		t.Errorf("快照名称期望 prod-eu，实际 %q", client.ContextName)
+	}
+	ctx := context.TODO()
+
+	nodeCollector, err := collector.NewNodeCollector(client)
+	if err != nil {
+		t.Fatalf("创建节点采集器失败: %v", err)
+	}
+	nodes, err := nodeCollector.GetNodes(ctx)
+	if err != nil {
+		t.Fatalf("获取节点失败: %v", err)
+	}
+	if len(nodes.Items) != 1 || nodes.Items[0].Name != "node-1" {
+		t.Fatalf("期望1个节点 node-1，实际: %+v", nodes.Items)
+	}
+	if node := nodes.Items[0]; node.CPU.Used != 2 || node.RunningPods != 1 {
+		t.Errorf("节点指标错误: CPU已使用 %v，运行中Pod %d", node.CPU.Used, node.RunningPods)
+	}
+
+	podCollector, err := collector.NewPodCollector(client)
+	if err != nil {
+		t.Fatalf("创建Pod采集器失败: %v", err)
+	}
+	pods, err := podCollector.GetPods(ctx, "shop")
+	if err != nil {
+		t.Fatalf("获取Pod失败: %v", err)
+	}
+	if len(pods.Items) != 1 || pods.Items[0].Name != "web-1" {
+		t.Errorf("期望1个Pod web-1，实际: %d", len(pods.Items))
+	}
+
+	deployments, err := collector.NewDeploymentCollector(client).GetDeployments(ctx, "")
+	if err != nil {
+		t.Fatalf("获取Deployment失败: %v", err)
+	}
+	if len(deployments) != 1 || deployments[0].Replicas != 1 {
+		t.Errorf("期望1个副本数为1的Deployment，实际: %+v", deployments)
+	}
+
+	services, err := collector.NewServiceCollector(client).GetServices(ctx, "shop")
+	if err != nil {
+		t.Fatalf("获取Service失败: %v", err)
+	}
+	if len(services) != 1 || services[0].ReadyEndpoints != 1 || len(services[0].MatchingPods) != 1 {
+		t.Errorf("期望Service web有1个就绪端点和1个匹配Pod，实际: %+v", services)
+	}
+
+	gvk := schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+	resources, err := collector.NewResourceCollector(client).GetResources(ctx, gvk, "")
+	if err != nil {
+		t.Fatalf("获取CRD资源失败: %v", err)
+	}
+	if len(resources) != 1 || resources[0].Namespace != "shop" {
+		t.Errorf("期望 shop 命名空间中1个Certificate，实际: %+v", resources)
+	}
+}
+
+// TestSnapshotOverride 测试同一对象出现多次时以后读取的文件为准
+func TestSnapshotOverride(t *testing.T) {
+	dir := t.TempDir()
+	deployment := func(replicas string) string {
+		return `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  replicas: ` + replicas + `
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+        - name: web
+          image: nginx
+`
+	}
+	files := map[string]string{"a.yaml": deployment("1"), "b.yml": deployment("3"), "notes.txt": "ignored"}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("写入快照文件失败: %v", err)
+		}
+	}
+
+	client, err := snapshot.Load(dir)
+	if err != nil {
+		t.Fatalf("加载快照失败: %v", err)
+	}
+	deployments, err := collector.NewDeploymentCollector(client).GetDeployments(context.TODO(), "")
+	if err != nil {
+		t.Fatalf("获取Deployment失败: %v", err)
+	}
+	if len(deployments) != 1 || deployments[0].Replicas != 3 {
+		t.Errorf("期望使用 b.yml 中副本数为3的Deployment，实际: %+v", deployments)
+	}
+}
+
+// TestSnapshotInvalid 测试无效快照的错误
+func TestSnapshotInvalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		errPart string
+	}{
+		{name: "缺少kind", content: "apiVersion: v1\nmetadata:\n  name: web\n", errPart: "缺少 apiVersion 或 kind"},
+		{name: "列表项缺少名称", content: "apiVersion: v1\nkind: List\nitems:\n  - apiVersion: v1\n    kind: Pod\n", errPart: "items[0]"},
+		{name: "没有对象", content: "# empty\n", errPart: "没有Kubernetes对象"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "snapshot.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatalf("写入快照文件失败: %v", err)
+			}
+			_, err := snapshot.Load(path)
+			if err == nil || !strings.Contains(err.Error(), tc.errPart) {
+				t.Errorf("期望包含 %q 的错误，实际: %v", tc.errPart, err)
+			}
+		})
+	}
+}
+
+// TestSnapshotCaptureRoundTrip 测试采集的快照归档可以离线加载，清单中的集群名称生效且默认脱敏
+func TestSnapshotCaptureRoundTrip(t *testing.T) {
+	objects := make([]runtime.Object, 0)
+	for _, file := range []string{"metrics.json", "nodes.json", "workloads.yaml"} {
+		decoded, err := snapshot.DecodeFile(filepath.Join("testdata", "snapshots", "prod-eu", file))
+		if err != nil {
+			t.Fatalf("解析快照文件失败: %v", err)
+		}
+		objects = append(objects, decoded...)
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-1",
+			Namespace: "shop",
+			Annotations: map[string]string{
+				corev1.LastAppliedConfigAnnotation: `{"spec":{}}`,
+				"example.com/db-password":          "hunter2",
+				"example.com/owner":                "team-a",
+			},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:  "api",
+			Image: "api:1.0",
+			Env: []corev1.EnvVar{
+				{Name: "DB_PASSWORD", Value: "hunter2"},
+				{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{Key: "token"}}},
+			},
+		}}},
+	}
+	source, err := snapshot.NewClient("prod-eu", append(objects, pod))
+	if err != nil {
+		t.Fatalf("构造快照客户端失败: %v", err)
+	}
+
+	for _, redact := range []bool{true, false} {
+		path := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+		file, err := os.Create(path)
+		if err != nil {
+			t.Fatalf("创建快照文件失败: %v", err)
+		}
+		manifest, err := snapshot.Capture(context.TODO(), source, file, snapshot.CaptureOptions{ClusterName: "prod-eu-1", Redact: redact})
+		file.Close()
+		if err != nil {
+			t.Fatalf("采集快照失败: %v", err)
+		}
+		if manifest.Resources["pods"] != 2 || manifest.Resources["node-metrics"] != 1 || manifest.Redacted != redact {
+			t.Errorf("快照清单不符合预期: %+v", manifest)
+		}
+
+		client, err := snapshot.Load(path)
+		if err != nil {
+			t.Fatalf("加载快照归档失败: %v", err)
+		}
+		if client.ContextName != "prod-eu-1" {
+			t.Errorf("集群名称期望使用清单中的 prod-eu-1，实际 %q", client.ContextName)
+		}
+		loaded, err := client.GetRawPod(context.TODO(), "shop", "api-1")
+		if err != nil {
+			t.Fatalf("快照中没有Pod api-1: %v", err)
+		}
+
+		annotations, env := loaded.Annotations, loaded.Spec.Containers[0].Env
+		if redact {
+			_, hasLastApplied := annotations[corev1.LastAppliedConfigAnnotation]
+			if hasLastApplied || annotations["example.com/db-password"] != snapshot.RedactedValue || annotations["example.com/owner"] != "team-a" {
+				t.Errorf("注解脱敏不符合预期: %v", annotations)
+			}
+			if env[0].Value != snapshot.RedactedValue || env[1].ValueFrom == nil {
+				t.Errorf("环境变量脱敏不符合预期: %+v", env)
+			}
+		} else if annotations["example.com/db-password"] != "hunter2" || env[0].Value != "hunter2" {
+			t.Errorf("--no-redact 时应保留原始值: %v %+v", annotations, env)
+		}
+	}
+}
+
+// TestSnapshotManifestVersion 测试不支持的快照清单版本
+func TestSnapshotManifestVersion(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		snapshot.ManifestFile: `{"apiVersion": "inspector.k8s/v2", "kind": "ClusterSnapshot", "clusterName": "prod"}`,
+		"nodes.yaml":          "apiVersion: v1\nkind: Node\nmetadata:\n  name: node-1\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("写入快照文件失败: %v", err)
+		}
+	}
+	if _, err := snapshot.Load(dir); err == nil || !strings.Contains(err.Error(), "不支持的快照格式") {
+		t.Errorf("期望快照格式不支持的错误，实际: %v", err)
+	}
+}
+
+// TestSnapshotPodEvents 测试离线分析时每个Pod只读取关联到自身的事件
+func TestSnapshotPodEvents(t *testing.T) {
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"}}
+	}
+	newEvent := func(name, kind, podName, reason string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Namespace: "shop", Name: podName},
+			Reason:         reason,
+		}
+	}
+	client, err := snapshot.NewClient("shop", []runtime.Object{
+		newPod("web-1"),
+		newPod("web-2"),
+		newEvent("web-1.a", "Pod", "web-1", "BackOff"),
+		newEvent("web-2.a", "Pod", "web-2", "Unhealthy"),
+		newEvent("web-2.b", "Pod", "web-2", "Killing"),
+		newEvent("web-1.rs", "ReplicaSet", "web-1", "SuccessfulCreate"),
+	})
+	if err != nil {
+		t.Fatalf("构造快照客户端失败: %v", err)
+	}
+
+	expected := map[string][]string{
+		"web-1": {"BackOff"},
+		"web-2": {"Unhealthy", "Killing"},
+	}
+	for podName, reasons := range expected {
+		events, err := client.GetRawPodEvents(context.TODO(), "shop", podName)
+		if err != nil {
+			t.Fatalf("读取Pod %s 的事件失败: %v", podName, err)
+		}
+		actual := make([]string, 0, len(events))
+		for _, event := range events {
+			actual = append(actual, event.Reason)
+		}
+		if strings.Join(actual, ",") != strings.Join(reasons, ",") {
+			t.Errorf("Pod %s 期望事件 %v，实际 %v", podName, reasons, actual)
+		}
+	}
+}
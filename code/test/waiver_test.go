@@ -0,0 +1,186 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/report"
+	"github.com/FreshMan1123/k8s-resource-inspector/code/internal/waiver"
+)
+
+// newWaiverTestReport 构造包含多个资源发现项的报告
+func newWaiverTestReport() *report.Report {
+	r := &report.Report{
+		Summary: report.ReportSummary{
+			TotalResources:      3,
+			ResourcesWithIssues: 3,
+			FindingCounts:       map[report.Severity]int{report.SeverityWarning: 3},
+		},
+	}
+	r.Findings = []report.Finding{
+		{
+			ResourceName: "pod batch/report-20240101",
+			ResourceKind: "Pod",
+			RuleID:       "pod-missing-probes",
+			Severity:     report.SeverityWarning,
+			Details:      map[string]interface{}{"namespace": "batch"},
+		},
+		{
+			ResourceName: "default/legacy-api",
+			ResourceKind: "Deployment",
+			RuleID:       "min-replicas",
+			Severity:     report.SeverityWarning,
+			Details:      map[string]interface{}{"namespace": "default"},
+		},
+		{
+			ResourceName: "worker-1",
+			ResourceKind: "Node",
+			RuleID:       "node-high-cpu",
+			Severity:     report.SeverityWarning,
+		},
+	}
+	return r
+}
+
+// TestWaiverFileSuppression 测试豁免文件中有效豁免抑制发现项，过期豁免产生新的发现项
+func TestWaiverFileSuppression(t *testing.T) {
+	waivers, err := waiver.LoadFile(filepath.Join("testdata", "waivers", "waivers.yaml"))
+	if err != nil {
+		t.Fatalf("加载豁免文件失败: %v", err)
+	}
+
+	r := newWaiverTestReport()
+	report.ApplyWaivers(r, waivers, time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local))
+
+	if len(r.Suppressed) != 1 || r.Suppressed[0].RuleID != "pod-missing-probes" || r.Suppressed[0].Waiver.Owner != "team-data" {
+		t.Fatalf("期望Pod探针发现项被豁免，实际 %+v", r.Suppressed)
+	}
+	if r.Summary.Suppressed != 1 {
+		t.Errorf("期望被豁免数量为1，实际 %d", r.Summary.Suppressed)
+	}
+
+	var ruleIDs []string
+	for _, finding := range r.Findings {
+		ruleIDs = append(ruleIDs, finding.RuleID)
+	}
+	if got := strings.Join(ruleIDs, ","); got != "min-replicas,node-high-cpu,"+report.RuleWaiverExpired {
+		t.Errorf("过期豁免不应抑制发现项并应产生过期发现项，实际 %s", got)
+	}
+	expired := r.Findings[len(r.Findings)-1]
+	if expired.ResourceName != "default/legacy-api" || !strings.Contains(expired.Message, "2020-01-31") {
+		t.Errorf("过期豁免发现项内容错误: %+v", expired)
+	}
+
+	// 被豁免的发现项不计入严重性统计，全部被豁免的资源不再计为有问题
+	if r.Summary.FindingCounts[report.SeverityWarning] != 3 {
+		t.Errorf("期望WARNING数量为3，实际 %d", r.Summary.FindingCounts[report.SeverityWarning])
+	}
+	if r.Summary.ResourcesWithIssues != 2 {
+		t.Errorf("期望有问题的资源数量为2，实际 %d", r.Summary.ResourcesWithIssues)
+	}
+
+	// 文本报告单独列出被豁免的发现项
+	output := report.NewTextFormatter(false).Format(r)
+	if !strings.Contains(output, "SUPPRESSED FINDINGS") || !strings.Contains(output, "Waived by: team-data") {
+		t.Errorf("文本报告缺少豁免部分:\n%s", output)
+	}
+}
+
+// TestWaiverAnnotations 测试资源注解中的豁免
+func TestWaiverAnnotations(t *testing.T) {
+	waivers := waiver.NewSet()
+	waivers.AddAnnotations("Node", "worker-1", map[string]string{
+		waiver.AnnotationPrefix + "node-high-cpu": `{"reason": "压测节点", "owner": "team-perf", "expires": "2099-01-01"}`,
+		"unrelated": "value",
+	})
+	waivers.AddAnnotations("Deployment", "default/legacy-api", map[string]string{
+		waiver.AnnotationPrefix + "min-replicas": `{"reason": "缺少负责人"}`,
+	})
+
+	r := newWaiverTestReport()
+	report.ApplyWaivers(r, waivers, time.Now())
+
+	if len(r.Suppressed) != 1 || r.Suppressed[0].ResourceName != "worker-1" || r.Suppressed[0].Waiver.Source != waiver.SourceAnnotation {
+		t.Fatalf("期望节点发现项被注解豁免，实际 %+v", r.Suppressed)
+	}
+
+	var invalid *report.Finding
+	for i, finding := range r.Findings {
+		if finding.RuleID == report.RuleWaiverInvalid {
+			invalid = &r.Findings[i]
+		}
+		if finding.RuleID == "min-replicas" && finding.ResourceName != "default/legacy-api" {
+			t.Errorf("无效豁免不应抑制发现项")
+		}
+	}
+	if invalid == nil || !strings.Contains(invalid.Message, "owner") || !strings.Contains(invalid.Message, "expires") {
+		t.Errorf("缺少必填字段的注解应报告为无效豁免，实际 %+v", invalid)
+	}
+}
+
+// TestWaiverFileValidation 测试豁免文件中缺少必填字段或日期格式错误时报错
+func TestWaiverFileValidation(t *testing.T) {
+	testCases := []struct {
+		name   string
+		waiver string
+		errMsg string
+	}{
+		{"缺少负责人", `{rule: "min-replicas", resource: "default/api", reason: "迁移中", expires: "2099-01-01"}`, "owner"},
+		{"日期格式错误", `{rule: "min-replicas", resource: "default/api", reason: "迁移中", owner: "team-a", expires: "01/02/2099"}`, "YYYY-MM-DD"},
+		{"资源模式包含多个斜杠", `{rule: "min-replicas", resource: "default/api/v1", reason: "迁移中", owner: "team-a", expires: "2099-01-01"}`, "namespace/name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := "apiVersion: inspector.k8s/v1\nkind: WaiverConfig\nwaivers:\n  - " + tc.waiver + "\n"
+			path := filepath.Join(t.TempDir(), "waivers.yaml")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("写入豁免文件失败: %v", err)
+			}
+			if _, err := waiver.LoadFile(path); err == nil || !strings.Contains(err.Error(), tc.errMsg) {
+				t.Errorf("期望错误包含 %q，实际: %v", tc.errMsg, err)
+			}
+		})
+	}
+}
+
+// TestWaiverResourcePatterns 测试资源匹配模式按命名空间和名称分别匹配
+func TestWaiverResourcePatterns(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		resource string
+		matched  bool
+	}{
+		{"*", "default/web", true},
+		{"*", "worker-1", true},
+		{"web-*", "default/web-1", true},
+		{"web-*", "web-1", true},
+		{"default/*", "default/web", true},
+		{"default/*", "kube-system/web", false},
+		{"*/web", "shop/web", true},
+		{"team-?/api", "team-a/api", true},
+		{"default/web", "default/web", true},
+		{"default/web", "default/web-1", false},
+		{"default/*", "worker-1", false},
+	}
+
+	for _, tc := range testCases {
+		content := "apiVersion: inspector.k8s/v1\nkind: WaiverConfig\nwaivers:\n" +
+			"  - {rule: \"min-replicas\", resource: \"" + tc.pattern + "\", reason: \"测试\", owner: \"team-a\", expires: \"2099-01-01\"}\n"
+		path := filepath.Join(t.TempDir(), "waivers.yaml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("写入豁免文件失败: %v", err)
+		}
+		waivers, err := waiver.LoadFile(path)
+		if err != nil {
+			t.Fatalf("加载豁免文件失败: %v", err)
+		}
+		w, _ := waivers.Lookup("min-replicas", "Deployment", tc.resource, time.Now())
+		if (w != nil) != tc.matched {
+			t.Errorf("模式 %q 匹配 %q 期望 %v，实际 %v", tc.pattern, tc.resource, tc.matched, w != nil)
+		}
+	}
+}